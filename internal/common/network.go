@@ -0,0 +1,24 @@
+package common
+
+import (
+	"net"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// PublicAddressOnly is a net.Dialer Control that refuses connections to loopback, private and link local
+// addresses, so URLs submitted by users cannot reach internal services. It runs on every connection, after DNS
+// resolution and for each redirect.
+func PublicAddressOnly(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() {
+		return errors.Errorf("address %s is not public", host)
+	}
+	return nil
+}
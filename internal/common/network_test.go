@@ -0,0 +1,30 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestPublicAddressOnly(t *testing.T) {
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"10.1.2.3:443", false},
+		{"192.168.0.10:443", false},
+		{"169.254.169.254:80", false},
+		{"[::1]:443", false},
+		{"[fd00::1]:443", false},
+		{"0.0.0.0:80", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := PublicAddressOnly("tcp", tt.address, nil)
+			if (err == nil) != tt.allowed {
+				t.Errorf("PublicAddressOnly(%s) error = %v, allowed %v", tt.address, err, tt.allowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package common
+
+// RowString reads a text column from a row returned by a raw query, empty when it is null or not text
+func RowString(row map[string]any, key string) string {
+	value, _ := row[key].(string)
+	return value
+}
+
+// RowInt reads an integer column from a row returned by a raw query, 0 when it is null or not an integer
+func RowInt(row map[string]any, key string) int64 {
+	switch value := row[key].(type) {
+	case int64:
+		return value
+	case int32:
+		return int64(value)
+	case int:
+		return int64(value)
+	}
+	return 0
+}
+
+// RowFloat reads a floating point column from a row returned by a raw query, 0 when it is null or not a float
+func RowFloat(row map[string]any, key string) float64 {
+	switch value := row[key].(type) {
+	case float64:
+		return value
+	case float32:
+		return float64(value)
+	}
+	return 0
+}
@@ -1,3 +1,7 @@
 package constants
 
 type TagType int
+
+const (
+	TAG_TYPE_DOCUMENT TagType = iota + 1 // labels users put on their documents
+)
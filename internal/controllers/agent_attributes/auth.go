@@ -0,0 +1,120 @@
+package agent_attributes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent_attribute"
+	"github.com/pkg/errors"
+)
+
+// authGetByAgent returns the caller's attributes for an agent
+//
+//	@Public
+//	@Summary		Get agent attributes
+//	@Description	Returns the session account's customizations for an agent, empty when none are saved
+//	@Tags			AgentAttribute
+//	@Accept			json
+//	@Produce		json
+//	@Param			agent_id	path		string	true	"Agent ID"
+//	@Success		200			{object}	response.SuccessResponse{data=agent_attribute.AgentAttribute}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Router			/agent_attribute/agent/{agent_id} [get]
+func authGetByAgent(_ http.ResponseWriter, req *http.Request) (*agent_attribute.AgentAttribute, int, error) {
+	userSession := request.GetReqSession(req)
+
+	agentObj, err := getAgent(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*agent_attribute.AgentAttribute]()
+	}
+
+	attributeObj, err := agent_attribute.GetOrNew(req.Context(), userSession.User.ID(), agentObj.ID())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*agent_attribute.AgentAttribute]()
+	}
+
+	return response.Success(attributeObj)
+}
+
+// authUpdateByAgent merges the posted attributes into the caller's attributes for an agent
+//
+//	@Public
+//	@Summary		Update agent attributes
+//	@Description	Creates or updates the session account's customizations for an agent. Empty variable values remove the variable.
+//	@Tags			AgentAttribute
+//	@Accept			json
+//	@Produce		json
+//	@Param			agent_id	path		string							true	"Agent ID"
+//	@Param			body		body		agent_attribute.Attributes	true	"Attributes"
+//	@Success		200			{object}	response.SuccessResponse{data=agent_attribute.AgentAttribute}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Router			/agent_attribute/agent/{agent_id} [put]
+func authUpdateByAgent(_ http.ResponseWriter, req *http.Request) (*agent_attribute.AgentAttribute, int, error) {
+	userSession := request.GetReqSession(req)
+
+	input, err := request.GetJSONPostAs[*agent_attribute.Attributes](req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*agent_attribute.AgentAttribute]()
+	}
+
+	agentObj, err := getAgent(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*agent_attribute.AgentAttribute]()
+	}
+
+	attributeObj, err := agent_attribute.GetOrNew(req.Context(), userSession.User.ID(), agentObj.ID())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*agent_attribute.AgentAttribute]()
+	}
+
+	attributes, err := attributeObj.Attributes.Get()
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*agent_attribute.AgentAttribute]()
+	}
+
+	if attributes == nil {
+		attributes = &agent_attribute.Attributes{}
+	}
+	attributes.Merge(input)
+	attributeObj.Attributes.Set(attributes)
+
+	err = attributeObj.Save(userSession.User)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*agent_attribute.AgentAttribute]()
+	}
+
+	return response.Success(attributeObj)
+}
+
+func getAgent(req *http.Request) (*agent.Agent, error) {
+	agentID := chi.URLParam(req, "agent_id")
+	agentObj, err := agent.Get(req.Context(), types.UUID(agentID))
+	if err != nil {
+		return nil, err
+	}
+
+	if tools.Empty(agentObj) {
+		return nil, errors.Errorf("agent not found %s", agentID)
+	}
+
+	accountObj := helpers.GetLoadedUser(req)
+	if !agentObj.CanBeUsedBy(accountObj.OrganizationID.Get(), int64(accountObj.BillingPlanLevel.Get())) {
+		return nil, errors.Errorf("agent %s not available to account %s", agentID, accountObj.ID())
+	}
+
+	return agentObj, nil
+}
@@ -0,0 +1,17 @@
+package agent_attributes
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
+)
+
+func addSearch(parameters *model.Options, query string) {
+	if tools.IsAnyValidUUID(query) {
+		parameters.WithCondition("(%s.id = :id: OR %s.agent_id = :id: OR %s.account_id = :id:)", TABLE_NAME, TABLE_NAME, TABLE_NAME)
+		parameters.WithParam(":id:", query)
+		return
+	}
+
+	parameters.WithCondition("agents.name ILIKE :q:")
+	parameters.WithParam(":q:", "%"+query+"%")
+}
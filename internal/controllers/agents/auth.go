@@ -5,47 +5,52 @@ import (
 
 	"github.com/griffnb/core/lib/log"
 	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
-	"github.com/griffnb/techboss-ai-go/internal/environment"
-	"github.com/griffnb/techboss-ai-go/internal/models/organization"
-	"github.com/openai/openai-go/v3"
-	"github.com/openai/openai-go/v3/option"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/services/agent_service"
+	"github.com/pkg/errors"
 )
 
+// authSession starts a ChatKit session for an agent
+//
+//	@Public
+//	@Summary		Start ChatKit session
+//	@Description	Opens a ChatKit session for the agent's workflow and returns the client secret
+//	@Tags			Agent
+//	@Produce		json
+//	@Param			agent_id	query		string	true	"Agent ID"
+//	@Success		200			{object}	response.SuccessResponse{data=string}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Router			/agent/session [get]
 func authSession(_ http.ResponseWriter, req *http.Request) (string, int, error) {
 	userObj := helpers.GetLoadedUser(req)
-	client := openai.NewClient(
-		option.WithAPIKey(environment.GetConfig().AIKeys.OpenAI.APIKey),
-	)
 
-	org, err := organization.Get(req.Context(), userObj.OrganizationID.Get())
+	agentID := req.URL.Query().Get("agent_id")
+	if !tools.IsAnyValidUUID(agentID) {
+		return response.PublicBadRequestError[string]()
+	}
+
+	agentObj, err := agent.Get(req.Context(), types.UUID(agentID))
 	if err != nil {
 		log.ErrorContext(err, req.Context())
 		return response.PublicBadRequestError[string]()
 	}
 
-	resp, err := client.Beta.ChatKit.Sessions.New(req.Context(), openai.BetaChatKitSessionNewParams{
-		User: userObj.ID().String(),
-
-		Workflow: openai.ChatSessionWorkflowParam{
-			ID: req.URL.Query().Get("workflow_id"),
-			StateVariables: map[string]openai.ChatSessionWorkflowParamStateVariableUnion{
-				"organization_id": {
-					OfString: openai.String(userObj.OrganizationID.Get().String()),
-				},
-				"account_id": {
-					OfString: openai.String(userObj.ID().String()),
-				},
-				"business_information": {
-					OfString: openai.String(org.MetaData.GetI().GetOnboardAnswersString()),
-				},
-			},
-		},
-	})
+	if tools.Empty(agentObj) {
+		return response.PublicNotFoundError[string]()
+	}
+
+	session, err := agent_service.StartChatKitSession(req.Context(), agentObj, userObj)
 	if err != nil {
+		if errors.Is(err, agent_service.ErrAgentAccessDenied) {
+			return response.PublicCustomError[string]("Agent not available on your plan", http.StatusForbidden)
+		}
 		log.ErrorContext(err, req.Context())
 		return response.PublicBadRequestError[string]()
 	}
 
-	return response.Success(resp.ClientSecret)
+	return response.Success(session.ClientSecret)
 }
@@ -0,0 +1,74 @@
+package agents
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/form_submission"
+	"github.com/griffnb/techboss-ai-go/internal/services/agent_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+)
+
+// authSubmitForm runs a single shot form agent
+//
+//	@Public
+//	@Summary		Submit a form agent
+//	@Description	Validates the submitted form, runs it through the agent and returns JSON matching the agent's output schema
+//	@Tags			Agent
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Agent ID"
+//	@Param			body	body		map[string]any	true	"Form values keyed by field name"
+//	@Success		200		{object}	response.SuccessResponse{data=form_submission.FormSubmission}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		403		{object}	response.ErrorResponse
+//	@Router			/agent/{id}/form [post]
+func authSubmitForm(_ http.ResponseWriter, req *http.Request) (*form_submission.FormSubmission, int, error) {
+	userObj := helpers.GetLoadedUser(req)
+
+	id := chi.URLParam(req, "id")
+	agentObj, err := agent.Get(req.Context(), types.UUID(id))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*form_submission.FormSubmission]()
+	}
+
+	if tools.Empty(agentObj) {
+		return response.PublicNotFoundError[*form_submission.FormSubmission]()
+	}
+	if !agentObj.CanBeUsedBy(userObj.OrganizationID.Get(), int64(userObj.BillingPlanLevel.Get())) {
+		return response.PublicCustomError[*form_submission.FormSubmission]("Agent not available on your plan", http.StatusForbidden)
+	}
+
+	formSettings, err := agent_service.GetFormSettings(agentObj)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*form_submission.FormSubmission]()
+	}
+
+	input, err := formSettings.ValidateInput(request.GetJSONPostMap(req))
+	if err != nil {
+		return response.PublicCustomError[*form_submission.FormSubmission](err.Error(), http.StatusBadRequest)
+	}
+
+	service, err := openai.NewServiceFromEnv()
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*form_submission.FormSubmission]()
+	}
+
+	submission, err := agent_service.RunForm(req.Context(), service, agentObj, userObj, input)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicCustomError[*form_submission.FormSubmission]("Unable to generate a valid result", http.StatusBadRequest)
+	}
+
+	return response.Success(submission)
+}
@@ -61,5 +61,10 @@ func Setup(coreRouter *router.CoreRouter) {
 				constants.ROLE_ANY_AUTHORIZED: response.StandardRequestWrapper(authSession),
 			}))
 		})
+		r.Group(func(authR chi.Router) {
+			authR.Post("/{id}/form", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authSubmitForm),
+			}))
+		})
 	})
 }
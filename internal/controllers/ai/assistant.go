@@ -0,0 +1,84 @@
+package ai
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/services/agent_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+)
+
+type AssistantMessageInput struct {
+	ConversationID types.UUID `json:"conversation_id"`
+	Message        string     `json:"message"`
+}
+
+// authAssistantMessage posts a message to an assistant agent and streams the run back as normalized SSE events
+func authAssistantMessage(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	accountObj := helpers.GetLoadedUser(req)
+
+	input, err := request.GetJSONPostAs[*AssistantMessageInput](req)
+	if err != nil || tools.Empty(input.Message) {
+		http.Error(w, "message is required", http.StatusBadRequest)
+		return
+	}
+
+	agentObj, err := agent.Get(ctx, types.UUID(chi.URLParam(req, "id")))
+	if err != nil {
+		log.ErrorContext(err, ctx)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if tools.Empty(agentObj) {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if !agentObj.CanBeUsedBy(accountObj.OrganizationID.Get(), int64(accountObj.BillingPlanLevel.Get())) {
+		http.Error(w, "Agent not available on your plan", http.StatusForbidden)
+		return
+	}
+
+	conversationObj, err := agent_service.GetOrStartAssistantConversation(ctx, agentObj, accountObj, input.ConversationID)
+	if err != nil {
+		log.ErrorContext(err, ctx)
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", openai.ContentTypeSSE)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	emit := func(event *agent_service.AssistantEvent) error {
+		err := event.WriteSSE(w)
+		if err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	err = agent_service.StreamAssistantMessage(ctx, agent_service.NewOpenAIAssistantBackend(), agentObj, accountObj, conversationObj, input.Message, emit)
+	if err != nil {
+		log.ErrorContext(err, ctx)
+		_ = emit(&agent_service.AssistantEvent{
+			Type:           agent_service.ASSISTANT_EVENT_ERROR,
+			ConversationID: conversationObj.ID().String(),
+			Error:          "assistant run failed",
+		})
+	}
+}
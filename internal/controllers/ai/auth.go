@@ -3,8 +3,16 @@ package ai
 import (
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/services/agent_service"
 	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
 )
 
 // SetupAdmin sets up admin routes
@@ -37,3 +45,62 @@ func authStream(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
+
+func authAgentRun(w http.ResponseWriter, req *http.Request) {
+	service, instructions, err := loadAgentProxy(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	err = service.ProxyNonStreamingWithInstructions(req.Context(), req, w, instructions)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+func authAgentStream(w http.ResponseWriter, req *http.Request) {
+	service, instructions, err := loadAgentProxy(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	err = service.ProxyStreamingWithInstructions(req.Context(), req, w, instructions)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// loadAgentProxy builds the proxy service and the agent instructions merged with the caller's attributes
+func loadAgentProxy(req *http.Request) (*openai.Service, string, error) {
+	userSession := request.GetReqSession(req)
+
+	service, err := openai.NewServiceFromEnv()
+	if err != nil {
+		return nil, "", err
+	}
+
+	agentObj, err := agent.Get(req.Context(), types.UUID(chi.URLParam(req, "id")))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if tools.Empty(agentObj) {
+		return nil, "", errors.Errorf("agent not found %s", chi.URLParam(req, "id"))
+	}
+
+	accountObj := helpers.GetLoadedUser(req)
+	if !agentObj.CanBeUsedBy(accountObj.OrganizationID.Get(), int64(accountObj.BillingPlanLevel.Get())) {
+		return nil, "", errors.Errorf("agent %s not available to account %s", agentObj.ID(), accountObj.ID())
+	}
+
+	instructions, err := agent_service.BuildInstructions(req.Context(), agentObj, userSession.User.ID())
+	if err != nil {
+		return nil, "", err
+	}
+
+	return service, instructions, nil
+}
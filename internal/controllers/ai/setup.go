@@ -24,6 +24,15 @@ func Setup(coreRouter *router.CoreRouter) {
 			authR.Post("/openai/stream/responses", helpers.RoleHandler(helpers.RoleHandlerMap{
 				constants.ROLE_UNAUTHORIZED: router.NoTimeoutStreamingMiddleware(authStream),
 			}))
+			authR.Post("/agent/{id}/openai/responses", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: router.NoTimeoutMiddleware(authAgentRun),
+			}))
+			authR.Post("/agent/{id}/openai/stream/responses", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: router.NoTimeoutStreamingMiddleware(authAgentStream),
+			}))
+			authR.Post("/agent/{id}/assistant/messages", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: router.NoTimeoutStreamingMiddleware(authAssistantMessage),
+			}))
 		})
 	})
 }
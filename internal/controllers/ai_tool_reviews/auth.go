@@ -0,0 +1,283 @@
+package ai_tool_reviews
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review_vote"
+)
+
+type VoteInput struct {
+	Helpful bool `json:"helpful"`
+}
+
+// authCreate reviews a tool, an account reviews each tool once
+//
+//	@Public
+//	@Summary		Create AI tool review
+//	@Description	Reviews a tool as the session account, the review is shown once a moderator approves it
+//	@Tags			AiToolReview
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		ai_tool_review.AiToolReview	true	"Review, with ai_tool_id"
+//	@Success		200		{object}	response.SuccessResponse{data=ai_tool_review.AiToolReview}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		409		{object}	response.ErrorResponse
+//	@Router			/ai_tool_review [post]
+func authCreate(_ http.ResponseWriter, req *http.Request) (*ai_tool_review.AiToolReview, int, error) {
+	user := request.GetReqSession(req).User
+	userObj := helpers.GetLoadedUser(req)
+
+	data := request.GetModelPostData(req)
+	aiToolID := types.UUID(tools.ParseStringI(data["ai_tool_id"]))
+	if tools.Empty(aiToolID) {
+		return response.PublicCustomError[*ai_tool_review.AiToolReview]("ai_tool_id is required", http.StatusBadRequest)
+	}
+
+	toolObj, err := ai_tool.Get(req.Context(), aiToolID)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReview]()
+	}
+	if tools.Empty(toolObj) || toolObj.Deleted.Get() == 1 || toolObj.Disabled.Get() == 1 {
+		return response.PublicNotFoundError[*ai_tool_review.AiToolReview]()
+	}
+
+	existingObj, err := ai_tool_review.GetByToolAndAccount(req.Context(), aiToolID, userObj.ID())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReview]()
+	}
+	if !tools.Empty(existingObj) {
+		return response.PublicCustomError[*ai_tool_review.AiToolReview](
+			"You have already reviewed this tool, edit your review instead", http.StatusConflict)
+	}
+
+	reviewObj := ai_tool_review.NewPublic(data, user)
+	reviewObj.AiToolID.Set(aiToolID)
+	if userObj.EmailVerifiedAtTS.Get() > 0 {
+		reviewObj.IsVerified.Set(1)
+	}
+
+	msg := validateReview(reviewObj)
+	if msg != "" {
+		return response.PublicCustomError[*ai_tool_review.AiToolReview](msg, http.StatusBadRequest)
+	}
+
+	err = reviewObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReview]()
+	}
+
+	return response.Success(reviewObj)
+}
+
+// authUpdate edits the session account's review, it goes back to moderation
+//
+//	@Public
+//	@Summary		Update AI tool review
+//	@Description	Edits a review of the session account, it is hidden until a moderator approves it again
+//	@Tags			AiToolReview
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Review ID"
+//	@Param			body	body		ai_tool_review.AiToolReview	true	"Review"
+//	@Success		200		{object}	response.SuccessResponse{data=ai_tool_review.AiToolReviewJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/ai_tool_review/{id} [put]
+func authUpdate(_ http.ResponseWriter, req *http.Request) (*ai_tool_review.AiToolReviewJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	reviewObj, err := getReview(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+	if tools.Empty(reviewObj) {
+		return response.PublicNotFoundError[*ai_tool_review.AiToolReviewJoined]()
+	}
+
+	data := request.GetModelPostData(req)
+	ai_tool_review.UpdatePublic(&reviewObj.AiToolReview, data, user)
+
+	msg := validateReview(&reviewObj.AiToolReview)
+	if msg != "" {
+		return response.PublicCustomError[*ai_tool_review.AiToolReviewJoined](msg, http.StatusBadRequest)
+	}
+
+	err = reviewObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+
+	return response.Success(reviewObj)
+}
+
+// authDelete deletes the session account's review
+//
+//	@Public
+//	@Summary		Delete AI tool review
+//	@Description	Deletes a review of the session account and takes it out of the tool's rating
+//	@Tags			AiToolReview
+//	@Produce		json
+//	@Param			id	path		string	true	"Review ID"
+//	@Success		200	{object}	response.SuccessResponse{data=ai_tool_review.AiToolReviewJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/ai_tool_review/{id} [delete]
+func authDelete(_ http.ResponseWriter, req *http.Request) (*ai_tool_review.AiToolReviewJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	reviewObj, err := getReview(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+	if tools.Empty(reviewObj) {
+		return response.PublicNotFoundError[*ai_tool_review.AiToolReviewJoined]()
+	}
+
+	reviewObj.Deleted.Set(1)
+	err = reviewObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+
+	return response.Success(reviewObj)
+}
+
+// authVote marks someone else's review as helpful or not, voting again changes the vote
+//
+//	@Public
+//	@Summary		Vote on AI tool review
+//	@Description	Records whether the session account found an approved review helpful, returning the review with its new tallies
+//	@Tags			AiToolReview
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string		true	"Review ID"
+//	@Param			body	body		VoteInput	true	"Vote"
+//	@Success		200		{object}	response.SuccessResponse{data=ai_tool_review.AiToolReviewJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/ai_tool_review/{id}/vote [post]
+func authVote(_ http.ResponseWriter, req *http.Request) (*ai_tool_review.AiToolReviewJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	input, err := request.GetJSONPostAs[*VoteInput](req)
+	if err != nil {
+		return response.PublicCustomError[*ai_tool_review.AiToolReviewJoined]("helpful is required", http.StatusBadRequest)
+	}
+
+	reviewObj, err := getPublicReview(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+	if tools.Empty(reviewObj) {
+		return response.PublicNotFoundError[*ai_tool_review.AiToolReviewJoined]()
+	}
+	if reviewObj.AccountID.Get() == user.ID() {
+		return response.PublicCustomError[*ai_tool_review.AiToolReviewJoined](
+			"You cannot vote on your own review", http.StatusBadRequest)
+	}
+
+	voteObj, err := ai_tool_review_vote.GetByReviewAndAccount(req.Context(), reviewObj.ID(), user.ID())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+	if tools.Empty(voteObj) {
+		voteObj = ai_tool_review_vote.New()
+		voteObj.AiToolReviewID.Set(reviewObj.ID())
+		voteObj.AccountID.Set(user.ID())
+	}
+	helpful := int64(0)
+	if input.Helpful {
+		helpful = 1
+	}
+	voteObj.Helpful.Set(helpful)
+
+	err = voteObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+
+	return reloadPublicReview(req)
+}
+
+// authRemoveVote takes back the session account's vote on a review
+//
+//	@Public
+//	@Summary		Remove AI tool review vote
+//	@Description	Removes the session account's vote on a review, returning the review with its new tallies
+//	@Tags			AiToolReview
+//	@Produce		json
+//	@Param			id	path		string	true	"Review ID"
+//	@Success		200	{object}	response.SuccessResponse{data=ai_tool_review.AiToolReviewJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/ai_tool_review/{id}/vote [delete]
+func authRemoveVote(_ http.ResponseWriter, req *http.Request) (*ai_tool_review.AiToolReviewJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	voteObj, err := ai_tool_review_vote.GetByReviewAndAccount(req.Context(), types.UUID(chi.URLParam(req, "id")), user.ID())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+	if tools.Empty(voteObj) {
+		return response.PublicNotFoundError[*ai_tool_review.AiToolReviewJoined]()
+	}
+
+	err = voteObj.Delete(req.Context())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+
+	return reloadPublicReview(req)
+}
+
+func getReview(req *http.Request) (*ai_tool_review.AiToolReviewJoined, error) {
+	user := request.GetReqSession(req).User
+	return ai_tool_review.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+}
+
+func getPublicReview(req *http.Request) (*ai_tool_review.AiToolReviewJoined, error) {
+	return ai_tool_review.GetPublicJoined(req.Context(), types.UUID(chi.URLParam(req, "id")))
+}
+
+// reloadPublicReview returns the review with the tallies its votes were just recounted into
+func reloadPublicReview(req *http.Request) (*ai_tool_review.AiToolReviewJoined, int, error) {
+	reviewObj, err := getPublicReview(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool_review.AiToolReviewJoined]()
+	}
+	if tools.Empty(reviewObj) {
+		return response.PublicNotFoundError[*ai_tool_review.AiToolReviewJoined]()
+	}
+	return response.Success(reviewObj)
+}
+
+// validateReview returns a message describing what is wrong with the review, empty when it is valid
+func validateReview(reviewObj *ai_tool_review.AiToolReview) string {
+	rating := reviewObj.Rating.Get()
+	if rating < ai_tool_review.MIN_RATING || rating > ai_tool_review.MAX_RATING {
+		return fmt.Sprintf("rating must be between %d and %d", ai_tool_review.MIN_RATING, ai_tool_review.MAX_RATING)
+	}
+	if tools.Empty(reviewObj.Body.Get()) {
+		return "body is required"
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package ai_tool_reviews
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review"
+)
+
+// openToolIndex lists a tool's approved reviews
+//
+//	@Summary		List AI tool reviews
+//	@Description	Lists the approved reviews of a tool, newest first unless sorted by most helpful or rating
+//	@Tags			AiToolReview
+//	@Produce		json
+//	@Param			ai_tool_id	path		string	true	"Tool ID"
+//	@Param			sort		query		string	false	"newest, helpful, rating_high or rating_low"
+//	@Param			limit		query		int		false	"Page size"
+//	@Param			offset		query		int		false	"Page offset"
+//	@Success		200			{object}	response.SuccessResponse{data=[]ai_tool_review.AiToolReviewJoined}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Router			/ai_tool_review/tool/{ai_tool_id} [get]
+func openToolIndex(_ http.ResponseWriter, req *http.Request) ([]*ai_tool_review.AiToolReviewJoined, int, error) {
+	sort := req.URL.Query().Get("sort")
+	if !ai_tool_review.ValidSort(sort) {
+		return response.PublicCustomError[[]*ai_tool_review.AiToolReviewJoined]("Unknown sort", http.StatusBadRequest)
+	}
+
+	parameters := request.BuildIndexParams(req.Context(), req.URL.Query(), TABLE_NAME)
+	if tools.Empty(parameters.Limit) {
+		parameters.Limit = constants.SYSTEM_LIMIT
+	}
+	ai_tool_review.AddSort(parameters, sort)
+
+	reviewObjs, err := ai_tool_review.FindAllPublicJoined(req.Context(), parameters, types.UUID(chi.URLParam(req, "ai_tool_id")))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[[]*ai_tool_review.AiToolReviewJoined]()
+	}
+
+	return response.Success(reviewObjs)
+}
+
+// openToolCount counts a tool's approved reviews, for paging through them
+//
+//	@Summary		Count AI tool reviews
+//	@Description	Counts the approved reviews of a tool
+//	@Tags			AiToolReview
+//	@Produce		json
+//	@Param			ai_tool_id	path		string	true	"Tool ID"
+//	@Success		200			{object}	response.SuccessResponse{data=int64}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Router			/ai_tool_review/tool/{ai_tool_id}/count [get]
+func openToolCount(_ http.ResponseWriter, req *http.Request) (int64, int, error) {
+	parameters := request.BuildIndexParams(req.Context(), req.URL.Query(), TABLE_NAME)
+
+	count, err := ai_tool_review.CountPublic(req.Context(), parameters, types.UUID(chi.URLParam(req, "ai_tool_id")))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[int64]()
+	}
+
+	return response.Success(count)
+}
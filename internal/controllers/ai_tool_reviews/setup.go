@@ -0,0 +1,86 @@
+//go:generate core_gen controller AiToolReview -modelPackage=ai_tool_review -skip=authCreate,authUpdate
+package ai_tool_reviews
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/router"
+	"github.com/griffnb/core/lib/router/response"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review"
+)
+
+const (
+	TABLE_NAME string = ai_tool_review.TABLE
+	ROUTE      string = "ai_tool_review"
+)
+
+// Setup sets up the router
+func Setup(coreRouter *router.CoreRouter) {
+	// Admin routes
+	coreRouter.AddMainRoute(tools.BuildString("/admin/", ROUTE), func(r chi.Router) {
+		r.Group(func(adminR chi.Router) {
+			adminR.Get("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminIndex),
+			}))
+			adminR.Get("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminGet),
+			}))
+			adminR.Get("/count", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminCount),
+			}))
+		})
+		r.Group(func(adminR chi.Router) {
+			adminR.Post("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ADMIN: response.StandardRequestWrapper(adminCreate),
+			}))
+			adminR.Put("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ADMIN: response.StandardRequestWrapper(adminUpdate),
+			}))
+		})
+		r.Group(func(adminR chi.Router) {
+			adminR.Get("/_ts", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: helpers.TSValidation(TABLE_NAME),
+			}))
+		})
+	})
+
+	// Public authenticated routes
+	coreRouter.AddMainRoute(tools.BuildString("/", ROUTE), func(r chi.Router) {
+		r.Group(func(authR chi.Router) {
+			authR.Get("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authIndex),
+			}))
+			authR.Get("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authGet),
+			}))
+		})
+		r.Group(func(authR chi.Router) {
+			authR.Post("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authCreate),
+			}))
+			authR.Put("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authUpdate),
+			}))
+			authR.Delete("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authDelete),
+			}))
+			authR.Post("/{id}/vote", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authVote),
+			}))
+			authR.Delete("/{id}/vote", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authRemoveVote),
+			}))
+		})
+		r.Group(func(openR chi.Router) {
+			openR.Get("/tool/{ai_tool_id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: response.StandardPublicRequestWrapper(openToolIndex),
+			}))
+			openR.Get("/tool/{ai_tool_id}/count", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: response.StandardPublicRequestWrapper(openToolCount),
+			}))
+		})
+	})
+}
@@ -0,0 +1,33 @@
+package ai_tools
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool"
+)
+
+// openAutocomplete suggests tools, categories and tags for what has been typed so far
+//
+//	@Summary		Autocomplete AI tool catalog
+//	@Description	Suggests tools, categories and tags whose names start with or are close to the input, tolerating typos
+//	@Tags			AiTool
+//	@Produce		json
+//	@Param			q		query		string	true	"Typed input, at least 2 characters"
+//	@Param			limit	query		int		false	"Number of suggestions, 8 by default and at most 20"
+//	@Success		200		{object}	response.SuccessResponse{data=[]ai_tool.Suggestion}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/ai_tool/autocomplete [get]
+func openAutocomplete(_ http.ResponseWriter, req *http.Request) ([]*ai_tool.Suggestion, int, error) {
+	limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+
+	suggestions, err := ai_tool.FindSuggestions(req.Context(), req.URL.Query().Get("q"), limit)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[[]*ai_tool.Suggestion]()
+	}
+
+	return response.Success(suggestions)
+}
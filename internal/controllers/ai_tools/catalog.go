@@ -0,0 +1,58 @@
+package ai_tools
+
+import (
+	"net/http"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool"
+)
+
+type CatalogInput struct {
+	Query               string       `json:"query"`
+	CategoryIDs         []types.UUID `json:"category_ids"`
+	BusinessFunctionIDs []types.UUID `json:"business_function_ids"`
+	TagIDs              []types.UUID `json:"tag_ids"`
+	FreeTier            bool         `json:"free_tier"`
+	Featured            bool         `json:"featured"`
+	Limit               int          `json:"limit"`
+	Offset              int          `json:"offset"`
+}
+
+// openCatalog searches the public catalog of AI tools
+//
+//	@Summary		Search AI tool catalog
+//	@Description	Ranks tools by how well their name, tagline and description match, filters them and counts each filter value
+//	@Tags			AiTool
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		CatalogInput	true	"Search"
+//	@Success		200		{object}	response.SuccessResponse{data=ai_tool.CatalogResults}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/ai_tool/catalog [post]
+func openCatalog(_ http.ResponseWriter, req *http.Request) (*ai_tool.CatalogResults, int, error) {
+	input, err := request.GetJSONPostAs[*CatalogInput](req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool.CatalogResults]()
+	}
+
+	results, err := ai_tool.SearchCatalog(req.Context(), &ai_tool.CatalogQuery{
+		Query:               input.Query,
+		CategoryIDs:         input.CategoryIDs,
+		BusinessFunctionIDs: input.BusinessFunctionIDs,
+		TagIDs:              input.TagIDs,
+		FreeTier:            input.FreeTier,
+		Featured:            input.Featured,
+		Limit:               input.Limit,
+		Offset:              input.Offset,
+	})
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*ai_tool.CatalogResults]()
+	}
+
+	return response.Success(results)
+}
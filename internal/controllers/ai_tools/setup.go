@@ -61,5 +61,14 @@ func Setup(coreRouter *router.CoreRouter) {
 				constants.ROLE_ANY_AUTHORIZED: response.StandardRequestWrapper(authCount),
 			}))
 		})
+
+		r.Group(func(openR chi.Router) {
+			openR.Post("/catalog", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: response.StandardPublicRequestWrapper(openCatalog),
+			}))
+			openR.Get("/autocomplete", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: response.StandardPublicRequestWrapper(openAutocomplete),
+			}))
+		})
 	})
 }
@@ -0,0 +1,47 @@
+package conversations
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/message"
+)
+
+// authMessages returns the stored message history for one of the caller's conversations
+//
+//	@Summary		Conversation messages
+//	@Description	Returns the mirrored message history for a conversation
+//	@Tags			Conversation
+//	@Produce		json
+//	@Param			id	path	string	true	"Conversation ID"
+//	@Success		200	{object}	response.SuccessResponse{data=[]message.Message}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/conversation/{id}/messages [get]
+func authMessages(_ http.ResponseWriter, req *http.Request) ([]*message.Message, int, error) {
+	user := request.GetReqSession(req).User
+
+	id := chi.URLParam(req, "id")
+	conversationObj, err := conversation.GetRestrictedJoined(req.Context(), types.UUID(id), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[[]*message.Message]()
+	}
+	if tools.Empty(conversationObj) {
+		return response.PublicNotFoundError[[]*message.Message]()
+	}
+
+	messages, err := message.GetMessagesByConversationID(req.Context(), conversationObj.ID(), constants.SYSTEM_LIMIT)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[[]*message.Message]()
+	}
+
+	return response.Success(messages)
+}
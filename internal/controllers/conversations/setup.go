@@ -56,6 +56,9 @@ func Setup(coreRouter *router.CoreRouter) {
 			authR.Get("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
 				constants.ROLE_ANY_AUTHORIZED: response.StandardRequestWrapper(authGet),
 			}))
+			authR.Get("/{id}/messages", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardRequestWrapper(authMessages),
+			}))
 		})
 	})
 }
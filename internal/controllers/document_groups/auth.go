@@ -0,0 +1,230 @@
+package document_groups
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_group"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
+)
+
+type MembershipInput struct {
+	DocumentIDs []types.UUID `json:"document_ids"`
+}
+
+// authCreate creates a document group for the organization
+//
+//	@Public
+//	@Summary		Create document group
+//	@Description	Creates a knowledge base, policy or product catalog group in the session organization
+//	@Tags			DocumentGroup
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		document_group.DocumentGroup	true	"Group"
+//	@Success		200		{object}	response.SuccessResponse{data=document_group.DocumentGroup}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document_group [post]
+func authCreate(_ http.ResponseWriter, req *http.Request) (*document_group.DocumentGroup, int, error) {
+	user := request.GetReqSession(req).User
+
+	data := request.GetModelPostData(req)
+	groupObj := document_group.NewPublic(data, user)
+
+	msg := validateGroup(groupObj)
+	if msg != "" {
+		return response.PublicCustomError[*document_group.DocumentGroup](msg, http.StatusBadRequest)
+	}
+
+	err := groupObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document_group.DocumentGroup]()
+	}
+
+	return response.Success(groupObj)
+}
+
+// authUpdate renames a group or changes its description or type
+//
+//	@Public
+//	@Summary		Update document group
+//	@Description	Updates a group of the session organization
+//	@Tags			DocumentGroup
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Group ID"
+//	@Param			body	body		document_group.DocumentGroup	true	"Group"
+//	@Success		200		{object}	response.SuccessResponse{data=document_group.DocumentGroupJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document_group/{id} [put]
+func authUpdate(_ http.ResponseWriter, req *http.Request) (*document_group.DocumentGroupJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	groupObj, err := getGroup(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document_group.DocumentGroupJoined]()
+	}
+	if tools.Empty(groupObj) {
+		return response.PublicNotFoundError[*document_group.DocumentGroupJoined]()
+	}
+
+	data := request.GetModelPostData(req)
+	document_group.UpdatePublic(&groupObj.DocumentGroup, data, user)
+
+	msg := validateGroup(&groupObj.DocumentGroup)
+	if msg != "" {
+		return response.PublicCustomError[*document_group.DocumentGroupJoined](msg, http.StatusBadRequest)
+	}
+
+	err = groupObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document_group.DocumentGroupJoined]()
+	}
+
+	return response.Success(groupObj)
+}
+
+// authDelete deletes a group, its documents are kept outside of any group
+//
+//	@Public
+//	@Summary		Delete document group
+//	@Description	Deletes a group of the session organization, its documents are not deleted
+//	@Tags			DocumentGroup
+//	@Produce		json
+//	@Param			id	path		string	true	"Group ID"
+//	@Success		200	{object}	response.SuccessResponse{data=document_group.DocumentGroupJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/document_group/{id} [delete]
+func authDelete(_ http.ResponseWriter, req *http.Request) (*document_group.DocumentGroupJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	groupObj, err := getGroup(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document_group.DocumentGroupJoined]()
+	}
+	if tools.Empty(groupObj) {
+		return response.PublicNotFoundError[*document_group.DocumentGroupJoined]()
+	}
+
+	err = document_service.DeleteGroup(req.Context(), &groupObj.DocumentGroup, user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document_group.DocumentGroupJoined]()
+	}
+
+	return response.Success(groupObj)
+}
+
+// authAddDocuments moves documents into a group, a document belongs to one group at a time
+//
+//	@Public
+//	@Summary		Add documents to group
+//	@Description	Moves documents the session account may edit into a group, taking them out of any other group
+//	@Tags			DocumentGroup
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Group ID"
+//	@Param			body	body		MembershipInput	true	"Documents"
+//	@Success		200		{object}	response.SuccessResponse{data=[]document.DocumentJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document_group/{id}/documents [post]
+func authAddDocuments(_ http.ResponseWriter, req *http.Request) ([]*document.DocumentJoined, int, error) {
+	return setMembership(req, true)
+}
+
+// authRemoveDocuments takes documents out of a group
+//
+//	@Public
+//	@Summary		Remove documents from group
+//	@Description	Takes documents the session account may edit out of a group, documents in other groups are left alone
+//	@Tags			DocumentGroup
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Group ID"
+//	@Param			body	body		MembershipInput	true	"Documents"
+//	@Success		200		{object}	response.SuccessResponse{data=[]document.DocumentJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document_group/{id}/documents/remove [post]
+func authRemoveDocuments(_ http.ResponseWriter, req *http.Request) ([]*document.DocumentJoined, int, error) {
+	return setMembership(req, false)
+}
+
+func setMembership(req *http.Request, add bool) ([]*document.DocumentJoined, int, error) {
+	user := request.GetReqSession(req).User
+	userObj := helpers.GetLoadedUser(req)
+
+	input, err := request.GetJSONPostAs[*MembershipInput](req)
+	if err != nil || len(input.DocumentIDs) == 0 {
+		return response.PublicCustomError[[]*document.DocumentJoined]("document_ids is required", http.StatusBadRequest)
+	}
+
+	groupObj, err := getGroup(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[[]*document.DocumentJoined]()
+	}
+	if tools.Empty(groupObj) {
+		return response.PublicNotFoundError[[]*document.DocumentJoined]()
+	}
+
+	documentObjs := []*document.DocumentJoined{}
+	for _, documentID := range input.DocumentIDs {
+		documentObj, err := document.GetRestrictedJoined(req.Context(), documentID, user)
+		if err != nil {
+			log.ErrorContext(err, req.Context())
+			return response.PublicBadRequestError[[]*document.DocumentJoined]()
+		}
+		if tools.Empty(documentObj) {
+			return response.PublicNotFoundError[[]*document.DocumentJoined]()
+		}
+		if !documentObj.EditableBy(userObj.ID(), userObj.Role.Get()) {
+			return response.PublicCustomError[[]*document.DocumentJoined](
+				"Only the uploader or an organization admin can move this document", http.StatusForbidden)
+		}
+		documentObjs = append(documentObjs, documentObj)
+	}
+
+	for _, documentObj := range documentObjs {
+		groupID := groupObj.ID()
+		if !add {
+			if documentObj.DocumentGroupID.Get() != groupObj.ID() {
+				continue
+			}
+			groupID = ""
+		}
+
+		err = document_service.SetGroup(req.Context(), &documentObj.Document, groupID, user)
+		if err != nil {
+			log.ErrorContext(err, req.Context())
+			return response.PublicBadRequestError[[]*document.DocumentJoined]()
+		}
+	}
+
+	return response.Success(documentObjs)
+}
+
+func getGroup(req *http.Request) (*document_group.DocumentGroupJoined, error) {
+	user := request.GetReqSession(req).User
+	return document_group.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+}
+
+// validateGroup returns a message describing what is wrong with the group, empty when it is valid
+func validateGroup(groupObj *document_group.DocumentGroup) string {
+	if tools.Empty(groupObj.Name.Get()) {
+		return "name is required"
+	}
+	if !groupObj.GroupType.Get().Valid() {
+		return "Unknown group type"
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+//go:generate core_gen controller DocumentGroup -modelPackage=document_group -skip=authCreate,authUpdate
+package document_groups
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/router"
+	"github.com/griffnb/core/lib/router/response"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_group"
+)
+
+const (
+	TABLE_NAME string = document_group.TABLE
+	ROUTE      string = "document_group"
+)
+
+// Setup sets up the router
+func Setup(coreRouter *router.CoreRouter) {
+	// Admin routes
+	coreRouter.AddMainRoute(tools.BuildString("/admin/", ROUTE), func(r chi.Router) {
+		r.Group(func(adminR chi.Router) {
+			adminR.Get("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminIndex),
+			}))
+			adminR.Get("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminGet),
+			}))
+			adminR.Get("/count", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminCount),
+			}))
+		})
+		r.Group(func(adminR chi.Router) {
+			adminR.Post("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ADMIN: response.StandardRequestWrapper(adminCreate),
+			}))
+			adminR.Put("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ADMIN: response.StandardRequestWrapper(adminUpdate),
+			}))
+		})
+		r.Group(func(adminR chi.Router) {
+			adminR.Get("/_ts", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: helpers.TSValidation(TABLE_NAME),
+			}))
+		})
+	})
+
+	// Public authenticated routes
+	coreRouter.AddMainRoute(tools.BuildString("/", ROUTE), func(r chi.Router) {
+		r.Group(func(authR chi.Router) {
+			authR.Get("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authIndex),
+			}))
+			authR.Get("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authGet),
+			}))
+			authR.Post("/{id}/documents", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authAddDocuments),
+			}))
+			authR.Post("/{id}/documents/remove", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authRemoveDocuments),
+			}))
+		})
+		r.Group(func(authR chi.Router) {
+			authR.Post("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ORG_ADMIN: response.StandardPublicRequestWrapper(authCreate),
+			}))
+			authR.Put("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ORG_ADMIN: response.StandardPublicRequestWrapper(authUpdate),
+			}))
+			authR.Delete("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ORG_ADMIN: response.StandardPublicRequestWrapper(authDelete),
+			}))
+		})
+	})
+}
@@ -0,0 +1,46 @@
+package documents
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+// adminEmbed queues an indexed document to be embedded again, used after the embedding provider changes
+//
+//	@Summary		Re-embed document
+//	@Description	Replaces the document's chunks in the vector index on the task worker
+//	@Tags			Document
+//	@Produce		json
+//	@Param			id	path		string	true	"Document ID"
+//	@Success		200	{object}	response.SuccessResponse{data=document.Document}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/admin/document/{id}/embed [post]
+func adminEmbed(_ http.ResponseWriter, req *http.Request) (*document.Document, int, error) {
+	documentObj, err := document.Get(req.Context(), types.UUID(chi.URLParam(req, "id")))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*document.Document](err)
+	}
+	if tools.Empty(documentObj) {
+		return response.AdminBadRequestError[*document.Document](errors.New("Document not found"))
+	}
+	if documentObj.Status.Get() != document.STATUS_INDEXED {
+		return response.AdminBadRequestError[*document.Document](errors.New("Only indexed documents can be embedded again"))
+	}
+
+	err = worker_jobs.QueueDocumentEmbedJob(documentObj.ID())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*document.Document](err)
+	}
+
+	return response.Success(documentObj)
+}
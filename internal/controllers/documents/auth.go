@@ -0,0 +1,243 @@
+package documents
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
+	"github.com/pkg/errors"
+)
+
+type UploadInput struct {
+	Name        string `json:"name"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+}
+
+type WebpageInput struct {
+	Name         string `json:"name"`
+	URL          string `json:"url"`
+	RefetchHours int    `json:"refetch_hours"`
+}
+
+type SitemapInput struct {
+	Name         string   `json:"name"`
+	URL          string   `json:"url"`
+	Include      []string `json:"include"`
+	Exclude      []string `json:"exclude"`
+	MaxPages     int      `json:"max_pages"`
+	DelayMS      int      `json:"delay_ms"`
+	RefetchHours int      `json:"refetch_hours"`
+}
+
+type UploadResponse struct {
+	Document  *document.Document `json:"document"`
+	UploadURL string             `json:"upload_url"`
+}
+
+// authUpload creates a pending document and returns the presigned URL its file is uploaded to
+//
+//	@Public
+//	@Summary		Start document upload
+//	@Description	Creates a pending document, PUT the file to upload_url with the same content type then confirm it
+//	@Tags			Document
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		UploadInput	true	"File"
+//	@Success		200		{object}	response.SuccessResponse{data=UploadResponse}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document/upload [post]
+func authUpload(_ http.ResponseWriter, req *http.Request) (*UploadResponse, int, error) {
+	user := request.GetReqSession(req).User
+	if tools.Empty(user.GetString("organization_id")) {
+		return response.PublicCustomError[*UploadResponse]("An organization is required", http.StatusBadRequest)
+	}
+
+	input, err := request.GetJSONPostAs[*UploadInput](req)
+	if err != nil || tools.Empty(input.FileName) {
+		return response.PublicCustomError[*UploadResponse]("file_name is required", http.StatusBadRequest)
+	}
+	if !document_service.Supported(input.ContentType) {
+		return response.PublicCustomError[*UploadResponse]("File type is not supported", http.StatusBadRequest)
+	}
+
+	documentObj := document.NewPublic(map[string]any{}, user)
+	documentObj.Name.Set(input.Name)
+	documentObj.FileName.Set(input.FileName)
+	documentObj.ContentType.Set(document_service.NormalizeContentType(input.ContentType))
+
+	uploadURL, err := document_service.CreateUpload(documentObj, user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*UploadResponse]()
+	}
+
+	return response.Success(&UploadResponse{
+		Document:  documentObj,
+		UploadURL: uploadURL,
+	})
+}
+
+// authWebpage captures a webpage as a document, re-fetching it every refetch_hours when set
+//
+//	@Public
+//	@Summary		Capture webpage
+//	@Description	Fetches a page, stores its readable text and a summary, and indexes it
+//	@Tags			Document
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		WebpageInput	true	"Page"
+//	@Success		200		{object}	response.SuccessResponse{data=document.Document}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document/webpage [post]
+func authWebpage(_ http.ResponseWriter, req *http.Request) (*document.Document, int, error) {
+	user := request.GetReqSession(req).User
+	if tools.Empty(user.GetString("organization_id")) {
+		return response.PublicCustomError[*document.Document]("An organization is required", http.StatusBadRequest)
+	}
+
+	input, err := request.GetJSONPostAs[*WebpageInput](req)
+	if err != nil || document_service.ValidateWebpageURL(input.URL) != nil {
+		return response.PublicCustomError[*document.Document]("A valid http url is required", http.StatusBadRequest)
+	}
+	if input.RefetchHours < 0 || input.RefetchHours > document_service.MAX_REFETCH_HOURS {
+		return response.PublicCustomError[*document.Document]("refetch_hours is out of range", http.StatusBadRequest)
+	}
+
+	documentObj := document.NewPublic(map[string]any{}, user)
+	documentObj.Name.Set(input.Name)
+
+	err = document_service.CreateWebpage(documentObj, input.URL, input.RefetchHours, user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.Document]()
+	}
+
+	return response.Success(documentObj)
+}
+
+// authSitemap crawls a sitemap, capturing each page it lists as a child webpage document
+//
+//	@Public
+//	@Summary		Crawl sitemap
+//	@Description	Reads a sitemap or sitemap index, or the site's robots.txt sitemaps, and captures the matching pages
+//	@Tags			Document
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		SitemapInput	true	"Sitemap"
+//	@Success		200		{object}	response.SuccessResponse{data=document.Document}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document/sitemap [post]
+func authSitemap(_ http.ResponseWriter, req *http.Request) (*document.Document, int, error) {
+	user := request.GetReqSession(req).User
+	if tools.Empty(user.GetString("organization_id")) {
+		return response.PublicCustomError[*document.Document]("An organization is required", http.StatusBadRequest)
+	}
+
+	input, err := request.GetJSONPostAs[*SitemapInput](req)
+	if err != nil || document_service.ValidateWebpageURL(input.URL) != nil {
+		return response.PublicCustomError[*document.Document]("A valid http url is required", http.StatusBadRequest)
+	}
+	if input.RefetchHours < 0 || input.RefetchHours > document_service.MAX_REFETCH_HOURS {
+		return response.PublicCustomError[*document.Document]("refetch_hours is out of range", http.StatusBadRequest)
+	}
+	if input.MaxPages < 0 || input.MaxPages > document_service.CRAWL_MAX_PAGES {
+		return response.PublicCustomError[*document.Document]("max_pages is out of range", http.StatusBadRequest)
+	}
+	if input.DelayMS < 0 || int64(input.DelayMS) > document_service.CRAWL_MAX_DELAY.Milliseconds() {
+		return response.PublicCustomError[*document.Document]("delay_ms is out of range", http.StatusBadRequest)
+	}
+
+	documentObj := document.NewPublic(map[string]any{}, user)
+	documentObj.Name.Set(input.Name)
+
+	settings := &document.CrawlSettings{
+		Include:  input.Include,
+		Exclude:  input.Exclude,
+		MaxPages: input.MaxPages,
+		DelayMS:  input.DelayMS,
+	}
+	err = document_service.CreateSitemap(documentObj, input.URL, settings, input.RefetchHours, user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.Document]()
+	}
+
+	return response.Success(documentObj)
+}
+
+// authUploaded confirms the file of a pending document was uploaded and starts processing it
+//
+//	@Public
+//	@Summary		Confirm document upload
+//	@Description	Queues an uploaded document for text extraction and indexing
+//	@Tags			Document
+//	@Produce		json
+//	@Param			id	path		string	true	"Document ID"
+//	@Success		200	{object}	response.SuccessResponse{data=document.DocumentJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/document/{id}/uploaded [post]
+func authUploaded(_ http.ResponseWriter, req *http.Request) (*document.DocumentJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	documentObj, err := document.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.DocumentJoined]()
+	}
+	if tools.Empty(documentObj) {
+		return response.PublicNotFoundError[*document.DocumentJoined]()
+	}
+
+	err = document_service.ConfirmUpload(&documentObj.Document, user)
+	if err != nil {
+		if errors.Is(err, document_service.ErrNotUploadable) {
+			return response.PublicCustomError[*document.DocumentJoined](err.Error(), http.StatusBadRequest)
+		}
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.DocumentJoined]()
+	}
+
+	return response.Success(documentObj)
+}
+
+// authRetry restarts a failed document from the last step it completed
+//
+//	@Public
+//	@Summary		Retry document
+//	@Description	Requeues a document in the error status for processing or indexing
+//	@Tags			Document
+//	@Produce		json
+//	@Param			id	path		string	true	"Document ID"
+//	@Success		200	{object}	response.SuccessResponse{data=document.DocumentJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/document/{id}/retry [post]
+func authRetry(_ http.ResponseWriter, req *http.Request) (*document.DocumentJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	documentObj, err := document.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.DocumentJoined]()
+	}
+	if tools.Empty(documentObj) {
+		return response.PublicNotFoundError[*document.DocumentJoined]()
+	}
+
+	err = document_service.Retry(&documentObj.Document, user)
+	if err != nil {
+		if errors.Is(err, document_service.ErrNotRetryable) {
+			return response.PublicCustomError[*document.DocumentJoined](err.Error(), http.StatusBadRequest)
+		}
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.DocumentJoined]()
+	}
+
+	return response.Success(documentObj)
+}
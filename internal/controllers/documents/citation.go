@@ -0,0 +1,143 @@
+package documents
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
+)
+
+// CitationResponse is a resolved citation, URL opens the original file, or the page for webpage captures
+type CitationResponse struct {
+	DocumentID   types.UUID `json:"document_id"`
+	DocumentName string     `json:"document_name"`
+	ChunkID      types.UUID `json:"chunk_id"`
+	Snippet      string     `json:"snippet"`
+	StartOffset  int        `json:"start_offset"`
+	EndOffset    int        `json:"end_offset"`
+	Page         int        `json:"page,omitempty"`
+	Heading      string     `json:"heading,omitempty"`
+	URL          string     `json:"url"`
+	ExpiresAtTS  int64      `json:"expires_at_ts,omitempty"` // when a signed file URL stops working
+}
+
+// authCitation resolves a citation on an assistant message to the cited passage and a link to its source
+//
+//	@Public
+//	@Summary		Resolve citation
+//	@Description	Returns the cited passage of a document in the session organization and a short lived link to the original
+//	@Tags			Document
+//	@Produce		json
+//	@Param			chunk_id	path		string	true	"Chunk ID from the citation"
+//	@Success		200			{object}	response.SuccessResponse{data=CitationResponse}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Router			/document/citation/{chunk_id} [get]
+func authCitation(_ http.ResponseWriter, req *http.Request) (*CitationResponse, int, error) {
+	userObj := helpers.GetLoadedUser(req)
+
+	chunkObj, err := document_chunk.Get(req.Context(), types.UUID(chi.URLParam(req, "chunk_id")))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*CitationResponse]()
+	}
+	if tools.Empty(chunkObj) || tools.Empty(userObj.OrganizationID.Get()) ||
+		chunkObj.OrganizationID.Get() != userObj.OrganizationID.Get() {
+		return response.PublicNotFoundError[*CitationResponse]()
+	}
+
+	// also hides documents another member keeps private
+	documentObj, err := document.GetRestrictedJoined(req.Context(), chunkObj.DocumentID.Get(), userObj)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*CitationResponse]()
+	}
+	if tools.Empty(documentObj) {
+		return response.PublicNotFoundError[*CitationResponse]()
+	}
+
+	citation := &CitationResponse{
+		DocumentID:   documentObj.ID(),
+		DocumentName: documentObj.Name.Get(),
+		ChunkID:      chunkObj.ID(),
+		Snippet:      chunkObj.Content.Get(),
+		StartOffset:  int(chunkObj.StartOffset.Get()),
+		EndOffset:    int(chunkObj.EndOffset.Get()),
+		Page:         int(chunkObj.Page.Get()),
+		Heading:      chunkObj.Heading.Get(),
+	}
+
+	metaData, err := documentObj.MetaData.Get()
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+	}
+	if documentObj.RawS3URL.Get() != "" {
+		citation.URL, citation.ExpiresAtTS = document_service.SignedFileURL(documentObj.ID(), time.Now())
+	} else if metaData != nil {
+		citation.URL = metaData.WebpageURL
+	}
+
+	return response.Success(citation)
+}
+
+// openFile downloads a document's original file through a signed link from authCitation
+//
+//	@Summary		Download document file
+//	@Description	Streams the original uploaded file, inline only for PDFs, plain text and images, the link is signed and expires
+//	@Tags			Document
+//	@Param			id			path	string	true	"Document ID"
+//	@Param			expires		query	string	true	"Expiry timestamp"
+//	@Param			signature	query	string	true	"Signature"
+//	@Success		200
+//	@Failure		403
+//	@Router			/document/{id}/file [get]
+func openFile(res http.ResponseWriter, req *http.Request) {
+	documentID := types.UUID(chi.URLParam(req, "id"))
+	err := document_service.VerifyFileSignature(
+		documentID,
+		req.URL.Query().Get("expires"),
+		req.URL.Query().Get("signature"),
+		time.Now(),
+	)
+	if err != nil {
+		response.ErrorWrapper(res, req, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	documentObj, err := document.Get(req.Context(), documentID)
+	if err != nil || tools.Empty(documentObj) || documentObj.Deleted.Get() == 1 {
+		response.ErrorWrapper(res, req, "Not found", http.StatusNotFound)
+		return
+	}
+
+	body, err := document_service.ReadRawFile(req.Context(), documentObj)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		response.ErrorWrapper(res, req, "Not found", http.StatusNotFound)
+		return
+	}
+
+	// uploads come from users, so only known safe types open in the browser and nothing can run script on our origin
+	if document_service.ServeInline(documentObj.ContentType.Get()) {
+		res.Header().Set("Content-Type", documentObj.ContentType.Get())
+		res.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", documentObj.FileName.Get()))
+	} else {
+		res.Header().Set("Content-Type", "application/octet-stream")
+		res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", documentObj.FileName.Get()))
+	}
+	res.Header().Set("X-Content-Type-Options", "nosniff")
+	res.Header().Set("Content-Security-Policy", "sandbox")
+	res.Header().Set("Cache-Control", "private, no-store")
+	_, err = res.Write(body)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+	}
+}
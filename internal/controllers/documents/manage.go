@@ -0,0 +1,178 @@
+package documents
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
+	"github.com/pkg/errors"
+)
+
+type TagsInput struct {
+	Tags []string `json:"tags"`
+}
+
+// authUpdate renames a document or changes who in the organization can see it
+//
+//	@Public
+//	@Summary		Update document
+//	@Description	Renames a document or changes its sharing level, only the uploader or an organization admin may
+//	@Tags			Document
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Document ID"
+//	@Param			body	body		document.Document	true	"Document"
+//	@Success		200		{object}	response.SuccessResponse{data=document.DocumentJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document/{id} [put]
+func authUpdate(_ http.ResponseWriter, req *http.Request) (*document.DocumentJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	documentObj, err := getEditableDocument(req)
+	if err != nil {
+		return documentError[*document.DocumentJoined](req, err)
+	}
+
+	sharing := documentObj.Sharing.Get()
+	data := request.GetModelPostData(req)
+	document.UpdatePublic(&documentObj.Document, data, user)
+
+	if tools.Empty(documentObj.Name.Get()) {
+		return response.PublicCustomError[*document.DocumentJoined]("name is required", http.StatusBadRequest)
+	}
+	if !documentObj.Sharing.Get().Valid() {
+		return response.PublicCustomError[*document.DocumentJoined]("Unknown sharing level", http.StatusBadRequest)
+	}
+
+	err = documentObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.DocumentJoined]()
+	}
+
+	// only documents shared with the organization are kept in its vector store
+	if documentObj.Sharing.Get() != sharing {
+		err = worker_jobs.QueueDocumentVectorSyncJob(documentObj.ID())
+		if err != nil {
+			log.ErrorContext(err, req.Context())
+		}
+	}
+
+	return response.Success(documentObj)
+}
+
+// authTags replaces the tags of a document
+//
+//	@Public
+//	@Summary		Tag document
+//	@Description	Replaces a document's tags with the given names, unknown tags are created
+//	@Tags			Document
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string		true	"Document ID"
+//	@Param			body	body		TagsInput	true	"Tags"
+//	@Success		200		{object}	response.SuccessResponse{data=document.DocumentJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document/{id}/tags [put]
+func authTags(_ http.ResponseWriter, req *http.Request) (*document.DocumentJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	input, err := request.GetJSONPostAs[*TagsInput](req)
+	if err != nil {
+		return response.PublicCustomError[*document.DocumentJoined]("tags is required", http.StatusBadRequest)
+	}
+
+	documentObj, err := getEditableDocument(req)
+	if err != nil {
+		return documentError[*document.DocumentJoined](req, err)
+	}
+
+	err = document_service.SetTags(req.Context(), &documentObj.Document, input.Tags, user)
+	if err != nil {
+		if errors.Is(err, document_service.ErrTooManyTags) {
+			return response.PublicCustomError[*document.DocumentJoined](err.Error(), http.StatusBadRequest)
+		}
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.DocumentJoined]()
+	}
+
+	// reloaded for the new tags
+	documentObj, err = document.GetRestrictedJoined(req.Context(), documentObj.ID(), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.DocumentJoined]()
+	}
+
+	return response.Success(documentObj)
+}
+
+// authDelete deletes a document along with its index and stored files
+//
+//	@Public
+//	@Summary		Delete document
+//	@Description	Deletes a document, its search index and its files, only the uploader or an organization admin may
+//	@Tags			Document
+//	@Produce		json
+//	@Param			id	path		string	true	"Document ID"
+//	@Success		200	{object}	response.SuccessResponse{data=document.DocumentJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/document/{id} [delete]
+func authDelete(_ http.ResponseWriter, req *http.Request) (*document.DocumentJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	documentObj, err := getEditableDocument(req)
+	if err != nil {
+		return documentError[*document.DocumentJoined](req, err)
+	}
+
+	err = document_service.Delete(req.Context(), &documentObj.Document, user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*document.DocumentJoined]()
+	}
+
+	return response.Success(documentObj)
+}
+
+var (
+	errDocumentNotFound  = errors.New("document not found")
+	errDocumentForbidden = errors.New("Only the uploader or an organization admin can change this document")
+)
+
+// getEditableDocument loads the document in the URL, failing unless the session account may change it
+func getEditableDocument(req *http.Request) (*document.DocumentJoined, error) {
+	userObj := helpers.GetLoadedUser(req)
+
+	documentObj, err := document.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), userObj)
+	if err != nil {
+		return nil, err
+	}
+	if tools.Empty(documentObj) {
+		return nil, errDocumentNotFound
+	}
+	if !documentObj.EditableBy(userObj.ID(), userObj.Role.Get()) {
+		return nil, errDocumentForbidden
+	}
+	return documentObj, nil
+}
+
+// documentError turns an error from getEditableDocument into a response
+func documentError[T any](req *http.Request, err error) (T, int, error) {
+	switch {
+	case errors.Is(err, errDocumentNotFound):
+		return response.PublicNotFoundError[T]()
+	case errors.Is(err, errDocumentForbidden):
+		return response.PublicCustomError[T](err.Error(), http.StatusForbidden)
+	default:
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[T]()
+	}
+}
@@ -0,0 +1,62 @@
+package documents
+
+import (
+	"net/http"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/services/retrieval_service"
+)
+
+type SearchInput struct {
+	Query            string       `json:"query"`
+	DocumentGroupIDs []types.UUID `json:"document_group_ids"`
+	TagIDs           []types.UUID `json:"tag_ids"`
+	Limit            int          `json:"limit"`
+}
+
+// authSearch finds the passages of the organization's documents that best match a query
+//
+//	@Public
+//	@Summary		Search documents
+//	@Description	Hybrid keyword and semantic search over the session organization's documents
+//	@Tags			Document
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		SearchInput	true	"Search"
+//	@Success		200		{object}	response.SuccessResponse{data=[]retrieval_service.Result}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document/search [post]
+func authSearch(_ http.ResponseWriter, req *http.Request) ([]*retrieval_service.Result, int, error) {
+	userObj := helpers.GetLoadedUser(req)
+
+	input, err := request.GetJSONPostAs[*SearchInput](req)
+	if err != nil || tools.Empty(input.Query) {
+		return response.PublicCustomError[[]*retrieval_service.Result]("query is required", http.StatusBadRequest)
+	}
+	if tools.Empty(userObj.OrganizationID.Get()) {
+		return response.PublicCustomError[[]*retrieval_service.Result]("An organization is required", http.StatusBadRequest)
+	}
+
+	results, err := retrieval_service.Retrieve(req.Context(), &retrieval_service.Request{
+		ChunkScope: document_chunk.ChunkScope{
+			OrganizationID:   userObj.OrganizationID.Get(),
+			AccountID:        userObj.ID(),
+			DocumentGroupIDs: input.DocumentGroupIDs,
+			TagIDs:           input.TagIDs,
+			Limit:            input.Limit,
+		},
+		Query: input.Query,
+	})
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[[]*retrieval_service.Result]()
+	}
+
+	return response.Success(results)
+}
@@ -1,4 +1,4 @@
-//go:generate core_gen controller Document -modelPackage=document
+//go:generate core_gen controller Document -modelPackage=document -skip=authCreate,authUpdate
 package documents
 
 import (
@@ -39,6 +39,9 @@ func Setup(coreRouter *router.CoreRouter) {
 			adminR.Put("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
 				constants.ROLE_ADMIN: response.StandardRequestWrapper(adminUpdate),
 			}))
+			adminR.Post("/{id}/embed", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ADMIN: response.StandardRequestWrapper(adminEmbed),
+			}))
 		})
 		r.Group(func(adminR chi.Router) {
 			adminR.Get("/_ts", helpers.RoleHandler(helpers.RoleHandlerMap{
@@ -51,10 +54,56 @@ func Setup(coreRouter *router.CoreRouter) {
 	coreRouter.AddMainRoute(tools.BuildString("/", ROUTE), func(r chi.Router) {
 		r.Group(func(authR chi.Router) {
 			authR.Get("/", helpers.RoleHandler(helpers.RoleHandlerMap{
-				constants.ROLE_ANY_AUTHORIZED: response.StandardRequestWrapper(authIndex),
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authIndex),
 			}))
 			authR.Get("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
-				constants.ROLE_ANY_AUTHORIZED: response.StandardRequestWrapper(authGet),
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authGet),
+			}))
+			authR.Get("/citation/{chunk_id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authCitation),
+			}))
+			authR.Get("/{id}/versions", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authVersions),
+			}))
+			authR.Get("/{id}/download", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authDownload),
+			}))
+		})
+		r.Group(func(authR chi.Router) {
+			authR.Post("/search", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authSearch),
+			}))
+			authR.Post("/upload", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authUpload),
+			}))
+			authR.Post("/webpage", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authWebpage),
+			}))
+			authR.Post("/sitemap", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authSitemap),
+			}))
+			authR.Post("/{id}/uploaded", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authUploaded),
+			}))
+			authR.Post("/{id}/retry", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authRetry),
+			}))
+			authR.Post("/{id}/versions", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authUploadVersion),
+			}))
+			authR.Put("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authUpdate),
+			}))
+			authR.Put("/{id}/tags", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authTags),
+			}))
+			authR.Delete("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ANY_AUTHORIZED: response.StandardPublicRequestWrapper(authDelete),
+			}))
+		})
+		r.Group(func(openR chi.Router) {
+			openR.Get("/{id}/file", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: openFile,
 			}))
 		})
 	})
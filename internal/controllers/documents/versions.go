@@ -0,0 +1,132 @@
+package documents
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
+	"github.com/pkg/errors"
+)
+
+type DownloadResponse struct {
+	URL         string `json:"url"`
+	ExpiresAtTS int64  `json:"expires_at_ts"`
+}
+
+// authVersions lists every version of a document, including the ones retired from search
+//
+//	@Public
+//	@Summary		Document versions
+//	@Description	Returns the version history of a document, newest first
+//	@Tags			Document
+//	@Produce		json
+//	@Param			id	path		string	true	"Document ID, any version"
+//	@Success		200	{object}	response.SuccessResponse{data=[]document.DocumentJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/document/{id}/versions [get]
+func authVersions(_ http.ResponseWriter, req *http.Request) ([]*document.DocumentJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	documentObj, err := document.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[[]*document.DocumentJoined]()
+	}
+	if tools.Empty(documentObj) {
+		return response.PublicNotFoundError[[]*document.DocumentJoined]()
+	}
+
+	versions, err := document.FindVersionsRestrictedJoined(req.Context(), documentObj.VersionRootID(), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[[]*document.DocumentJoined]()
+	}
+
+	return response.Success(versions)
+}
+
+// authUploadVersion starts the upload of a new version of a document
+//
+//	@Public
+//	@Summary		Upload new version
+//	@Description	Creates a pending next version, PUT the file to upload_url then confirm it. The current version stays searchable until the new one is indexed.
+//	@Tags			Document
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string		true	"Current version's document ID"
+//	@Param			body	body		UploadInput	true	"File"
+//	@Success		200		{object}	response.SuccessResponse{data=UploadResponse}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/document/{id}/versions [post]
+func authUploadVersion(_ http.ResponseWriter, req *http.Request) (*UploadResponse, int, error) {
+	user := request.GetReqSession(req).User
+
+	input, err := request.GetJSONPostAs[*UploadInput](req)
+	if err != nil || tools.Empty(input.FileName) {
+		return response.PublicCustomError[*UploadResponse]("file_name is required", http.StatusBadRequest)
+	}
+	if !document_service.Supported(input.ContentType) {
+		return response.PublicCustomError[*UploadResponse]("File type is not supported", http.StatusBadRequest)
+	}
+
+	previousObj, err := getEditableDocument(req)
+	if err != nil {
+		return documentError[*UploadResponse](req, err)
+	}
+
+	documentObj := document.NewPublic(map[string]any{}, user)
+	documentObj.Name.Set(input.Name)
+	documentObj.FileName.Set(input.FileName)
+	documentObj.ContentType.Set(document_service.NormalizeContentType(input.ContentType))
+
+	uploadURL, err := document_service.CreateVersion(req.Context(), &previousObj.Document, documentObj, user)
+	if err != nil {
+		if errors.Is(err, document_service.ErrNotVersionable) {
+			return response.PublicCustomError[*UploadResponse](err.Error(), http.StatusBadRequest)
+		}
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*UploadResponse]()
+	}
+
+	return response.Success(&UploadResponse{
+		Document:  documentObj,
+		UploadURL: uploadURL,
+	})
+}
+
+// authDownload returns a short lived link to the original file of any version of a document
+//
+//	@Public
+//	@Summary		Download document
+//	@Description	Returns a signed link to the uploaded file, older versions stay downloadable
+//	@Tags			Document
+//	@Produce		json
+//	@Param			id	path		string	true	"Document ID"
+//	@Success		200	{object}	response.SuccessResponse{data=DownloadResponse}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/document/{id}/download [get]
+func authDownload(_ http.ResponseWriter, req *http.Request) (*DownloadResponse, int, error) {
+	user := request.GetReqSession(req).User
+
+	documentObj, err := document.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*DownloadResponse]()
+	}
+	if tools.Empty(documentObj) {
+		return response.PublicNotFoundError[*DownloadResponse]()
+	}
+	if tools.Empty(documentObj.RawS3URL.Get()) {
+		return response.PublicCustomError[*DownloadResponse]("Document has no file", http.StatusBadRequest)
+	}
+
+	url, expiresAtTS := document_service.SignedFileURL(documentObj.ID(), time.Now())
+	return response.Success(&DownloadResponse{URL: url, ExpiresAtTS: expiresAtTS})
+}
@@ -53,41 +53,3 @@ func authGet(_ http.ResponseWriter, req *http.Request) (*document.DocumentJoined
 
 	return response.Success(documentObj)
 }
-
-func authCreate(_ http.ResponseWriter, req *http.Request) (*document.Document, int, error) {
-
-	user := request.GetReqSession(req).User
-
-	data := request.GetModelPostData(req)
-	documentObj := document.NewPublic(data, user)
-	err := documentObj.Save(user)
-	if err != nil {
-		log.ErrorContext(err, req.Context())
-		return response.PublicBadRequestError[*document.Document]()
-
-	}
-
-	return response.Success(documentObj)
-}
-
-func authUpdate(_ http.ResponseWriter, req *http.Request) (*document.DocumentJoined, int, error) {
-
-	user := request.GetReqSession(req).User
-
-	id := chi.URLParam(req, "id")
-	data := request.GetModelPostData(req)
-	documentObj, err := document.GetRestrictedJoined(req.Context(), types.UUID(id), user)
-	if err != nil {
-		log.ErrorContext(err, req.Context())
-		return response.PublicBadRequestError[*document.DocumentJoined]()
-	}
-
-	document.UpdatePublic(&documentObj.Document, data, user)
-	err = documentObj.Save(user)
-	if err != nil {
-		log.ErrorContext(err, req.Context())
-		return response.PublicBadRequestError[*document.DocumentJoined]()
-	}
-
-	return response.Success(documentObj)
-}
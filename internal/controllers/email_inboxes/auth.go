@@ -0,0 +1,166 @@
+package email_inboxes
+
+import (
+	"context"
+	"net/http"
+	"net/mail"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_inbox"
+)
+
+// authCreate connects a mailbox address to one of the organization's agents
+//
+//	@Public
+//	@Summary		Create email inbox
+//	@Description	Routes mail sent to an address to an agent of the session organization
+//	@Tags			EmailInbox
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		email_inbox.EmailInbox	true	"Inbox"
+//	@Success		200		{object}	response.SuccessResponse{data=email_inbox.EmailInbox}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/email_inbox [post]
+func authCreate(_ http.ResponseWriter, req *http.Request) (*email_inbox.EmailInbox, int, error) {
+	userObj := helpers.GetLoadedUser(req)
+
+	data := request.GetModelPostData(req)
+	inboxObj := email_inbox.NewPublic(data, userObj)
+
+	msg, err := validateInbox(req.Context(), inboxObj, int64(userObj.BillingPlanLevel.Get()))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_inbox.EmailInbox]()
+	}
+	if msg != "" {
+		return response.PublicCustomError[*email_inbox.EmailInbox](msg, http.StatusBadRequest)
+	}
+
+	err = inboxObj.Save(userObj)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_inbox.EmailInbox]()
+	}
+
+	return response.Success(inboxObj)
+}
+
+// authUpdate changes an inbox's address, agent or approval setting
+//
+//	@Public
+//	@Summary		Update email inbox
+//	@Description	Updates an inbox of the session organization
+//	@Tags			EmailInbox
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Inbox ID"
+//	@Param			body	body		email_inbox.EmailInbox	true	"Inbox"
+//	@Success		200		{object}	response.SuccessResponse{data=email_inbox.EmailInboxJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/email_inbox/{id} [put]
+func authUpdate(_ http.ResponseWriter, req *http.Request) (*email_inbox.EmailInboxJoined, int, error) {
+	userObj := helpers.GetLoadedUser(req)
+
+	inboxObj, err := getInbox(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_inbox.EmailInboxJoined]()
+	}
+	if tools.Empty(inboxObj) {
+		return response.PublicNotFoundError[*email_inbox.EmailInboxJoined]()
+	}
+
+	data := request.GetModelPostData(req)
+	email_inbox.UpdatePublic(&inboxObj.EmailInbox, data, userObj)
+
+	msg, err := validateInbox(req.Context(), &inboxObj.EmailInbox, int64(userObj.BillingPlanLevel.Get()))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_inbox.EmailInboxJoined]()
+	}
+	if msg != "" {
+		return response.PublicCustomError[*email_inbox.EmailInboxJoined](msg, http.StatusBadRequest)
+	}
+
+	err = inboxObj.Save(userObj)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_inbox.EmailInboxJoined]()
+	}
+
+	return response.Success(inboxObj)
+}
+
+// authDelete disconnects an inbox, mail to its address is no longer answered
+//
+//	@Public
+//	@Summary		Delete email inbox
+//	@Description	Deletes an inbox of the session organization
+//	@Tags			EmailInbox
+//	@Produce		json
+//	@Param			id	path		string	true	"Inbox ID"
+//	@Success		200	{object}	response.SuccessResponse{data=email_inbox.EmailInboxJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/email_inbox/{id} [delete]
+func authDelete(_ http.ResponseWriter, req *http.Request) (*email_inbox.EmailInboxJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	inboxObj, err := getInbox(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_inbox.EmailInboxJoined]()
+	}
+	if tools.Empty(inboxObj) {
+		return response.PublicNotFoundError[*email_inbox.EmailInboxJoined]()
+	}
+
+	inboxObj.Deleted.Set(1)
+	err = inboxObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_inbox.EmailInboxJoined]()
+	}
+
+	return response.Success(inboxObj)
+}
+
+func getInbox(req *http.Request) (*email_inbox.EmailInboxJoined, error) {
+	user := request.GetReqSession(req).User
+	return email_inbox.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+}
+
+// validateInbox returns a message describing what is wrong with the inbox, empty when it is valid
+func validateInbox(ctx context.Context, inboxObj *email_inbox.EmailInbox, planLevel int64) (string, error) {
+	address, err := mail.ParseAddress(inboxObj.Address.Get())
+	if err != nil || address.Address != inboxObj.Address.Get() {
+		return "A valid email address is required", nil
+	}
+
+	existing, err := email_inbox.GetByAddress(ctx, inboxObj.Address.Get())
+	if err != nil {
+		return "", err
+	}
+	if !tools.Empty(existing) && existing.ID() != inboxObj.ID() {
+		return "Address is already connected to an inbox", nil
+	}
+
+	if tools.Empty(inboxObj.AgentID.Get()) {
+		return "Agent is required", nil
+	}
+	agentObj, err := agent.Get(ctx, inboxObj.AgentID.Get())
+	if err != nil {
+		return "", err
+	}
+	if tools.Empty(agentObj) || !agentObj.CanBeUsedBy(inboxObj.OrganizationID.Get(), planLevel) {
+		return "Agent not available on your plan", nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,135 @@
+package email_inboxes
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/services/mailbox_service"
+)
+
+const (
+	// INBOUND_BODY_LIMIT caps the size of a posted message
+	INBOUND_BODY_LIMIT = 25 << 20
+	// INBOUND_KEY_HEADER carries the shared key, SNS subscriptions pass it as the key query parameter instead.
+	// SNS posts are also checked against the SNS signature.
+	INBOUND_KEY_HEADER = "X-Inbound-Key"
+)
+
+// openInboundSES receives SES receipt notifications delivered through an SNS subscription
+func openInboundSES(res http.ResponseWriter, req *http.Request) {
+	if !verifyInboundKey(req) {
+		response.ErrorWrapper(res, req, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, INBOUND_BODY_LIMIT))
+	if err != nil {
+		response.ErrorWrapper(res, req, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	message, notification, err := mailbox_service.ParseSNS(body)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		response.ErrorWrapper(res, req, "invalid notification", http.StatusBadRequest)
+		return
+	}
+
+	err = mailbox_service.VerifySNS(req.Context(), message)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		response.ErrorWrapper(res, req, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch message.Type {
+	case mailbox_service.SNS_TYPE_SUBSCRIPTION_CONFIRMATION:
+		err = mailbox_service.ConfirmSubscription(req.Context(), message)
+		if err != nil {
+			log.ErrorContext(err, req.Context())
+			response.ErrorWrapper(res, req, "confirmation failed", http.StatusBadRequest)
+			return
+		}
+	case mailbox_service.SNS_TYPE_NOTIFICATION:
+		// a failure here is answered with an error so SNS delivers the notification again
+		key, err := notification.StoredKey(req.Context())
+		if err != nil {
+			log.ErrorContext(err, req.Context())
+			response.ErrorWrapper(res, req, "message could not be stored", http.StatusInternalServerError)
+			return
+		}
+		err = worker_jobs.QueueInboundEmailJob(key, notification.Receipt.Recipients)
+		if err != nil {
+			log.ErrorContext(err, req.Context())
+			response.ErrorWrapper(res, req, "message could not be queued", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	response.JSONDataResponseWrapper(res, req, "success")
+}
+
+// openInboundMIME receives a raw message from a generic inbound parse webhook, posted as multipart form data
+// with the message in the email field and optionally the envelope recipients in the recipient field
+func openInboundMIME(res http.ResponseWriter, req *http.Request) {
+	if !verifyInboundKey(req) {
+		response.ErrorWrapper(res, req, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	req.Body = http.MaxBytesReader(res, req.Body, INBOUND_BODY_LIMIT)
+	err := req.ParseMultipartForm(INBOUND_BODY_LIMIT)
+	if err != nil {
+		response.ErrorWrapper(res, req, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	raw := []byte(req.FormValue("email"))
+	if len(raw) == 0 {
+		file, _, err := req.FormFile("email")
+		if err != nil {
+			response.ErrorWrapper(res, req, "email is required", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		raw, err = io.ReadAll(file)
+		if err != nil {
+			response.ErrorWrapper(res, req, "invalid email", http.StatusBadRequest)
+			return
+		}
+	}
+
+	recipients := []string{}
+	for _, recipient := range strings.Split(req.FormValue("recipient"), ",") {
+		if strings.TrimSpace(recipient) != "" {
+			recipients = append(recipients, recipient)
+		}
+	}
+
+	key, err := mailbox_service.StageRaw(req.Context(), raw)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		response.ErrorWrapper(res, req, "message could not be stored", http.StatusInternalServerError)
+		return
+	}
+	err = worker_jobs.QueueInboundEmailJob(key, recipients)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		response.ErrorWrapper(res, req, "message could not be queued", http.StatusInternalServerError)
+		return
+	}
+
+	response.JSONDataResponseWrapper(res, req, "success")
+}
+
+func verifyInboundKey(req *http.Request) bool {
+	key := req.Header.Get(INBOUND_KEY_HEADER)
+	if key == "" {
+		key = req.URL.Query().Get("key")
+	}
+	return mailbox_service.VerifyKey(key)
+}
@@ -0,0 +1,17 @@
+package email_inboxes
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
+)
+
+func addSearch(parameters *model.Options, query string) {
+	if tools.IsAnyValidUUID(query) {
+		parameters.WithCondition("(%s.id = :id: OR %s.organization_id = :id: OR %s.agent_id = :id:)", TABLE_NAME, TABLE_NAME, TABLE_NAME)
+		parameters.WithParam(":id:", query)
+		return
+	}
+
+	parameters.WithCondition("(%s.address ILIKE :q: OR %s.name ILIKE :q:)", TABLE_NAME, TABLE_NAME)
+	parameters.WithParam(":q:", "%"+query+"%")
+}
@@ -0,0 +1,73 @@
+//go:generate core_gen controller EmailInbox -modelPackage=email_inbox -skip=adminCreate,adminUpdate,authCreate,authUpdate
+package email_inboxes
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/router"
+	"github.com/griffnb/core/lib/router/response"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_inbox"
+)
+
+const (
+	TABLE_NAME string = email_inbox.TABLE
+	ROUTE      string = "email_inbox"
+)
+
+// Setup sets up the router
+func Setup(coreRouter *router.CoreRouter) {
+	// Admin routes
+	coreRouter.AddMainRoute(tools.BuildString("/admin/", ROUTE), func(r chi.Router) {
+		r.Group(func(adminR chi.Router) {
+			adminR.Get("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminIndex),
+			}))
+			adminR.Get("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminGet),
+			}))
+			adminR.Get("/count", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: response.StandardRequestWrapper(adminCount),
+			}))
+		})
+		r.Group(func(adminR chi.Router) {
+			adminR.Get("/_ts", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_READ_ADMIN: helpers.TSValidation(TABLE_NAME),
+			}))
+		})
+	})
+
+	// Public authenticated routes
+	coreRouter.AddMainRoute(tools.BuildString("/", ROUTE), func(r chi.Router) {
+		r.Group(func(authR chi.Router) {
+			authR.Get("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ORG_ADMIN: response.StandardPublicRequestWrapper(authIndex),
+			}))
+			authR.Get("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ORG_ADMIN: response.StandardPublicRequestWrapper(authGet),
+			}))
+		})
+		r.Group(func(authR chi.Router) {
+			authR.Post("/", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ORG_ADMIN: response.StandardPublicRequestWrapper(authCreate),
+			}))
+			authR.Put("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ORG_ADMIN: response.StandardPublicRequestWrapper(authUpdate),
+			}))
+			authR.Delete("/{id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_ORG_ADMIN: response.StandardPublicRequestWrapper(authDelete),
+			}))
+		})
+
+		r.Group(func(openR chi.Router) {
+			openR.Post("/inbound/ses", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: openInboundSES,
+			}))
+			openR.Post("/inbound/mime", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: openInboundMIME,
+			}))
+		})
+	})
+}
@@ -0,0 +1,141 @@
+package email_messages
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_inbox"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_message"
+	"github.com/griffnb/techboss-ai-go/internal/services/mailbox_service"
+)
+
+// authUpdate edits a held reply before it is approved
+//
+//	@Public
+//	@Summary		Edit email draft
+//	@Description	Changes the subject or body of an agent reply waiting for approval
+//	@Tags			EmailMessage
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Email message ID"
+//	@Param			body	body		email_message.EmailMessage	true	"Draft"
+//	@Success		200		{object}	response.SuccessResponse{data=email_message.EmailMessageJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/email_message/{id} [put]
+func authUpdate(_ http.ResponseWriter, req *http.Request) (*email_message.EmailMessageJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	emailMessageObj, err := getEmailMessage(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_message.EmailMessageJoined]()
+	}
+	if tools.Empty(emailMessageObj) {
+		return response.PublicNotFoundError[*email_message.EmailMessageJoined]()
+	}
+	if !emailMessageObj.CanSend() {
+		return response.PublicCustomError[*email_message.EmailMessageJoined]("Only unsent replies can be edited", http.StatusBadRequest)
+	}
+
+	data := request.GetModelPostData(req)
+	email_message.UpdatePublic(&emailMessageObj.EmailMessage, data, user)
+
+	err = emailMessageObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_message.EmailMessageJoined]()
+	}
+
+	return response.Success(emailMessageObj)
+}
+
+// authApprove sends a held reply, or retries one that failed to send
+//
+//	@Public
+//	@Summary		Approve email draft
+//	@Description	Sends an agent reply that was held for approval
+//	@Tags			EmailMessage
+//	@Produce		json
+//	@Param			id	path		string	true	"Email message ID"
+//	@Success		200	{object}	response.SuccessResponse{data=email_message.EmailMessageJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/email_message/{id}/approve [post]
+func authApprove(_ http.ResponseWriter, req *http.Request) (*email_message.EmailMessageJoined, int, error) {
+	userObj := helpers.GetLoadedUser(req)
+
+	emailMessageObj, err := getEmailMessage(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_message.EmailMessageJoined]()
+	}
+	if tools.Empty(emailMessageObj) {
+		return response.PublicNotFoundError[*email_message.EmailMessageJoined]()
+	}
+	if !emailMessageObj.CanSend() {
+		return response.PublicCustomError[*email_message.EmailMessageJoined]("Only unsent replies can be approved", http.StatusBadRequest)
+	}
+
+	inboxObj, err := email_inbox.Get(req.Context(), emailMessageObj.InboxID.Get())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_message.EmailMessageJoined]()
+	}
+	if tools.Empty(inboxObj) || !inboxObj.IsActive() {
+		return response.PublicCustomError[*email_message.EmailMessageJoined]("Inbox is no longer active", http.StatusBadRequest)
+	}
+
+	err = mailbox_service.Send(req.Context(), inboxObj, &emailMessageObj.EmailMessage, userObj.ID())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicCustomError[*email_message.EmailMessageJoined]("Reply could not be sent", http.StatusBadRequest)
+	}
+
+	return response.Success(emailMessageObj)
+}
+
+// authDiscard drops a held reply without sending it
+//
+//	@Public
+//	@Summary		Discard email draft
+//	@Description	Discards an agent reply that was held for approval
+//	@Tags			EmailMessage
+//	@Produce		json
+//	@Param			id	path		string	true	"Email message ID"
+//	@Success		200	{object}	response.SuccessResponse{data=email_message.EmailMessageJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/email_message/{id}/discard [post]
+func authDiscard(_ http.ResponseWriter, req *http.Request) (*email_message.EmailMessageJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	emailMessageObj, err := getEmailMessage(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_message.EmailMessageJoined]()
+	}
+	if tools.Empty(emailMessageObj) {
+		return response.PublicNotFoundError[*email_message.EmailMessageJoined]()
+	}
+	if !emailMessageObj.CanSend() {
+		return response.PublicCustomError[*email_message.EmailMessageJoined]("Only unsent replies can be discarded", http.StatusBadRequest)
+	}
+
+	emailMessageObj.Status.Set(email_message.STATUS_DISCARDED)
+	err = emailMessageObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*email_message.EmailMessageJoined]()
+	}
+
+	return response.Success(emailMessageObj)
+}
+
+func getEmailMessage(req *http.Request) (*email_message.EmailMessageJoined, error) {
+	user := request.GetReqSession(req).User
+	return email_message.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+}
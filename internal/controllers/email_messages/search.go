@@ -0,0 +1,17 @@
+package email_messages
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
+)
+
+func addSearch(parameters *model.Options, query string) {
+	if tools.IsAnyValidUUID(query) {
+		parameters.WithCondition("(%s.id = :id: OR %s.conversation_id = :id: OR %s.inbox_id = :id:)", TABLE_NAME, TABLE_NAME, TABLE_NAME)
+		parameters.WithParam(":id:", query)
+		return
+	}
+
+	parameters.WithCondition("(%s.subject ILIKE :q: OR %s.from_address ILIKE :q: OR %s.to_address ILIKE :q:)", TABLE_NAME, TABLE_NAME, TABLE_NAME)
+	parameters.WithParam(":q:", "%"+query+"%")
+}
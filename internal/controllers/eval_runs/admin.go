@@ -0,0 +1,137 @@
+package eval_runs
+
+import (
+	"net/http"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_run"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+	"github.com/griffnb/techboss-ai-go/internal/services/eval_service"
+	"github.com/pkg/errors"
+)
+
+type RunInput struct {
+	SuiteID       types.UUID      `json:"suite_id"`
+	AgentID       types.UUID      `json:"agent_id"`       // defaults to the suite's agent
+	Provider      string          `json:"provider"`       // openai or stub
+	Label         string          `json:"label"`          // shown when comparing runs
+	AgentSettings *agent.Settings `json:"agent_settings"` // optional candidate settings to test instead of the saved ones
+}
+
+// adminRun queues a suite run against an agent
+//
+//	@Summary		Run eval suite
+//	@Description	Creates a pending eval run and queues it on the task worker
+//	@Tags			EvalRun
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		RunInput	true	"Run input"
+//	@Success		200		{object}	response.SuccessResponse{data=eval_run.EvalRun}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/admin/eval_run [post]
+func adminRun(_ http.ResponseWriter, req *http.Request) (*eval_run.EvalRun, int, error) {
+	userSession := request.GetReqSession(req)
+
+	input, err := request.GetJSONPostAs[*RunInput](req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*eval_run.EvalRun](err)
+	}
+
+	suiteObj, err := eval_suite.Get(req.Context(), input.SuiteID)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*eval_run.EvalRun](err)
+	}
+	if tools.Empty(suiteObj) {
+		return response.AdminBadRequestError[*eval_run.EvalRun](errors.Errorf("Suite not found with ID: %s", input.SuiteID))
+	}
+
+	agentID := input.AgentID
+	if tools.Empty(agentID) {
+		agentID = suiteObj.AgentID.Get()
+	}
+
+	agentObj, err := agent.Get(req.Context(), agentID)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*eval_run.EvalRun](err)
+	}
+	if tools.Empty(agentObj) {
+		return response.AdminBadRequestError[*eval_run.EvalRun](errors.Errorf("Agent not found with ID: %s", agentID))
+	}
+
+	runObj, err := eval_service.CreateRun(req.Context(), suiteObj, agentObj, input.Provider, input.Label, input.AgentSettings, userSession.User)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*eval_run.EvalRun](err)
+	}
+
+	err = worker_jobs.QueueEvalRunJob(runObj.ID())
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*eval_run.EvalRun](err)
+	}
+
+	return response.Success(runObj)
+}
+
+type RunComparison struct {
+	Base    *eval_run.EvalRunJoined    `json:"base"`
+	Compare *eval_run.EvalRunJoined    `json:"compare"`
+	Cases   []*eval_run.CaseComparison `json:"cases"`
+}
+
+// adminCompare lines up two runs case by case
+//
+//	@Summary		Compare eval runs
+//	@Description	Returns two runs side by side with each case marked same, fixed, regressed, added or removed
+//	@Tags			EvalRun
+//	@Produce		json
+//	@Param			base_id		query		string	true	"Base run ID"
+//	@Param			compare_id	query		string	true	"Compared run ID"
+//	@Success		200			{object}	response.SuccessResponse{data=RunComparison}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Router			/admin/eval_run/compare [get]
+func adminCompare(_ http.ResponseWriter, req *http.Request) (*RunComparison, int, error) {
+	baseObj, baseResults, err := loadRunResults(req, req.URL.Query().Get("base_id"))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*RunComparison](err)
+	}
+
+	compareObj, compareResults, err := loadRunResults(req, req.URL.Query().Get("compare_id"))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.AdminBadRequestError[*RunComparison](err)
+	}
+
+	return response.Success(&RunComparison{
+		Base:    baseObj,
+		Compare: compareObj,
+		Cases:   eval_run.CompareResults(baseResults, compareResults),
+	})
+}
+
+func loadRunResults(req *http.Request, id string) (*eval_run.EvalRunJoined, []*eval_run.CaseResult, error) {
+	runObj, err := eval_run.GetJoined(req.Context(), types.UUID(id))
+	if err != nil {
+		return nil, nil, err
+	}
+	if tools.Empty(runObj) {
+		return nil, nil, errors.Errorf("Object not found with ID: %s", id)
+	}
+
+	results, err := runObj.Results.Get()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return runObj, results, nil
+}
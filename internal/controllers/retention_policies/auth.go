@@ -0,0 +1,140 @@
+package retention_policies
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/retention_policy"
+)
+
+// authCreate creates a retention policy for the organization
+//
+//	@Public
+//	@Summary		Create retention policy
+//	@Description	Creates a policy deleting the uploaded files, or the documents, of the session organization once they are older than its days, optionally only those tagged with its tag
+//	@Tags			RetentionPolicy
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		retention_policy.RetentionPolicy	true	"Policy"
+//	@Success		200		{object}	response.SuccessResponse{data=retention_policy.RetentionPolicy}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/retention_policy [post]
+func authCreate(_ http.ResponseWriter, req *http.Request) (*retention_policy.RetentionPolicy, int, error) {
+	user := request.GetReqSession(req).User
+
+	data := request.GetModelPostData(req)
+	policyObj := retention_policy.NewPublic(data, user)
+
+	msg := validatePolicy(policyObj)
+	if msg != "" {
+		return response.PublicCustomError[*retention_policy.RetentionPolicy](msg, http.StatusBadRequest)
+	}
+
+	err := policyObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*retention_policy.RetentionPolicy]()
+	}
+
+	return response.Success(policyObj)
+}
+
+// authUpdate changes what a policy deletes and when
+//
+//	@Public
+//	@Summary		Update retention policy
+//	@Description	Updates a policy of the session organization, the change applies from its next daily run
+//	@Tags			RetentionPolicy
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Policy ID"
+//	@Param			body	body		retention_policy.RetentionPolicy	true	"Policy"
+//	@Success		200		{object}	response.SuccessResponse{data=retention_policy.RetentionPolicyJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/retention_policy/{id} [put]
+func authUpdate(_ http.ResponseWriter, req *http.Request) (*retention_policy.RetentionPolicyJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	policyObj, err := getPolicy(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*retention_policy.RetentionPolicyJoined]()
+	}
+	if tools.Empty(policyObj) {
+		return response.PublicNotFoundError[*retention_policy.RetentionPolicyJoined]()
+	}
+
+	data := request.GetModelPostData(req)
+	retention_policy.UpdatePublic(&policyObj.RetentionPolicy, data, user)
+
+	msg := validatePolicy(&policyObj.RetentionPolicy)
+	if msg != "" {
+		return response.PublicCustomError[*retention_policy.RetentionPolicyJoined](msg, http.StatusBadRequest)
+	}
+
+	err = policyObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*retention_policy.RetentionPolicyJoined]()
+	}
+
+	return response.Success(policyObj)
+}
+
+// authDelete deletes a policy, documents it already removed are not restored
+//
+//	@Public
+//	@Summary		Delete retention policy
+//	@Description	Deletes a policy of the session organization so it no longer runs
+//	@Tags			RetentionPolicy
+//	@Produce		json
+//	@Param			id	path		string	true	"Policy ID"
+//	@Success		200	{object}	response.SuccessResponse{data=retention_policy.RetentionPolicyJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/retention_policy/{id} [delete]
+func authDelete(_ http.ResponseWriter, req *http.Request) (*retention_policy.RetentionPolicyJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	policyObj, err := getPolicy(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*retention_policy.RetentionPolicyJoined]()
+	}
+	if tools.Empty(policyObj) {
+		return response.PublicNotFoundError[*retention_policy.RetentionPolicyJoined]()
+	}
+
+	policyObj.Deleted.Set(1)
+	err = policyObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*retention_policy.RetentionPolicyJoined]()
+	}
+
+	return response.Success(policyObj)
+}
+
+func getPolicy(req *http.Request) (*retention_policy.RetentionPolicyJoined, error) {
+	user := request.GetReqSession(req).User
+	return retention_policy.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+}
+
+// validatePolicy returns a message describing what is wrong with the policy, empty when it is valid
+func validatePolicy(policyObj *retention_policy.RetentionPolicy) string {
+	if tools.Empty(policyObj.Name.Get()) {
+		return "name is required"
+	}
+	if !policyObj.Action.Get().Valid() {
+		return "Unknown action"
+	}
+	if policyObj.Days.Get() < 1 || policyObj.Days.Get() > retention_policy.MAX_DAYS {
+		return fmt.Sprintf("days must be between 1 and %d", retention_policy.MAX_DAYS)
+	}
+	return ""
+}
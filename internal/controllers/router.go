@@ -3,14 +3,24 @@ package controllers
 import (
 	"github.com/griffnb/techboss-ai-go/internal/controllers/accounts"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/admins"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/agent_attributes"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/agents"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/ai"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/ai_tool_reviews"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/ai_tools"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/billing"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/billing_plan_prices"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/billing_plans"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/categories"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/change_logs"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/conversations"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/document_groups"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/documents"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/email_inboxes"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/email_messages"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/eval_runs"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/eval_suites"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/form_submissions"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/subscriptions"
 
 	"github.com/griffnb/core/lib/router"
@@ -19,7 +29,13 @@ import (
 	"github.com/griffnb/techboss-ai-go/internal/controllers/login"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/logs"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/organizations"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/retention_policies"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/slack_installations"
 	"github.com/griffnb/techboss-ai-go/internal/controllers/utilities"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/webhook_deliveries"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/webhook_endpoints"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/widget_leads"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/widgets"
 )
 
 // Setup Adds the controllers to the router
@@ -35,15 +51,31 @@ func Setup(coreRouter *router.CoreRouter) {
 
 	ai.Setup(coreRouter)
 	agents.Setup(coreRouter)
+	agent_attributes.Setup(coreRouter)
 	accounts.Setup(coreRouter)
+	ai_tool_reviews.Setup(coreRouter)
 	ai_tools.Setup(coreRouter)
 	billing.Setup(coreRouter)
 	billing_plans.Setup(coreRouter)
 	billing_plan_prices.Setup(coreRouter)
 	categories.Setup(coreRouter)
+	conversations.Setup(coreRouter)
+	document_groups.Setup(coreRouter)
+	documents.Setup(coreRouter)
+	email_inboxes.Setup(coreRouter)
+	email_messages.Setup(coreRouter)
+	eval_runs.Setup(coreRouter)
+	eval_suites.Setup(coreRouter)
+	form_submissions.Setup(coreRouter)
 	leads.Setup(coreRouter)
 	organizations.Setup(coreRouter)
+	retention_policies.Setup(coreRouter)
+	slack_installations.Setup(coreRouter)
 	subscriptions.Setup(coreRouter)
+	webhook_deliveries.Setup(coreRouter)
+	webhook_endpoints.Setup(coreRouter)
+	widget_leads.Setup(coreRouter)
+	widgets.Setup(coreRouter)
 	agents.Setup(coreRouter)
 
 	// Print all routes
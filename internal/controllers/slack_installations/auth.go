@@ -0,0 +1,138 @@
+package slack_installations
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/slack_installation"
+	"github.com/griffnb/techboss-ai-go/internal/services/slack_service"
+)
+
+type InstallResponse struct {
+	URL string `json:"url"`
+}
+
+// authInstall returns the Slack link an organization admin follows to install the app
+//
+//	@Public
+//	@Summary		Slack install link
+//	@Description	Returns the Slack OAuth link that installs the app for the session organization
+//	@Tags			SlackInstallation
+//	@Produce		json
+//	@Success		200	{object}	response.SuccessResponse{data=InstallResponse}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/slack_installation/install [get]
+func authInstall(_ http.ResponseWriter, req *http.Request) (*InstallResponse, int, error) {
+	userObj := helpers.GetLoadedUser(req)
+
+	if !slack_service.Configured() {
+		return response.PublicCustomError[*InstallResponse]("Slack is not configured", http.StatusBadRequest)
+	}
+
+	return response.Success(&InstallResponse{
+		URL: slack_service.InstallURL(userObj.OrganizationID.Get(), userObj.ID()),
+	})
+}
+
+// authUpdate changes which agents answer in which channels
+//
+//	@Public
+//	@Summary		Update Slack channel mapping
+//	@Description	Maps Slack channels and direct messages to agents for an installation of the session organization
+//	@Tags			SlackInstallation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string									true	"Installation ID"
+//	@Param			body	body		slack_installation.SlackInstallation	true	"Channel mapping"
+//	@Success		200		{object}	response.SuccessResponse{data=slack_installation.SlackInstallationJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/slack_installation/{id} [put]
+func authUpdate(_ http.ResponseWriter, req *http.Request) (*slack_installation.SlackInstallationJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	installationObj, err := slack_installation.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*slack_installation.SlackInstallationJoined]()
+	}
+	if tools.Empty(installationObj) {
+		return response.PublicNotFoundError[*slack_installation.SlackInstallationJoined]()
+	}
+
+	data := request.GetModelPostData(req)
+	slack_installation.UpdatePublic(&installationObj.SlackInstallation, data, user)
+
+	agentIDs := []types.UUID{}
+	channels, err := installationObj.Channels.Get()
+	if err != nil {
+		return response.PublicCustomError[*slack_installation.SlackInstallationJoined]("Invalid channel mapping", http.StatusBadRequest)
+	}
+	for _, channel := range channels {
+		if tools.Empty(channel.ChannelID) {
+			return response.PublicCustomError[*slack_installation.SlackInstallationJoined]("Channel is required", http.StatusBadRequest)
+		}
+		agentIDs = append(agentIDs, channel.AgentID)
+	}
+	if !tools.Empty(installationObj.DMAgentID.Get()) {
+		agentIDs = append(agentIDs, installationObj.DMAgentID.Get())
+	}
+
+	for _, agentID := range agentIDs {
+		agentObj, err := agent.Get(req.Context(), agentID)
+		if err != nil {
+			log.ErrorContext(err, req.Context())
+			return response.PublicBadRequestError[*slack_installation.SlackInstallationJoined]()
+		}
+		if tools.Empty(agentObj) || !agentObj.CanBeUsedBy(installationObj.OrganizationID.Get(), int64(installationObj.BillingPlanLevel.Get())) {
+			return response.PublicCustomError[*slack_installation.SlackInstallationJoined]("Agent not available on your plan", http.StatusBadRequest)
+		}
+	}
+
+	err = installationObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*slack_installation.SlackInstallationJoined]()
+	}
+
+	return response.Success(installationObj)
+}
+
+// authDelete disconnects a workspace, the app stops answering there
+//
+//	@Public
+//	@Summary		Disconnect Slack workspace
+//	@Description	Removes an installation of the session organization
+//	@Tags			SlackInstallation
+//	@Produce		json
+//	@Param			id	path		string	true	"Installation ID"
+//	@Success		200	{object}	response.SuccessResponse{data=slack_installation.SlackInstallationJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/slack_installation/{id} [delete]
+func authDelete(_ http.ResponseWriter, req *http.Request) (*slack_installation.SlackInstallationJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	installationObj, err := slack_installation.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*slack_installation.SlackInstallationJoined]()
+	}
+	if tools.Empty(installationObj) {
+		return response.PublicNotFoundError[*slack_installation.SlackInstallationJoined]()
+	}
+
+	installationObj.Deleted.Set(1)
+	err = installationObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*slack_installation.SlackInstallationJoined]()
+	}
+
+	return response.Success(installationObj)
+}
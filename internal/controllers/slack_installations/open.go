@@ -0,0 +1,86 @@
+package slack_installations
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/services/slack_service"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// EVENT_BODY_LIMIT caps the Events API payload read before verification
+const EVENT_BODY_LIMIT = 1 << 20
+
+// openOAuthCallback finishes the Slack install and sends the admin back to the app
+func openOAuthCallback(res http.ResponseWriter, req *http.Request) {
+	redirect := environment.GetConfig().Server.AppURL + "/settings/integrations/slack"
+
+	query := req.URL.Query()
+	if query.Get("error") != "" {
+		http.Redirect(res, req, redirect+"?error="+url.QueryEscape(query.Get("error")), http.StatusFound)
+		return
+	}
+
+	_, err := slack_service.CompleteInstall(req.Context(), query.Get("code"), query.Get("state"))
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		http.Redirect(res, req, redirect+"?error=install_failed", http.StatusFound)
+		return
+	}
+
+	http.Redirect(res, req, redirect+"?installed=1", http.StatusFound)
+}
+
+// openEvents receives the Slack Events API. Callbacks are acknowledged right away and answered in the background,
+// Slack expects a response within three seconds.
+func openEvents(res http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(req.Body, EVENT_BODY_LIMIT))
+	if err != nil {
+		response.ErrorWrapper(res, req, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	err = slack_service.VerifyRequest(req.Header, body)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		response.ErrorWrapper(res, req, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event, err := slack_service.ParseEvent(body)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		response.ErrorWrapper(res, req, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	switch event.Type {
+	case slackevents.URLVerification:
+		verification, ok := event.Data.(*slackevents.EventsAPIURLVerificationEvent)
+		if !ok {
+			response.ErrorWrapper(res, req, "invalid event", http.StatusBadRequest)
+			return
+		}
+		res.Header().Set("Content-Type", "text/plain")
+		_, _ = res.Write([]byte(verification.Challenge))
+		return
+	case slackevents.CallbackEvent:
+		// Retries follow a slow ack, the first delivery is already being answered
+		if req.Header.Get("X-Slack-Retry-Num") == "" {
+			bgContext := context.WithoutCancel(req.Context())
+			go func() {
+				err := slack_service.HandleCallback(bgContext, event)
+				if err != nil {
+					log.ErrorContext(err, bgContext)
+				}
+			}()
+		}
+	}
+
+	response.JSONDataResponseWrapper(res, req, "success")
+}
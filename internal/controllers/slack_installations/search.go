@@ -0,0 +1,18 @@
+package slack_installations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
+)
+
+func addSearch(parameters *model.Options, query string) {
+	if tools.IsAnyValidUUID(query) {
+		parameters.WithCondition("(%s.id = :id: OR %s.organization_id = :id:)", TABLE_NAME, TABLE_NAME)
+		parameters.WithParam(":id:", query)
+		return
+	}
+
+	parameters.WithCondition("(%s.team_id = :team_id: OR %s.team_name ILIKE :q:)", TABLE_NAME, TABLE_NAME)
+	parameters.WithParam(":team_id:", query)
+	parameters.WithParam(":q:", "%"+query+"%")
+}
@@ -0,0 +1,51 @@
+package webhook_deliveries
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/webhook_delivery"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
+	"github.com/pkg/errors"
+)
+
+// authReplay sends a logged event to its endpoint again as a new delivery
+//
+//	@Public
+//	@Summary		Replay webhook delivery
+//	@Description	Queues a new delivery of the same event payload to the same endpoint
+//	@Tags			WebhookDelivery
+//	@Produce		json
+//	@Param			id	path		string	true	"Delivery ID"
+//	@Success		200	{object}	response.SuccessResponse{data=webhook_delivery.WebhookDelivery}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Failure		404	{object}	response.ErrorResponse
+//	@Router			/webhook_delivery/{id}/replay [post]
+func authReplay(_ http.ResponseWriter, req *http.Request) (*webhook_delivery.WebhookDelivery, int, error) {
+	user := request.GetReqSession(req).User
+
+	deliveryObj, err := webhook_delivery.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*webhook_delivery.WebhookDelivery]()
+	}
+	if tools.Empty(deliveryObj) {
+		return response.PublicNotFoundError[*webhook_delivery.WebhookDelivery]()
+	}
+
+	replayObj, err := webhook_service.Replay(req.Context(), &deliveryObj.WebhookDelivery)
+	if err != nil {
+		if errors.Is(err, webhook_service.ErrEndpointInactive) {
+			return response.PublicCustomError[*webhook_delivery.WebhookDelivery]("Endpoint is disabled", http.StatusBadRequest)
+		}
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*webhook_delivery.WebhookDelivery]()
+	}
+
+	return response.Success(replayObj)
+}
@@ -0,0 +1,225 @@
+package webhook_endpoints
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/webhook_endpoint"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
+)
+
+// EndpointWithSecret returns the signing secret alongside the endpoint, the only time it can be read
+type EndpointWithSecret struct {
+	Endpoint *webhook_endpoint.WebhookEndpoint `json:"endpoint"`
+	Secret   string                            `json:"secret"`
+}
+
+// authCreate registers a webhook endpoint for the organization
+//
+//	@Public
+//	@Summary		Create webhook endpoint
+//	@Description	Registers an endpoint for the session organization and returns its signing secret once
+//	@Tags			WebhookEndpoint
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		webhook_endpoint.WebhookEndpoint	true	"Endpoint"
+//	@Success		200		{object}	response.SuccessResponse{data=EndpointWithSecret}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/webhook_endpoint [post]
+func authCreate(_ http.ResponseWriter, req *http.Request) (*EndpointWithSecret, int, error) {
+	user := request.GetReqSession(req).User
+
+	data := request.GetModelPostData(req)
+	endpointObj := webhook_endpoint.NewPublic(data, user)
+
+	msg := validateEndpoint(endpointObj)
+	if msg != "" {
+		return response.PublicCustomError[*EndpointWithSecret](msg, http.StatusBadRequest)
+	}
+
+	secret, err := endpointObj.RotateSecret()
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*EndpointWithSecret]()
+	}
+
+	err = endpointObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*EndpointWithSecret]()
+	}
+
+	return response.Success(&EndpointWithSecret{Endpoint: endpointObj, Secret: secret})
+}
+
+// authUpdate changes an endpoint's URL, description or event filter
+//
+//	@Public
+//	@Summary		Update webhook endpoint
+//	@Description	Updates an endpoint of the session organization
+//	@Tags			WebhookEndpoint
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Endpoint ID"
+//	@Param			body	body		webhook_endpoint.WebhookEndpoint	true	"Endpoint"
+//	@Success		200		{object}	response.SuccessResponse{data=webhook_endpoint.WebhookEndpointJoined}
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Router			/webhook_endpoint/{id} [put]
+func authUpdate(_ http.ResponseWriter, req *http.Request) (*webhook_endpoint.WebhookEndpointJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	endpointObj, err := getEndpoint(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+	if tools.Empty(endpointObj) {
+		return response.PublicNotFoundError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+
+	data := request.GetModelPostData(req)
+	webhook_endpoint.UpdatePublic(&endpointObj.WebhookEndpoint, data, user)
+
+	msg := validateEndpoint(&endpointObj.WebhookEndpoint)
+	if msg != "" {
+		return response.PublicCustomError[*webhook_endpoint.WebhookEndpointJoined](msg, http.StatusBadRequest)
+	}
+
+	err = endpointObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+
+	return response.Success(endpointObj)
+}
+
+// authDelete removes an endpoint, pending deliveries to it are dropped
+//
+//	@Public
+//	@Summary		Delete webhook endpoint
+//	@Description	Deletes an endpoint of the session organization
+//	@Tags			WebhookEndpoint
+//	@Produce		json
+//	@Param			id	path		string	true	"Endpoint ID"
+//	@Success		200	{object}	response.SuccessResponse{data=webhook_endpoint.WebhookEndpointJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/webhook_endpoint/{id} [delete]
+func authDelete(_ http.ResponseWriter, req *http.Request) (*webhook_endpoint.WebhookEndpointJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	endpointObj, err := getEndpoint(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+	if tools.Empty(endpointObj) {
+		return response.PublicNotFoundError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+
+	endpointObj.Deleted.Set(1)
+	err = endpointObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+
+	return response.Success(endpointObj)
+}
+
+// authRotateSecret replaces the endpoint's signing secret, the old secret keeps signing during the grace period
+//
+//	@Public
+//	@Summary		Rotate webhook secret
+//	@Description	Generates a new signing secret and returns it once. The previous secret stays valid for 24 hours.
+//	@Tags			WebhookEndpoint
+//	@Produce		json
+//	@Param			id	path		string	true	"Endpoint ID"
+//	@Success		200	{object}	response.SuccessResponse{data=EndpointWithSecret}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/webhook_endpoint/{id}/rotate_secret [post]
+func authRotateSecret(_ http.ResponseWriter, req *http.Request) (*EndpointWithSecret, int, error) {
+	user := request.GetReqSession(req).User
+
+	endpointObj, err := getEndpoint(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*EndpointWithSecret]()
+	}
+	if tools.Empty(endpointObj) {
+		return response.PublicNotFoundError[*EndpointWithSecret]()
+	}
+
+	secret, err := endpointObj.RotateSecret()
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*EndpointWithSecret]()
+	}
+
+	err = endpointObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*EndpointWithSecret]()
+	}
+
+	return response.Success(&EndpointWithSecret{Endpoint: &endpointObj.WebhookEndpoint, Secret: secret})
+}
+
+// authEnable re-enables an endpoint that was disabled after repeated failures
+//
+//	@Public
+//	@Summary		Enable webhook endpoint
+//	@Description	Re-enables an auto disabled endpoint and resets its failure count
+//	@Tags			WebhookEndpoint
+//	@Produce		json
+//	@Param			id	path		string	true	"Endpoint ID"
+//	@Success		200	{object}	response.SuccessResponse{data=webhook_endpoint.WebhookEndpointJoined}
+//	@Failure		400	{object}	response.ErrorResponse
+//	@Router			/webhook_endpoint/{id}/enable [post]
+func authEnable(_ http.ResponseWriter, req *http.Request) (*webhook_endpoint.WebhookEndpointJoined, int, error) {
+	user := request.GetReqSession(req).User
+
+	endpointObj, err := getEndpoint(req)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+	if tools.Empty(endpointObj) {
+		return response.PublicNotFoundError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+
+	endpointObj.Status.Set(webhook_endpoint.STATUS_ACTIVE)
+	endpointObj.FailureCount.Set(0)
+	endpointObj.DisabledReason.Set("")
+	err = endpointObj.Save(user)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*webhook_endpoint.WebhookEndpointJoined]()
+	}
+
+	return response.Success(endpointObj)
+}
+
+func getEndpoint(req *http.Request) (*webhook_endpoint.WebhookEndpointJoined, error) {
+	user := request.GetReqSession(req).User
+	return webhook_endpoint.GetRestrictedJoined(req.Context(), types.UUID(chi.URLParam(req, "id")), user)
+}
+
+// validateEndpoint returns a message describing what is wrong with the endpoint, empty when it is valid
+func validateEndpoint(endpointObj *webhook_endpoint.WebhookEndpoint) string {
+	if webhook_service.ValidateEndpointURL(endpointObj.URL.Get()) != nil {
+		return "A valid https URL is required"
+	}
+
+	eventTypes, err := endpointObj.EventTypes.Get()
+	if err != nil || !webhook_service.ValidEventTypes(eventTypes) {
+		return "Unknown event type"
+	}
+
+	return ""
+}
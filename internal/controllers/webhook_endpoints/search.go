@@ -0,0 +1,17 @@
+package webhook_endpoints
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
+)
+
+func addSearch(parameters *model.Options, query string) {
+	if tools.IsAnyValidUUID(query) {
+		parameters.WithCondition("(%s.id = :id: OR %s.organization_id = :id:)", TABLE_NAME, TABLE_NAME)
+		parameters.WithParam(":id:", query)
+		return
+	}
+
+	parameters.WithCondition("(%s.url ILIKE :q: OR %s.description ILIKE :q:)", TABLE_NAME, TABLE_NAME)
+	parameters.WithParam(":q:", "%"+query+"%")
+}
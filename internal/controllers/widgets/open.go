@@ -0,0 +1,291 @@
+package widgets
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/router/request"
+	"github.com/griffnb/core/lib/router/response"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/integrations/cloudflare"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/message"
+	"github.com/griffnb/techboss-ai-go/internal/services/agent_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
+)
+
+type WidgetConfig struct {
+	WidgetID     string `json:"widget_id"`
+	AgentName    string `json:"agent_name"`
+	Greeting     string `json:"greeting"`
+	CaptureLeads bool   `json:"capture_leads"`
+}
+
+type SessionInput struct {
+	CFToken string `json:"cf_token"`
+}
+
+type SessionResponse struct {
+	ConversationID types.UUID `json:"conversation_id"`
+	VisitorToken   string     `json:"visitor_token"`
+	Greeting       string     `json:"greeting"`
+}
+
+type VisitorInput struct {
+	ConversationID types.UUID `json:"conversation_id"`
+	VisitorToken   string     `json:"visitor_token"`
+}
+
+type MessageInput struct {
+	VisitorInput
+	Message string `json:"message"`
+}
+
+type LeadInput struct {
+	VisitorInput
+	agent_service.WidgetLeadInput
+}
+
+// openPreflight answers CORS preflight requests for allowed origins
+func openPreflight(res http.ResponseWriter, req *http.Request) {
+	_, _, err := loadWidget(res, req)
+	if err != nil {
+		res.WriteHeader(http.StatusForbidden)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// openGetWidget returns the public configuration for a widget
+//
+//	@Summary		Widget config
+//	@Description	Returns the public configuration for an embedded agent widget
+//	@Tags			Widget
+//	@Produce		json
+//	@Param			widget_id	path		string	true	"Widget ID"
+//	@Success		200			{object}	response.SuccessResponse{data=WidgetConfig}
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Router			/widget/{widget_id} [get]
+func openGetWidget(res http.ResponseWriter, req *http.Request) (*WidgetConfig, int, error) {
+	agentObj, widget, err := loadWidget(res, req)
+	if err != nil {
+		return widgetError[*WidgetConfig](req, err)
+	}
+
+	return response.Success(&WidgetConfig{
+		WidgetID:     widget.ID,
+		AgentName:    agentObj.Name.Get(),
+		Greeting:     widget.Greeting,
+		CaptureLeads: widget.CaptureLeads,
+	})
+}
+
+// openStartSession verifies the visitor with Turnstile and starts an anonymous conversation
+//
+//	@Summary		Start widget session
+//	@Description	Verifies Cloudflare Turnstile and starts an anonymous visitor conversation
+//	@Tags			Widget
+//	@Accept			json
+//	@Produce		json
+//	@Param			widget_id	path		string			true	"Widget ID"
+//	@Param			body		body		SessionInput	true	"Cloudflare token"
+//	@Success		200			{object}	response.SuccessResponse{data=SessionResponse}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		429			{object}	response.ErrorResponse
+//	@Failure		503			{object}	response.ErrorResponse
+//	@Router			/widget/{widget_id}/session [post]
+func openStartSession(res http.ResponseWriter, req *http.Request) (*SessionResponse, int, error) {
+	agentObj, widget, err := loadWidget(res, req)
+	if err != nil {
+		return widgetError[*SessionResponse](req, err)
+	}
+
+	limited, err := rateLimited(req, "session", widget.ID, SESSION_LIMIT_PER_MINUTE)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*SessionResponse]()
+	}
+	if limited {
+		return response.PublicCustomError[*SessionResponse]("Too many requests", http.StatusTooManyRequests)
+	}
+
+	input, err := request.GetJSONPostAs[*SessionInput](req)
+	if err != nil {
+		return response.PublicCustomError[*SessionResponse]("Payload error", http.StatusBadRequest)
+	}
+
+	// the widget is public, so outside local development a missing Turnstile key refuses sessions instead of
+	// silently turning bot protection off
+	if !cloudflare.Configured() || tools.Empty(cloudflare.Client()) {
+		if !environment.IsLocalDev() && !environment.IsUnitTest() {
+			log.ErrorContext(errors.New("turnstile is not configured, refusing widget sessions"), req.Context())
+			return response.PublicCustomError[*SessionResponse]("Widget sessions are unavailable", http.StatusServiceUnavailable)
+		}
+	} else {
+		if tools.Empty(input.CFToken) {
+			return response.PublicCustomError[*SessionResponse]("Cloudflare token is required", http.StatusBadRequest)
+		}
+		valid, err := cloudflare.Client().ValidateTurnstileResponse(input.CFToken, req.RemoteAddr)
+		if err != nil {
+			log.ErrorContext(err, req.Context())
+			return response.PublicBadRequestError[*SessionResponse]()
+		}
+		if !valid {
+			return response.PublicBadRequestError[*SessionResponse]()
+		}
+	}
+
+	conversationObj, visitorToken, err := agent_service.StartWidgetConversation(req.Context(), agentObj, widget)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*SessionResponse]()
+	}
+
+	return response.Success(&SessionResponse{
+		ConversationID: conversationObj.ID(),
+		VisitorToken:   visitorToken,
+		Greeting:       widget.Greeting,
+	})
+}
+
+// openSendMessage answers a visitor message in an existing widget conversation
+//
+//	@Summary		Send widget message
+//	@Description	Sends a visitor message and returns the agent's reply
+//	@Tags			Widget
+//	@Accept			json
+//	@Produce		json
+//	@Param			widget_id	path		string			true	"Widget ID"
+//	@Param			body		body		MessageInput	true	"Visitor message"
+//	@Success		200			{object}	response.SuccessResponse{data=message.Message}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		429			{object}	response.ErrorResponse
+//	@Router			/widget/{widget_id}/message [post]
+func openSendMessage(res http.ResponseWriter, req *http.Request) (*message.Message, int, error) {
+	agentObj, widget, err := loadWidget(res, req)
+	if err != nil {
+		return widgetError[*message.Message](req, err)
+	}
+
+	limited, err := rateLimited(req, "message", widget.ID, MESSAGE_LIMIT_PER_MINUTE)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*message.Message]()
+	}
+	if limited {
+		return response.PublicCustomError[*message.Message]("Too many requests", http.StatusTooManyRequests)
+	}
+
+	input, err := request.GetJSONPostAs[*MessageInput](req)
+	if err != nil || tools.Empty(input.Message) {
+		return response.PublicCustomError[*message.Message]("message is required", http.StatusBadRequest)
+	}
+
+	conversationObj, err := agent_service.GetWidgetConversation(req.Context(), agentObj, input.ConversationID, input.VisitorToken)
+	if err != nil {
+		return widgetError[*message.Message](req, err)
+	}
+
+	service, err := openai.NewServiceFromEnv()
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*message.Message]()
+	}
+
+	reply, err := agent_service.Reply(req.Context(), service, agentObj, conversationObj, input.Message)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[*message.Message]()
+	}
+
+	return response.Success(reply)
+}
+
+// openCaptureLead stores the visitor's contact details as a lead
+//
+//	@Summary		Capture widget lead
+//	@Description	Records the visitor's email as a lead linked to the widget conversation
+//	@Tags			Widget
+//	@Accept			json
+//	@Produce		json
+//	@Param			widget_id	path		string		true	"Widget ID"
+//	@Param			body		body		LeadInput	true	"Visitor contact details"
+//	@Success		200			{object}	response.SuccessResponse{data=bool}
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		429			{object}	response.ErrorResponse
+//	@Router			/widget/{widget_id}/lead [post]
+func openCaptureLead(res http.ResponseWriter, req *http.Request) (bool, int, error) {
+	agentObj, widget, err := loadWidget(res, req)
+	if err != nil {
+		return widgetError[bool](req, err)
+	}
+
+	if !widget.CaptureLeads {
+		return response.PublicCustomError[bool]("Lead capture is not enabled", http.StatusBadRequest)
+	}
+
+	limited, err := rateLimited(req, "lead", widget.ID, LEAD_LIMIT_PER_MINUTE)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicBadRequestError[bool]()
+	}
+	if limited {
+		return response.PublicCustomError[bool]("Too many requests", http.StatusTooManyRequests)
+	}
+
+	input, err := request.GetJSONPostAs[*LeadInput](req)
+	if err != nil {
+		return response.PublicCustomError[bool]("Payload error", http.StatusBadRequest)
+	}
+
+	conversationObj, err := agent_service.GetWidgetConversation(req.Context(), agentObj, input.ConversationID, input.VisitorToken)
+	if err != nil {
+		return widgetError[bool](req, err)
+	}
+
+	_, err = agent_service.CaptureWidgetLead(req.Context(), agentObj, conversationObj, &input.WidgetLeadInput)
+	if err != nil {
+		log.ErrorContext(err, req.Context())
+		return response.PublicCustomError[bool]("Invalid email", http.StatusBadRequest)
+	}
+
+	return response.Success(true)
+}
+
+// loadWidget resolves the widget from the route, checks the Origin and sets the CORS headers for it
+func loadWidget(res http.ResponseWriter, req *http.Request) (*agent.Agent, *agent.WidgetSettings, error) {
+	origin := req.Header.Get("Origin")
+
+	agentObj, widget, err := agent_service.GetWidgetAgent(req.Context(), chi.URLParam(req, "widget_id"), origin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res.Header().Set("Access-Control-Allow-Origin", origin)
+	res.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	res.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	res.Header().Add("Vary", "Origin")
+
+	return agentObj, widget, nil
+}
+
+// widgetError maps widget lookup failures onto public responses
+func widgetError[T any](req *http.Request, err error) (T, int, error) {
+	switch {
+	case errors.Is(err, agent_service.ErrWidgetNotFound), errors.Is(err, agent_service.ErrWidgetConversationNotFound):
+		return response.PublicNotFoundError[T]()
+	case errors.Is(err, agent_service.ErrWidgetOriginDenied):
+		return response.PublicCustomError[T]("Origin not allowed", http.StatusForbidden)
+	}
+
+	log.ErrorContext(err, req.Context())
+	return response.PublicBadRequestError[T]()
+}
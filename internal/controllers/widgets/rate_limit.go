@@ -0,0 +1,33 @@
+package widgets
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+// Per visitor IP, per widget, per minute limits for each widget endpoint
+const (
+	SESSION_LIMIT_PER_MINUTE int64 = 10
+	MESSAGE_LIMIT_PER_MINUTE int64 = 20
+	LEAD_LIMIT_PER_MINUTE    int64 = 5
+)
+
+// rateLimited counts the request against the bucket for the visitor's IP and reports whether it is over the limit
+func rateLimited(req *http.Request, bucket string, widgetID string, limit int64) (bool, error) {
+	ip, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		ip = req.RemoteAddr
+	}
+
+	count, err := environment.GetCache().Incr(tools.Sha1(fmt.Sprintf("widget_%s_%s_%s_%s", bucket, widgetID, ip, time.Now().Round(time.Minute).String())))
+	if err != nil {
+		return false, err
+	}
+
+	return int64(count) > limit, nil
+}
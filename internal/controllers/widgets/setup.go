@@ -0,0 +1,38 @@
+package widgets
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/griffnb/core/lib/router"
+	"github.com/griffnb/core/lib/router/response"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/controllers/helpers"
+)
+
+const (
+	ROUTE string = "widget"
+)
+
+// Setup sets up the public widget routes, these are called from customer websites
+func Setup(coreRouter *router.CoreRouter) {
+	coreRouter.AddMainRoute(tools.BuildString("/", ROUTE), func(r chi.Router) {
+		r.Group(func(openR chi.Router) {
+			openR.Options("/{widget_id}/*", openPreflight)
+			openR.Options("/{widget_id}", openPreflight)
+
+			openR.Get("/{widget_id}", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: response.StandardPublicRequestWrapper(openGetWidget),
+			}))
+			openR.Post("/{widget_id}/session", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: response.StandardPublicRequestWrapper(openStartSession),
+			}))
+			openR.Post("/{widget_id}/message", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: response.StandardPublicRequestWrapper(openSendMessage),
+			}))
+			openR.Post("/{widget_id}/lead", helpers.RoleHandler(helpers.RoleHandlerMap{
+				constants.ROLE_UNAUTHORIZED: response.StandardPublicRequestWrapper(openCaptureLead),
+			}))
+		})
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/griffnb/core/lib/log"
 	"github.com/griffnb/techboss-ai-go/internal/constants"
 	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/delay_queue"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/vector_store_service"
 	"github.com/robfig/cron/v3"
 )
 
@@ -21,11 +23,20 @@ func Run() *cron.Cron {
 
 	c := cron.New(cron.WithLocation(loc))
 
+	// every minute
+	_, _ = c.AddFunc("* * * * *", func() {
+		go func() {
+			// delayed jobs such as webhook retries are due to the minute
+			err := delay_queue.RunDelayQueue(context.Background())
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	})
 	// hourly
 	_, _ = c.AddFunc("0 * * * *", func() {
 		go func() {
-			// Run delay queue hourly for now
-			err := delay_queue.RunDelayQueue(context.Background())
+			err := document_service.QueueDueRefetches(context.Background())
 			if err != nil {
 				log.Error(err)
 			}
@@ -33,6 +44,18 @@ func Run() *cron.Cron {
 	})
 	// daily
 	_, _ = c.AddFunc("0 1 * * *", func() {
+		go func() {
+			err := vector_store_service.ReconcileAll(context.Background())
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+		go func() {
+			err := document_service.QueueRetention(context.Background())
+			if err != nil {
+				log.Error(err)
+			}
+		}()
 	})
 
 	// weekly
@@ -9,7 +9,9 @@ import (
 	"github.com/pkg/errors"
 )
 
-const LIMIT = 10
+// LIMIT is how many due items one run moves to the worker queue. Runs are every minute, so a full batch each run
+// keeps up with bursts such as webhook retries from many organizations at once.
+const LIMIT = 500
 
 func RunDelayQueue(ctx context.Context) error {
 	items, err := PopFromDynamo(ctx, LIMIT)
@@ -6,7 +6,11 @@ import (
 
 	"github.com/griffnb/core/lib/queue"
 	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
 	dynamoqueue "github.com/griffnb/techboss-ai-go/internal/services/dynamo_queue"
+	"github.com/griffnb/techboss-ai-go/internal/services/eval_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/mailbox_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
 )
 
 func (this *TaskWorker) ProcessJob(ctx context.Context, job *queue.RawJob) error {
@@ -24,6 +28,105 @@ func (this *TaskWorker) ProcessJob(ctx context.Context, job *queue.RawJob) error
 		if err != nil {
 			return err
 		}
+	case worker_jobs.DOCUMENT_PROCESS:
+		jobData := &worker_jobs.DocumentProcessJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		err = document_service.Process(ctx, jobData.DocumentID)
+		if err != nil {
+			return err
+		}
+	case worker_jobs.DOCUMENT_INDEX:
+		jobData := &worker_jobs.DocumentIndexJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		err = document_service.Index(ctx, jobData.DocumentID)
+		if err != nil {
+			return err
+		}
+	case worker_jobs.DOCUMENT_EMBED:
+		jobData := &worker_jobs.DocumentEmbedJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		err = document_service.Embed(ctx, jobData.DocumentID)
+		if err != nil {
+			return err
+		}
+	case worker_jobs.DOCUMENT_VECTOR_SYNC:
+		jobData := &worker_jobs.DocumentVectorSyncJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		err = document_service.SyncVectorStore(ctx, jobData.DocumentID)
+		if err != nil {
+			return err
+		}
+	case worker_jobs.DOCUMENT_RETENTION:
+		jobData := &worker_jobs.DocumentRetentionJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		err = document_service.EnforceRetention(ctx, jobData.OrganizationID)
+		if err != nil {
+			return err
+		}
+	case worker_jobs.DOCUMENT_REFETCH:
+		jobData := &worker_jobs.DocumentRefetchJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		err = document_service.Refetch(ctx, jobData.DocumentID)
+		if err != nil {
+			return err
+		}
+	case worker_jobs.EVAL_RUN:
+		jobData := &worker_jobs.EvalRunJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		_, err = eval_service.ExecuteRun(ctx, jobData.RunID)
+		if err != nil {
+			return err
+		}
+	case worker_jobs.WEBHOOK_DELIVERY:
+		jobData := &worker_jobs.WebhookDeliveryJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		err = webhook_service.Deliver(ctx, jobData.DeliveryID)
+		if err != nil {
+			return err
+		}
+	case worker_jobs.INBOUND_EMAIL:
+		jobData := &worker_jobs.InboundEmailJob{}
+		err := job.GetData(jobData)
+		if err != nil {
+			return err
+		}
+
+		err = mailbox_service.ReceiveStored(ctx, jobData.ObjectKey, jobData.Recipients)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
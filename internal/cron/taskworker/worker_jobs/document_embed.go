@@ -0,0 +1,23 @@
+package worker_jobs
+
+import (
+	"github.com/griffnb/core/lib/queue"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const DOCUMENT_EMBED = "document_embed"
+
+type DocumentEmbedJob struct {
+	DocumentID types.UUID `json:"document_id"`
+}
+
+func QueueDocumentEmbedJob(documentID types.UUID) error {
+	job := &queue.Job{
+		Type: DOCUMENT_EMBED,
+		Data: &DocumentEmbedJob{
+			DocumentID: documentID,
+		},
+	}
+	return environment.GetQueue().Push(environment.QUEUE_THROTTLES, job)
+}
@@ -0,0 +1,23 @@
+package worker_jobs
+
+import (
+	"github.com/griffnb/core/lib/queue"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const DOCUMENT_REFETCH = "document_refetch"
+
+type DocumentRefetchJob struct {
+	DocumentID types.UUID `json:"document_id"`
+}
+
+func QueueDocumentRefetchJob(documentID types.UUID) error {
+	job := &queue.Job{
+		Type: DOCUMENT_REFETCH,
+		Data: &DocumentRefetchJob{
+			DocumentID: documentID,
+		},
+	}
+	return environment.GetQueue().Push(environment.QUEUE_THROTTLES, job)
+}
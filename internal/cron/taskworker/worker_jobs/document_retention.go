@@ -0,0 +1,23 @@
+package worker_jobs
+
+import (
+	"github.com/griffnb/core/lib/queue"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const DOCUMENT_RETENTION = "document_retention"
+
+type DocumentRetentionJob struct {
+	OrganizationID types.UUID `json:"organization_id"`
+}
+
+func QueueDocumentRetentionJob(organizationID types.UUID) error {
+	job := &queue.Job{
+		Type: DOCUMENT_RETENTION,
+		Data: &DocumentRetentionJob{
+			OrganizationID: organizationID,
+		},
+	}
+	return environment.GetQueue().Push(environment.QUEUE_THROTTLES, job)
+}
@@ -0,0 +1,23 @@
+package worker_jobs
+
+import (
+	"github.com/griffnb/core/lib/queue"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const DOCUMENT_VECTOR_SYNC = "document_vector_sync"
+
+type DocumentVectorSyncJob struct {
+	DocumentID types.UUID `json:"document_id"`
+}
+
+func QueueDocumentVectorSyncJob(documentID types.UUID) error {
+	job := &queue.Job{
+		Type: DOCUMENT_VECTOR_SYNC,
+		Data: &DocumentVectorSyncJob{
+			DocumentID: documentID,
+		},
+	}
+	return environment.GetQueue().Push(environment.QUEUE_THROTTLES, job)
+}
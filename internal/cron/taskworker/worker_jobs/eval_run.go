@@ -0,0 +1,23 @@
+package worker_jobs
+
+import (
+	"github.com/griffnb/core/lib/queue"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const EVAL_RUN = "eval_run"
+
+type EvalRunJob struct {
+	RunID types.UUID `json:"run_id"`
+}
+
+func QueueEvalRunJob(runID types.UUID) error {
+	job := &queue.Job{
+		Type: EVAL_RUN,
+		Data: &EvalRunJob{
+			RunID: runID,
+		},
+	}
+	return environment.GetQueue().Push(environment.QUEUE_THROTTLES, job)
+}
@@ -0,0 +1,24 @@
+package worker_jobs
+
+import (
+	"github.com/griffnb/core/lib/queue"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const INBOUND_EMAIL = "inbound_email"
+
+type InboundEmailJob struct {
+	ObjectKey  string   `json:"object_key"`
+	Recipients []string `json:"recipients"`
+}
+
+func QueueInboundEmailJob(objectKey string, recipients []string) error {
+	job := &queue.Job{
+		Type: INBOUND_EMAIL,
+		Data: &InboundEmailJob{
+			ObjectKey:  objectKey,
+			Recipients: recipients,
+		},
+	}
+	return environment.GetQueue().Push(environment.QUEUE_THROTTLES, job)
+}
@@ -0,0 +1,23 @@
+package worker_jobs
+
+import (
+	"github.com/griffnb/core/lib/queue"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const WEBHOOK_DELIVERY = "webhook_delivery"
+
+type WebhookDeliveryJob struct {
+	DeliveryID types.UUID `json:"delivery_id"`
+}
+
+func QueueWebhookDeliveryJob(deliveryID types.UUID) error {
+	job := &queue.Job{
+		Type: WEBHOOK_DELIVERY,
+		Data: &WebhookDeliveryJob{
+			DeliveryID: deliveryID,
+		},
+	}
+	return environment.GetQueue().Push(environment.QUEUE_THROTTLES, job)
+}
@@ -17,6 +17,8 @@ type Config struct {
 	Cloudflare     *Cloudflare   `json:"cloudflare"`
 	Sendpulse      *Sendpulse    `json:"sendpulse"`
 	Stripe         *StripeConfig `json:"stripe"`
+	SlackApp       *SlackApp     `json:"slack_app"`
+	InboundEmail   *InboundEmail `json:"inbound_email"`
 }
 
 type Cloudflare struct {
@@ -79,3 +81,18 @@ type StripeConfig struct {
 	SecretKey  string `json:"secret_key"`
 	PublicKey  string `json:"public_key"`
 }
+
+// SlackApp is the customer facing Slack app, separate from the internal notification bot
+type SlackApp struct {
+	ClientID      string `json:"client_id"`
+	ClientSecret  string `json:"client_secret"`
+	SigningSecret string `json:"signing_secret"`
+	RedirectURL   string `json:"redirect_url"`
+}
+
+// InboundEmail authenticates mail posted to the agent inbox endpoints
+type InboundEmail struct {
+	WebhookKey string `json:"webhook_key"`
+	Bucket     string `json:"bucket"`    // S3 bucket SES stores received mail in, posted mail is staged there too
+	TopicArn   string `json:"topic_arn"` // optional, the only SNS topic notifications are accepted from
+}
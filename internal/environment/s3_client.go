@@ -0,0 +1,40 @@
+package environment
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/griffnb/core/lib/tools"
+)
+
+var (
+	s3Client     *awss3.Client
+	s3ClientOnce sync.Once
+)
+
+// GetS3Client returns an AWS SDK S3 client with the same credentials as GetS3, for calls the S3 wrapper does not
+// make such as deletes and HEAD requests. It is nil when S3 is not configured.
+func GetS3Client() *awss3.Client {
+	s3ClientOnce.Do(func() {
+		if IS_CLOUD {
+			if AWS_CONFIG != nil {
+				s3Client = awss3.NewFromConfig(*AWS_CONFIG)
+			}
+			return
+		}
+
+		s3Config := GetConfig().S3Config
+		if tools.Empty(s3Config) {
+			return
+		}
+		s3Client = awss3.NewFromConfig(aws.Config{
+			Region: s3Config.Region,
+			Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{AccessKeyID: s3Config.Key, SecretAccessKey: s3Config.Skey}, nil
+			}),
+		})
+	})
+	return s3Client
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/griffnb/core/lib/model"
 	"github.com/griffnb/core/lib/tools"
 	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
 )
 
 type Mocker struct {
@@ -23,6 +24,7 @@ type Mocker struct {
 	GetByExternalId        func(ctx context.Context, externalID string) (*Account, error)
 	GetByEmail             func(ctx context.Context, email string) (*Account, error)
 	GetExistingByEmail     func(ctx context.Context, email string) (*Account, error)
+	FindOrganizationOwners func(ctx context.Context, organizationID types.UUID) ([]*Account, error)
 }
 
 func GetAccountWithFeatures(ctx context.Context, id types.UUID) (*AccountWithFeatures, error) {
@@ -85,3 +87,17 @@ func GetByEmail(ctx context.Context, email string) (*Account, error) {
 		Columns.Disabled.Column()).
 		WithParam(":email:", strings.ToLower(email)))
 }
+
+// FindOrganizationOwners returns the active owners of an organization
+func FindOrganizationOwners(ctx context.Context, organizationID types.UUID) ([]*Account, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindOrganizationOwners(ctx, organizationID)
+	}
+	return FindAll(ctx, model.NewOptions().
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("%s = :role:", Columns.Role.Column()).
+		WithCondition("%s = 0 AND %s = 0", Columns.Disabled.Column(), Columns.Deleted.Column()).
+		WithParam(":organization_id:", organizationID).
+		WithParam(":role:", constants.ROLE_ORG_OWNER))
+}
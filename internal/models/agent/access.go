@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"slices"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+)
+
+// AccessSettings limits which organizations may use an agent
+type AccessSettings struct {
+	MinPlanLevel    int64        `json:"min_plan_level,omitempty"`   // lowest billing plan level allowed, 0 allows every plan
+	OrganizationIDs []types.UUID `json:"organization_ids,omitempty"` // when set only these organizations may use the agent
+}
+
+// CanBeUsedBy reports whether an organization on the given plan level may use the agent
+func (this *Agent) CanBeUsedBy(organizationID types.UUID, planLevel int64) bool {
+	if this.Status.Get() == constants.STATUS_DISABLED || this.Status.Get() == constants.STATUS_DELETED {
+		return false
+	}
+	if this.Disabled.Get() == 1 || this.Deleted.Get() == 1 {
+		return false
+	}
+
+	settings, err := this.Settings.Get()
+	if err != nil {
+		return false
+	}
+
+	if settings == nil || settings.Access == nil {
+		return true
+	}
+
+	return settings.Access.Allows(organizationID, planLevel)
+}
+
+// Allows checks the organization and plan level against the access settings
+func (this *AccessSettings) Allows(organizationID types.UUID, planLevel int64) bool {
+	if planLevel < this.MinPlanLevel {
+		return false
+	}
+
+	if len(this.OrganizationIDs) > 0 && !slices.Contains(this.OrganizationIDs, organizationID) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,34 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/griffnb/core/lib/types"
+)
+
+func TestAccessSettingsAllows(t *testing.T) {
+	orgA := types.UUID("0b8a7c1e-4f7d-4c36-9d7e-3f1a2b3c4d5e")
+	orgB := types.UUID("7d9e0f1a-2b3c-4d5e-8f9a-0b1c2d3e4f5a")
+
+	tests := []struct {
+		name      string
+		access    *AccessSettings
+		org       types.UUID
+		planLevel int64
+		want      bool
+	}{
+		{"open access", &AccessSettings{}, orgA, 0, true},
+		{"plan too low", &AccessSettings{MinPlanLevel: 2}, orgA, 1, false},
+		{"plan high enough", &AccessSettings{MinPlanLevel: 2}, orgA, 3, true},
+		{"org allowed", &AccessSettings{OrganizationIDs: []types.UUID{orgA}}, orgA, 0, true},
+		{"org not allowed", &AccessSettings{OrganizationIDs: []types.UUID{orgA}}, orgB, 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.access.Allows(tt.org, tt.planLevel); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
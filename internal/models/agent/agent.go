@@ -48,6 +48,16 @@ func (this *Agent) beforeSave(ctx context.Context) error {
 	this.BaseBeforeSave(ctx)
 	common.GenerateURN(this)
 	common.SetDisabledDeleted(this)
+
+	settings, err := this.Settings.Get()
+	if err != nil {
+		return err
+	}
+	if settings != nil && settings.Widget != nil && settings.Widget.ID == "" {
+		settings.Widget.ID = NewWidgetID()
+		this.Settings.Set(settings)
+	}
+
 	return this.ValidateSubStructs()
 }
 
@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+type FormFieldType string
+
+const (
+	FORM_FIELD_TEXT     FormFieldType = "text"
+	FORM_FIELD_TEXTAREA FormFieldType = "textarea"
+	FORM_FIELD_NUMBER   FormFieldType = "number"
+	FORM_FIELD_BOOLEAN  FormFieldType = "boolean"
+	FORM_FIELD_SELECT   FormFieldType = "select"
+)
+
+// FormSettings configures a single shot form agent
+type FormSettings struct {
+	Fields         []*FormField   `json:"fields"`          // input form definition
+	PromptTemplate string         `json:"prompt_template"` // text/template rendered with the submitted values
+	OutputSchema   map[string]any `json:"output_schema"`   // JSON schema the model output must match
+	Model          string         `json:"model,omitempty"` // model override
+}
+
+type FormField struct {
+	Name      string        `json:"name"`
+	Label     string        `json:"label"`
+	Type      FormFieldType `json:"type"`
+	Required  bool          `json:"required"`
+	Options   []string      `json:"options,omitempty"`    // allowed values for select fields
+	MaxLength int           `json:"max_length,omitempty"` // max length for text fields, 0 is unlimited
+}
+
+// ValidateInput checks the submitted values against the form definition and returns only the known fields
+func (this *FormSettings) ValidateInput(input map[string]any) (map[string]any, error) {
+	cleaned := map[string]any{}
+	problems := []string{}
+
+	for _, field := range this.Fields {
+		value, ok := input[field.Name]
+		if !ok || value == nil || value == "" {
+			if field.Required {
+				problems = append(problems, fmt.Sprintf("%s is required", field.Name))
+			}
+			continue
+		}
+
+		switch field.Type {
+		case FORM_FIELD_NUMBER:
+			if _, ok := value.(float64); !ok {
+				problems = append(problems, fmt.Sprintf("%s must be a number", field.Name))
+				continue
+			}
+		case FORM_FIELD_BOOLEAN:
+			if _, ok := value.(bool); !ok {
+				problems = append(problems, fmt.Sprintf("%s must be true or false", field.Name))
+				continue
+			}
+		case FORM_FIELD_SELECT:
+			str, ok := value.(string)
+			if !ok || !slices.Contains(field.Options, str) {
+				problems = append(problems, fmt.Sprintf("%s must be one of %s", field.Name, strings.Join(field.Options, ", ")))
+				continue
+			}
+		default:
+			str, ok := value.(string)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s must be text", field.Name))
+				continue
+			}
+			if field.MaxLength > 0 && len(str) > field.MaxLength {
+				problems = append(problems, fmt.Sprintf("%s must be at most %d characters", field.Name, field.MaxLength))
+				continue
+			}
+		}
+
+		cleaned[field.Name] = value
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+
+	return cleaned, nil
+}
+
+// RenderPrompt renders the prompt template with the validated input
+func (this *FormSettings) RenderPrompt(input map[string]any) (string, error) {
+	tmpl, err := template.New("form").Option("missingkey=zero").Parse(this.PromptTemplate)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid prompt template")
+	}
+
+	var builder strings.Builder
+	err = tmpl.Execute(&builder, input)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to render prompt template")
+	}
+
+	return builder.String(), nil
+}
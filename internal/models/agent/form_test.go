@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"testing"
+)
+
+func TestFormSettingsValidateInput(t *testing.T) {
+	settings := &FormSettings{
+		Fields: []*FormField{
+			{Name: "company", Type: FORM_FIELD_TEXT, Required: true, MaxLength: 10},
+			{Name: "employees", Type: FORM_FIELD_NUMBER},
+			{Name: "industry", Type: FORM_FIELD_SELECT, Options: []string{"saas", "retail"}},
+		},
+	}
+
+	t.Run("valid input drops unknown fields", func(t *testing.T) {
+		cleaned, err := settings.ValidateInput(map[string]any{
+			"company":   "Acme",
+			"employees": float64(12),
+			"industry":  "saas",
+			"extra":     "ignored",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := cleaned["extra"]; ok {
+			t.Errorf("expected unknown field to be dropped")
+		}
+		if cleaned["company"] != "Acme" {
+			t.Errorf("expected company to be kept, got %v", cleaned["company"])
+		}
+	})
+
+	t.Run("missing required field", func(t *testing.T) {
+		_, err := settings.ValidateInput(map[string]any{})
+		if err == nil {
+			t.Fatal("expected error for missing required field")
+		}
+	})
+
+	t.Run("wrong types and options", func(t *testing.T) {
+		_, err := settings.ValidateInput(map[string]any{
+			"company":   "Far too long a name",
+			"employees": "twelve",
+			"industry":  "mining",
+		})
+		if err == nil {
+			t.Fatal("expected validation error")
+		}
+	})
+}
+
+func TestFormSettingsRenderPrompt(t *testing.T) {
+	settings := &FormSettings{
+		PromptTemplate: "Write a tagline for {{.company}} in {{.industry}}.",
+	}
+
+	prompt, err := settings.RenderPrompt(map[string]any{"company": "Acme", "industry": "saas"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if prompt != "Write a tagline for Acme in saas." {
+		t.Errorf("unexpected prompt %q", prompt)
+	}
+}
@@ -0,0 +1,18 @@
+package agent
+
+import (
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_group"
+)
+
+// KnowledgeSettings limits which document groups the agent may retrieve from. Groups belong to one organization,
+// so agents shared across organizations list group types, each organization's groups of those types are used.
+type KnowledgeSettings struct {
+	DocumentGroupIDs   []types.UUID               `json:"document_group_ids,omitempty"`   // specific groups
+	DocumentGroupTypes []document_group.GroupType `json:"document_group_types,omitempty"` // every group of these types in the organization
+}
+
+// Restricted reports whether the settings limit retrieval at all, without groups or types every document is searched
+func (this *KnowledgeSettings) Restricted() bool {
+	return len(this.DocumentGroupIDs) > 0 || len(this.DocumentGroupTypes) > 0
+}
@@ -0,0 +1,28 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_group"
+)
+
+func TestKnowledgeSettingsRestricted(t *testing.T) {
+	tests := []struct {
+		name      string
+		knowledge *KnowledgeSettings
+		want      bool
+	}{
+		{"no limits", &KnowledgeSettings{}, false},
+		{"groups", &KnowledgeSettings{DocumentGroupIDs: []types.UUID{"0b8a7c1e-4f7d-4c36-9d7e-3f1a2b3c4d5e"}}, true},
+		{"group types", &KnowledgeSettings{DocumentGroupTypes: []document_group.GroupType{document_group.GROUP_TYPE_POLICY}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.knowledge.Restricted(); got != tt.want {
+				t.Errorf("Restricted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
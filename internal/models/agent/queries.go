@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
 	"github.com/griffnb/core/lib/types"
 )
 
@@ -16,4 +17,30 @@ type Mocker struct {
 	FindFirst        func(ctx context.Context, options *model.Options) (*Agent, error)
 	FindFirstJoined  func(ctx context.Context, options *model.Options) (*AgentJoined, error)
 	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetByWidgetID func(ctx context.Context, widgetID string) (*Agent, error)
+}
+
+// GetByWidgetID finds the active agent publishing the given widget id.
+// Returns nil when no agent has the widget.
+func GetByWidgetID(ctx context.Context, widgetID string) (*Agent, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetByWidgetID(ctx, widgetID)
+	}
+
+	obj, err := FindFirst(ctx, model.NewOptions().
+		WithCondition("%s->'widget'->>'id' = :widget_id:", Columns.Settings.Column()).
+		WithCondition("%s = 0", Columns.Disabled.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":widget_id:", widgetID))
+	if err != nil {
+		return nil, err
+	}
+
+	if tools.Empty(obj) {
+		return nil, nil
+	}
+
+	return obj, nil
 }
@@ -1,6 +1,13 @@
 package agent
 
 type Settings struct {
-	OpenAIAssistantID string `json:"open_ai_assistant_id"` // assistant id
-	WorkflowID        string `json:"workflow_id"`          // workflow id
+	OpenAIAssistantID string             `json:"open_ai_assistant_id"`      // assistant id
+	WorkflowID        string             `json:"workflow_id"`               // workflow id
+	Instructions      string             `json:"instructions,omitempty"`    // base system prompt
+	Model             string             `json:"model,omitempty"`           // model used for server side runs
+	Access            *AccessSettings    `json:"access,omitempty"`          // who may use the agent
+	StateVariables    map[string]string  `json:"state_variables,omitempty"` // chatkit state variable name to source, see agent_service.StateVariableSources
+	Form              *FormSettings      `json:"form,omitempty"`            // single shot form definition
+	Widget            *WidgetSettings    `json:"widget,omitempty"`          // public website widget
+	Knowledge         *KnowledgeSettings `json:"knowledge,omitempty"`       // document groups the agent may search
 }
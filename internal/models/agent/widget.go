@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+)
+
+// WIDGET_ID_PREFIX marks publishable widget ids so they are never mistaken for agent ids
+const WIDGET_ID_PREFIX = "wgt_"
+
+// WidgetSettings configures the public website widget for an agent
+type WidgetSettings struct {
+	ID             string     `json:"id,omitempty"`              // publishable widget id, generated on save
+	Enabled        bool       `json:"enabled,omitempty"`         // widget endpoints respond only when enabled
+	AllowedDomains []string   `json:"allowed_domains,omitempty"` // origins allowed to embed the widget, "*.example.com" matches subdomains
+	Greeting       string     `json:"greeting,omitempty"`        // first message shown to visitors
+	CaptureLeads   bool       `json:"capture_leads,omitempty"`   // ask visitors for an email
+	OrganizationID types.UUID `json:"organization_id,omitempty"` // organization that owns the widget's conversations and leads
+}
+
+// NewWidgetID generates a publishable widget id
+func NewWidgetID() string {
+	return WIDGET_ID_PREFIX + strings.ToLower(tools.RandString(24))
+}
+
+// AllowsOrigin checks a request Origin header against the allowed domains
+func (this *WidgetSettings) AllowsOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Hostname() == "" {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+
+	for _, domain := range this.AllowedDomains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" {
+			continue
+		}
+
+		if suffix, ok := strings.CutPrefix(domain, "*."); ok {
+			if host == suffix || strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+
+		if host == domain {
+			return true
+		}
+	}
+
+	return false
+}
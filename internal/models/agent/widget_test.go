@@ -0,0 +1,32 @@
+package agent
+
+import "testing"
+
+func TestWidgetSettingsAllowsOrigin(t *testing.T) {
+	widget := &WidgetSettings{AllowedDomains: []string{"acme.com", "*.shop.io", " Docs.Example.org "}}
+
+	tests := []struct {
+		name   string
+		origin string
+		want   bool
+	}{
+		{"exact domain", "https://acme.com", true},
+		{"exact domain with port", "http://acme.com:8080", true},
+		{"subdomain not allowed without wildcard", "https://www.acme.com", false},
+		{"wildcard subdomain", "https://store.shop.io", true},
+		{"wildcard apex", "https://shop.io", true},
+		{"wildcard lookalike", "https://evilshop.io", false},
+		{"case and whitespace ignored", "https://docs.example.org", true},
+		{"unlisted domain", "https://other.com", false},
+		{"missing origin", "", false},
+		{"null origin", "null", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := widget.AllowsOrigin(tt.origin); got != tt.want {
+				t.Errorf("AllowsOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/griffnb/core/lib/model/fields"
 	"github.com/griffnb/techboss-ai-go/internal/common"
 	"github.com/griffnb/techboss-ai-go/internal/environment"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/agent_attribute/migrations"
 	"github.com/griffnb/techboss-ai-go/internal/models/base"
 )
 
@@ -26,11 +27,14 @@ type Structure struct {
 
 type DBColumns struct {
 	base.Structure
-	AccountID *fields.IntField `column:"account_id" type:"integer" default:"0" index:"true"`
-	AgentID   *fields.IntField `column:"agent_id"   type:"integer" default:"0" index:"true"`
+	AccountID  *fields.UUIDField                `public:"view" column:"account_id" type:"uuid"  default:"null" null:"true" index:"true"`
+	AgentID    *fields.UUIDField                `public:"view" column:"agent_id"   type:"uuid"  default:"null" null:"true" index:"true"`
+	Attributes *fields.StructField[*Attributes] `public:"edit" column:"attributes" type:"jsonb" default:"{}"`
 }
 
-type JoinData struct{}
+type JoinData struct {
+	AgentName *fields.StringField `public:"view" json:"agent_name" type:"text"`
+}
 
 type AgentAttribute struct {
 	model.BaseModel
@@ -0,0 +1,84 @@
+package agent_attribute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/griffnb/core/lib/types"
+)
+
+// Attributes is the per account customization of an agent
+type Attributes struct {
+	PreferredTone     string            `public:"edit" json:"preferred_tone,omitempty"`
+	Variables         map[string]string `public:"edit" json:"variables,omitempty"`
+	PinnedDocumentIDs []types.UUID      `public:"edit" json:"pinned_document_ids,omitempty"`
+}
+
+// IsEmpty returns true when nothing has been customized
+func (this *Attributes) IsEmpty() bool {
+	return this == nil || (this.PreferredTone == "" && len(this.Variables) == 0 && len(this.PinnedDocumentIDs) == 0)
+}
+
+// Merge overlays the non empty values from other onto this
+func (this *Attributes) Merge(other *Attributes) {
+	if other == nil {
+		return
+	}
+	if other.PreferredTone != "" {
+		this.PreferredTone = other.PreferredTone
+	}
+	if other.Variables != nil {
+		if this.Variables == nil {
+			this.Variables = map[string]string{}
+		}
+		for key, value := range other.Variables {
+			if value == "" {
+				delete(this.Variables, key)
+				continue
+			}
+			this.Variables[key] = value
+		}
+	}
+	if other.PinnedDocumentIDs != nil {
+		this.PinnedDocumentIDs = other.PinnedDocumentIDs
+	}
+}
+
+// ToPrompt renders the attributes as an instruction block that can be appended to an agent prompt
+func (this *Attributes) ToPrompt() string {
+	if this.IsEmpty() {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("## User Preferences\n")
+
+	if this.PreferredTone != "" {
+		builder.WriteString(fmt.Sprintf("- Respond using a %s tone.\n", this.PreferredTone))
+	}
+
+	if len(this.Variables) > 0 {
+		keys := make([]string, 0, len(this.Variables))
+		for key := range this.Variables {
+			keys = append(keys, key)
+		}
+		// keep the prompt stable between requests so it can be cached
+		sort.Strings(keys)
+
+		builder.WriteString("- Saved variables:\n")
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf("  - %s: %s\n", key, this.Variables[key]))
+		}
+	}
+
+	if len(this.PinnedDocumentIDs) > 0 {
+		ids := make([]string, len(this.PinnedDocumentIDs))
+		for i, id := range this.PinnedDocumentIDs {
+			ids[i] = id.String()
+		}
+		builder.WriteString(fmt.Sprintf("- Prefer the pinned documents when answering: %s\n", strings.Join(ids, ", ")))
+	}
+
+	return builder.String()
+}
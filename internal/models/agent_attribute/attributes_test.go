@@ -0,0 +1,62 @@
+package agent_attribute
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/griffnb/core/lib/types"
+)
+
+func TestAttributes_ToPrompt(t *testing.T) {
+	t.Run("empty attributes render nothing", func(t *testing.T) {
+		attributes := &Attributes{}
+		if attributes.ToPrompt() != "" {
+			t.Fatalf("expected empty prompt, got %s", attributes.ToPrompt())
+		}
+	})
+
+	t.Run("variables are rendered in key order", func(t *testing.T) {
+		attributes := &Attributes{
+			PreferredTone: "friendly",
+			Variables: map[string]string{
+				"company":  "Acme",
+				"audience": "developers",
+			},
+			PinnedDocumentIDs: []types.UUID{"doc-1"},
+		}
+
+		prompt := attributes.ToPrompt()
+		if !strings.Contains(prompt, "friendly tone") {
+			t.Fatalf("expected tone in prompt, got %s", prompt)
+		}
+		if strings.Index(prompt, "audience") > strings.Index(prompt, "company") {
+			t.Fatalf("expected sorted variables, got %s", prompt)
+		}
+		if !strings.Contains(prompt, "doc-1") {
+			t.Fatalf("expected pinned documents in prompt, got %s", prompt)
+		}
+	})
+}
+
+func TestAttributes_Merge(t *testing.T) {
+	t.Run("empty variable values remove the key", func(t *testing.T) {
+		attributes := &Attributes{
+			PreferredTone: "formal",
+			Variables:     map[string]string{"company": "Acme", "region": "US"},
+		}
+
+		attributes.Merge(&Attributes{
+			Variables: map[string]string{"region": "", "product": "Boss"},
+		})
+
+		if attributes.PreferredTone != "formal" {
+			t.Fatalf("expected tone to be kept, got %s", attributes.PreferredTone)
+		}
+		if _, ok := attributes.Variables["region"]; ok {
+			t.Fatalf("expected region to be removed")
+		}
+		if attributes.Variables["product"] != "Boss" {
+			t.Fatalf("expected product to be added")
+		}
+	})
+}
@@ -14,3 +14,40 @@ func GetByAgentID(ctx context.Context, agentID types.UUID) ([]*AgentAttribute, e
 
 	return FindAll(ctx, options)
 }
+
+// GetOrNew returns the saved attributes for the account and agent, or an unsaved record when none exist yet
+func GetOrNew(ctx context.Context, accountID, agentID types.UUID) (*AgentAttribute, error) {
+	obj, err := GetByAccountAndAgent(ctx, accountID, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj != nil {
+		return obj, nil
+	}
+
+	obj = New()
+	obj.AccountID.Set(accountID)
+	obj.AgentID.Set(agentID)
+	obj.Attributes.Set(&Attributes{})
+	return obj, nil
+}
+
+// GetPrompt returns the rendered attribute prompt for the account and agent, empty when nothing is customized
+func GetPrompt(ctx context.Context, accountID, agentID types.UUID) (string, error) {
+	obj, err := GetByAccountAndAgent(ctx, accountID, agentID)
+	if err != nil {
+		return "", err
+	}
+
+	if obj == nil {
+		return "", nil
+	}
+
+	attributes, err := obj.Attributes.Get()
+	if err != nil {
+		return "", err
+	}
+
+	return attributes.ToPrompt(), nil
+}
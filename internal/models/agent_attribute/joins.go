@@ -6,6 +6,10 @@ import (
 
 // AddJoinData adds in the join data
 func AddJoinData(options *model.Options) {
-	options.WithPrependJoins([]string{}...)
-	options.WithIncludeFields([]string{}...)
+	options.WithPrependJoins([]string{
+		"LEFT JOIN agents ON agents.id = agent_attributes.agent_id",
+	}...)
+	options.WithIncludeFields([]string{
+		"agents.name AS agent_name",
+	}...)
 }
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "agent_attributes"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792281600,
+		Table:       TABLE,
+		TableStruct: &AgentAttributeV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+
+	// One set of attributes per account per agent
+	model.AddMigration(&model.Migration{
+		ID:    1792281601,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			CREATE UNIQUE INDEX IF NOT EXISTS agent_attributes_account_agent_unique
+				ON agent_attributes (account_id, agent_id) WHERE disabled = 0 AND deleted = 0
+			`, map[string]interface{}{})
+		},
+	})
+}
+
+type AgentAttributeV1 struct {
+	base.Structure
+	AccountID  *fields.UUIDField        `column:"account_id" type:"uuid"  default:"null" null:"true" index:"true"`
+	AgentID    *fields.UUIDField        `column:"agent_id"   type:"uuid"  default:"null" null:"true" index:"true"`
+	Attributes *fields.StructField[any] `column:"attributes" type:"jsonb" default:"{}"`
+}
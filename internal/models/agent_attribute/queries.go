@@ -0,0 +1,47 @@
+package agent_attribute
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*AgentAttribute, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*AgentAttributeJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*AgentAttribute, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*AgentAttributeJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*AgentAttribute, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*AgentAttributeJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetByAccountAndAgent func(ctx context.Context, accountID types.UUID, agentID types.UUID) (*AgentAttribute, error)
+}
+
+// GetByAccountAndAgent finds the active attributes an account has saved for an agent.
+// Returns nil when the account has not customized the agent.
+func GetByAccountAndAgent(ctx context.Context, accountID types.UUID, agentID types.UUID) (*AgentAttribute, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetByAccountAndAgent(ctx, accountID, agentID)
+	}
+
+	obj, err := FindFirst(ctx, model.NewOptions().
+		WithCondition("%s = :account_id:", Columns.AccountID.Column()).
+		WithCondition("%s = :agent_id:", Columns.AgentID.Column()).
+		WithCondition("%s = 0", Columns.Disabled.Column()).
+		WithParam(":account_id:", accountID).
+		WithParam(":agent_id:", agentID))
+	if err != nil {
+		return nil, err
+	}
+
+	if tools.Empty(obj) {
+		return nil, nil
+	}
+
+	return obj, nil
+}
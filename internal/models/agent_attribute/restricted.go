@@ -6,6 +6,7 @@ import (
 	"github.com/griffnb/core/lib/model"
 	"github.com/griffnb/core/lib/model/coremodel"
 	"github.com/griffnb/core/lib/sanitize"
+	"github.com/griffnb/core/lib/types"
 )
 
 func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionUser coremodel.Model) ([]*AgentAttributeJoined, error) {
@@ -13,7 +14,7 @@ func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessio
 	return FindAllJoined(ctx, options)
 }
 
-func GetRestrictedJoined(ctx context.Context, id int64, sessionUser coremodel.Model) (*AgentAttributeJoined, error) {
+func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionUser coremodel.Model) (*AgentAttributeJoined, error) {
 	options := model.NewOptions().
 		WithCondition("%s = :id: AND %s = :account_id:", Columns.ID_.Column(), Columns.AccountID.Column()).
 		WithParam(":id:", id).
@@ -22,13 +23,12 @@ func GetRestrictedJoined(ctx context.Context, id int64, sessionUser coremodel.Mo
 	return FindFirstJoined(ctx, options)
 }
 
-// NewPublic creates a new model instance with sanitized input and session account context
-// TODO: Add any session-specific initialization
-func NewPublic(data map[string]any, _ coremodel.Model) *AgentAttribute {
+// NewPublic creates a new model instance with sanitized input owned by the session account
+func NewPublic(data map[string]any, sessionAccount coremodel.Model) *AgentAttribute {
 	obj := New()
 	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
 	obj.MergeData(data)
-	// obj.AccountID.Set(sessionAccount.ID())
+	obj.AccountID.Set(sessionAccount.ID())
 	return obj
 }
 
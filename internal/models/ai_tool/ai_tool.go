@@ -33,9 +33,10 @@ type DBColumns struct {
 	AffiliateURL               *fields.StringField            `column:"affiliate_url"                 type:"text"     default:""`
 	MetaData                   *fields.StructField[*MetaData] `column:"meta_data"                     type:"jsonb"    default:"{}"`
 	IsFeatured                 *fields.IntField               `column:"is_featured"                   type:"smallint" default:"0"`
-	SearchBlobTSV              *fields.StringField            `column:"search_blob_tsv"               type:"tsvector" default:"null" null:"true" index:"true"`
 	CategoryID                 *fields.UUIDField              `column:"category_id"                   type:"uuid"     default:"null" null:"true" index:"true"`
 	BusinessFunctionCategoryID *fields.UUIDField              `column:"business_function_category_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	AverageRating              *fields.DecimalField           `column:"average_rating"                type:"numeric"  default:"0"                             scale:"2" precision:"3"`
+	ReviewCount                *fields.IntField               `column:"review_count"                  type:"integer"  default:"0"`
 }
 
 type JoinData struct {
@@ -58,7 +59,6 @@ func (this *AiTool) beforeSave(ctx context.Context) error {
 	this.BaseBeforeSave(ctx)
 	common.GenerateURN(this)
 	common.SetDisabledDeleted(this)
-	this.UpdateSearchVector()
 	return this.ValidateSubStructs()
 }
 
@@ -0,0 +1,171 @@
+package ai_tool
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const (
+	// SUGGESTION_MIN_LENGTH is the shortest input suggestions are looked up for
+	SUGGESTION_MIN_LENGTH = 2
+	// SUGGESTION_DEFAULT_LIMIT is how many suggestions are returned when the caller does not ask for a number
+	SUGGESTION_DEFAULT_LIMIT = 8
+	// SUGGESTION_MAX_LIMIT is the most suggestions a caller can ask for
+	SUGGESTION_MAX_LIMIT = 20
+)
+
+// suggestion types
+const (
+	SUGGESTION_TOOL     = "tool"
+	SUGGESTION_CATEGORY = "category"
+	SUGGESTION_TAG      = "tag"
+)
+
+// Suggestion is a typeahead match, a tool, a category or a tag
+type Suggestion struct {
+	Type  string     `json:"type"`
+	ID    types.UUID `json:"id"`
+	Name  string     `json:"name"`
+	Slug  string     `json:"slug,omitempty"` // categories only
+	Logo  string     `json:"logo,omitempty"` // tools only
+	Score float64    `json:"score"`
+}
+
+// suggestionsSQL looks up each kind of suggestion in its own trigram indexed query and merges them. A name matches
+// when it starts with the input, or when the input is close to one of its words (<%) or to the whole name (%), which
+// is what lets typos through. The score is the closer of the two similarities, a bonus for a prefix match and a small
+// boost for popularity: being featured and reviewed for tools, and how many tools use them for categories and tags.
+// Only tags used on tools are suggested, tags of organizations' documents are not public.
+const suggestionsSQL = `
+(
+	SELECT
+		'` + SUGGESTION_TOOL + `' AS type,
+		ai_tools.id::text AS id,
+		ai_tools.name AS name,
+		'' AS slug,
+		COALESCE(ai_tools.meta_data->>'logo', '') AS logo,
+		(
+			GREATEST(word_similarity(:query:, lower(ai_tools.name)), similarity(:query:, lower(ai_tools.name)))
+			+ CASE WHEN lower(ai_tools.name) LIKE :prefix: THEN 0.3 ELSE 0 END
+			+ ai_tools.is_featured * 0.1
+			+ ln(1 + ai_tools.review_count) * 0.05
+		)::float8 AS score
+	FROM ai_tools
+	WHERE ai_tools.deleted = 0
+		AND ai_tools.disabled = 0
+		AND (lower(ai_tools.name) LIKE :prefix: OR :query: <% lower(ai_tools.name) OR lower(ai_tools.name) % :query:)
+	ORDER BY score DESC
+	LIMIT :limit:
+)
+UNION ALL
+(
+	SELECT
+		'` + SUGGESTION_CATEGORY + `' AS type,
+		categories.id::text AS id,
+		categories.name AS name,
+		categories.slug AS slug,
+		'' AS logo,
+		(
+			GREATEST(word_similarity(:query:, lower(categories.name)), similarity(:query:, lower(categories.name)))
+			+ CASE WHEN lower(categories.name) LIKE :prefix: THEN 0.3 ELSE 0 END
+			+ ln(1 + usage.tool_count) * 0.05
+		)::float8 AS score
+	FROM categories
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) AS tool_count
+		FROM ai_tools
+		WHERE (ai_tools.category_id = categories.id OR ai_tools.business_function_category_id = categories.id)
+			AND ai_tools.deleted = 0
+			AND ai_tools.disabled = 0
+	) usage
+	WHERE categories.deleted = 0
+		AND categories.disabled = 0
+		AND (lower(categories.name) LIKE :prefix: OR :query: <% lower(categories.name) OR lower(categories.name) % :query:)
+	ORDER BY score DESC
+	LIMIT :limit:
+)
+UNION ALL
+(
+	SELECT
+		'` + SUGGESTION_TAG + `' AS type,
+		tags.id::text AS id,
+		tags.name AS name,
+		'' AS slug,
+		'' AS logo,
+		(
+			GREATEST(word_similarity(:query:, lower(tags.name)), similarity(:query:, lower(tags.name)))
+			+ CASE WHEN lower(tags.name) LIKE :prefix: THEN 0.3 ELSE 0 END
+			+ ln(1 + usage.tool_count) * 0.05
+		)::float8 AS score
+	FROM tags
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) AS tool_count
+		FROM object_tags
+		JOIN ai_tools ON ai_tools.urn = object_tags.object_urn
+		WHERE object_tags.tag_id = tags.id
+			AND ai_tools.deleted = 0
+			AND ai_tools.disabled = 0
+	) usage
+	WHERE tags.deleted = 0
+		AND tags.internal = 0
+		AND usage.tool_count > 0
+		AND (lower(tags.name) LIKE :prefix: OR :query: <% lower(tags.name) OR lower(tags.name) % :query:)
+	ORDER BY score DESC
+	LIMIT :limit:
+)
+ORDER BY score DESC, name ASC
+LIMIT :limit:
+`
+
+// findSuggestions runs the typeahead lookup for a normalized input
+func findSuggestions(input string, limit int) ([]*Suggestion, error) {
+	rows, err := environment.DB().DB.GetAll(suggestionsSQL, map[string]any{
+		":query:":  input,
+		":prefix:": likePrefix(input),
+		":limit:":  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]*Suggestion, 0, len(rows))
+	for _, row := range rows {
+		suggestions = append(suggestions, &Suggestion{
+			Type:  common.RowString(row, "type"),
+			ID:    types.UUID(common.RowString(row, "id")),
+			Name:  common.RowString(row, "name"),
+			Slug:  common.RowString(row, "slug"),
+			Logo:  common.RowString(row, "logo"),
+			Score: common.RowFloat(row, "score"),
+		})
+	}
+	return suggestions, nil
+}
+
+// normalizeSuggestionInput lower cases and trims the input and collapses its spaces, the names are matched lower
+// cased. ok is false when it is too short to look up.
+func normalizeSuggestionInput(input string) (string, bool) {
+	input = strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	return input, utf8.RuneCountInString(input) >= SUGGESTION_MIN_LENGTH
+}
+
+// suggestionLimit applies the default and maximum number of suggestions
+func suggestionLimit(limit int) int {
+	if limit <= 0 {
+		return SUGGESTION_DEFAULT_LIMIT
+	}
+	if limit > SUGGESTION_MAX_LIMIT {
+		return SUGGESTION_MAX_LIMIT
+	}
+	return limit
+}
+
+// likePrefix builds a LIKE pattern matching names starting with the input, its wildcards taken literally
+func likePrefix(input string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(input)
+	return escaped + "%"
+}
@@ -0,0 +1,42 @@
+package ai_tool
+
+import "testing"
+
+func TestNormalizeSuggestionInput(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"  Chat   GPT ", "chat gpt", true},
+		{"ai", "ai", true},
+		{" A ", "a", false},
+		{"", "", false},
+		{"é", "é", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := normalizeSuggestionInput(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("normalizeSuggestionInput(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSuggestionLimit(t *testing.T) {
+	if got := suggestionLimit(0); got != SUGGESTION_DEFAULT_LIMIT {
+		t.Errorf("suggestionLimit(0) = %d, want %d", got, SUGGESTION_DEFAULT_LIMIT)
+	}
+	if got := suggestionLimit(500); got != SUGGESTION_MAX_LIMIT {
+		t.Errorf("suggestionLimit(500) = %d, want %d", got, SUGGESTION_MAX_LIMIT)
+	}
+	if got := suggestionLimit(5); got != 5 {
+		t.Errorf("suggestionLimit(5) = %d, want 5", got)
+	}
+}
+
+func TestLikePrefix(t *testing.T) {
+	if got := likePrefix(`100% real_ai\`); got != `100\% real\_ai\\%` {
+		t.Errorf("likePrefix() = %s", got)
+	}
+}
@@ -0,0 +1,304 @@
+package ai_tool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+const (
+	// CATALOG_DEFAULT_LIMIT is the page size when a search does not ask for one
+	CATALOG_DEFAULT_LIMIT = 24
+	// CATALOG_MAX_LIMIT is the largest page a search can ask for
+	CATALOG_MAX_LIMIT = 100
+	// CATALOG_TAG_FACETS is how many of the most used tags are counted
+	CATALOG_TAG_FACETS = 50
+	// CATALOG_RANK_WEIGHTS are the ts_rank weights of the D, C, B and A labelled words of search_blob_tsv, so a match in
+	// the name counts most, then the tagline, the description and the rest of the meta data
+	CATALOG_RANK_WEIGHTS = "{0.1, 0.2, 0.4, 1.0}"
+)
+
+// facet dimensions, a facet is counted with every filter applied but its own
+const (
+	facetCategory         = "category"
+	facetBusinessFunction = "business_function"
+	facetTag              = "tag"
+	facetFreeTier         = "free_tier"
+	facetFeatured         = "featured"
+)
+
+const (
+	freeTierCondition = "ai_tools.meta_data->>'free_tier' = 'true'"
+	featuredCondition = "ai_tools.is_featured = 1"
+)
+
+// CatalogQuery is a search of the public catalog. Query takes web search syntax and may be empty to browse. Each
+// list filter matches tools with any of its values, and the filters are combined with AND.
+type CatalogQuery struct {
+	Query               string
+	CategoryIDs         []types.UUID
+	BusinessFunctionIDs []types.UUID
+	TagIDs              []types.UUID
+	FreeTier            bool
+	Featured            bool
+	Limit               int
+	Offset              int
+}
+
+// CatalogTool is a tool as listed by the catalog, Rank is 0 when browsing without a query
+type CatalogTool struct {
+	ID                           types.UUID `json:"id"`
+	Name                         string     `json:"name"`
+	Tagline                      string     `json:"tagline"`
+	Description                  string     `json:"description"`
+	Logo                         string     `json:"logo"`
+	WebsiteURL                   string     `json:"website_url"`
+	CategoryID                   types.UUID `json:"category_id"`
+	CategoryName                 string     `json:"category_name"`
+	BusinessFunctionCategoryID   types.UUID `json:"business_function_category_id"`
+	BusinessFunctionCategoryName string     `json:"business_function_category_name"`
+	IsFeatured                   bool       `json:"is_featured"`
+	FreeTier                     bool       `json:"free_tier"`
+	AverageRating                float64    `json:"average_rating"`
+	ReviewCount                  int64      `json:"review_count"`
+	Rank                         float64    `json:"rank"`
+}
+
+// FacetCount is how many tools a filter value would match, e.g. "Marketing (42)"
+type FacetCount struct {
+	ID    types.UUID `json:"id"`
+	Name  string     `json:"name"`
+	Count int64      `json:"count"`
+}
+
+// CatalogFacets counts the tools each filter value would match alongside the other filters
+type CatalogFacets struct {
+	Categories        []*FacetCount `json:"categories"`
+	BusinessFunctions []*FacetCount `json:"business_functions"`
+	Tags              []*FacetCount `json:"tags"`
+	FreeTier          int64         `json:"free_tier"`
+	Featured          int64         `json:"featured"`
+}
+
+// CatalogResults is a page of a catalog search with the total it was taken from and the facet counts
+type CatalogResults struct {
+	Tools  []*CatalogTool `json:"tools"`
+	Total  int64          `json:"total"`
+	Facets *CatalogFacets `json:"facets"`
+}
+
+// catalogConditions builds the conditions of a catalog search, leaving out the filter of the facet being counted
+func catalogConditions(query *CatalogQuery, skipFacet string) ([]string, map[string]any) {
+	conditions := []string{
+		"ai_tools.deleted = 0",
+		"ai_tools.disabled = 0",
+	}
+	params := map[string]any{}
+
+	if !tools.Empty(query.Query) {
+		conditions = append(conditions, "ai_tools.search_blob_tsv @@ websearch_to_tsquery('english', :query:)")
+		params[":query:"] = query.Query
+	}
+	if len(query.CategoryIDs) > 0 && skipFacet != facetCategory {
+		conditions = append(conditions, "ai_tools.category_id IN (:category_ids:)")
+		params[":category_ids:"] = query.CategoryIDs
+	}
+	if len(query.BusinessFunctionIDs) > 0 && skipFacet != facetBusinessFunction {
+		conditions = append(conditions, "ai_tools.business_function_category_id IN (:business_function_ids:)")
+		params[":business_function_ids:"] = query.BusinessFunctionIDs
+	}
+	if len(query.TagIDs) > 0 && skipFacet != facetTag {
+		conditions = append(conditions,
+			"ai_tools.urn IN (SELECT object_tags.object_urn FROM object_tags WHERE object_tags.tag_id IN (:tag_ids:))")
+		params[":tag_ids:"] = query.TagIDs
+	}
+	if query.FreeTier && skipFacet != facetFreeTier {
+		conditions = append(conditions, freeTierCondition)
+	}
+	if query.Featured && skipFacet != facetFeatured {
+		conditions = append(conditions, featuredCondition)
+	}
+	return conditions, params
+}
+
+// findCatalogTools returns a page of the tools matching a search, best ranked first and then featured and by name
+func findCatalogTools(query *CatalogQuery) ([]*CatalogTool, error) {
+	conditions, params := catalogConditions(query, "")
+	rank := "0::float8"
+	if !tools.Empty(query.Query) {
+		rank = fmt.Sprintf("ts_rank('%s', ai_tools.search_blob_tsv, websearch_to_tsquery('english', :query:))::float8",
+			CATALOG_RANK_WEIGHTS)
+	}
+	params[":limit:"] = query.Limit
+	params[":offset:"] = query.Offset
+
+	rows, err := environment.DB().DB.GetAll(fmt.Sprintf(`
+	SELECT
+		ai_tools.id::text AS id,
+		ai_tools.name AS name,
+		COALESCE(ai_tools.meta_data->>'tagline', '') AS tagline,
+		ai_tools.description AS description,
+		COALESCE(ai_tools.meta_data->>'logo', '') AS logo,
+		ai_tools.website_url AS website_url,
+		COALESCE(ai_tools.category_id::text, '') AS category_id,
+		COALESCE(categories.name, '') AS category_name,
+		COALESCE(ai_tools.business_function_category_id::text, '') AS business_function_category_id,
+		COALESCE(business_function_categories.name, '') AS business_function_category_name,
+		ai_tools.is_featured::bigint AS is_featured,
+		COALESCE((%s)::int, 0)::bigint AS free_tier,
+		ai_tools.average_rating::float8 AS average_rating,
+		ai_tools.review_count::bigint AS review_count,
+		%s AS rank
+	FROM ai_tools
+	LEFT JOIN categories ON ai_tools.category_id = categories.id
+	LEFT JOIN categories business_function_categories ON ai_tools.business_function_category_id = business_function_categories.id
+	WHERE %s
+	ORDER BY rank DESC, ai_tools.is_featured DESC, ai_tools.name ASC, ai_tools.id ASC
+	LIMIT :limit: OFFSET :offset:
+	`, freeTierCondition, rank, strings.Join(conditions, " AND ")), params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*CatalogTool, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, &CatalogTool{
+			ID:                           types.UUID(common.RowString(row, "id")),
+			Name:                         common.RowString(row, "name"),
+			Tagline:                      common.RowString(row, "tagline"),
+			Description:                  common.RowString(row, "description"),
+			Logo:                         common.RowString(row, "logo"),
+			WebsiteURL:                   common.RowString(row, "website_url"),
+			CategoryID:                   types.UUID(common.RowString(row, "category_id")),
+			CategoryName:                 common.RowString(row, "category_name"),
+			BusinessFunctionCategoryID:   types.UUID(common.RowString(row, "business_function_category_id")),
+			BusinessFunctionCategoryName: common.RowString(row, "business_function_category_name"),
+			IsFeatured:                   common.RowInt(row, "is_featured") == 1,
+			FreeTier:                     common.RowInt(row, "free_tier") == 1,
+			AverageRating:                common.RowFloat(row, "average_rating"),
+			ReviewCount:                  common.RowInt(row, "review_count"),
+			Rank:                         common.RowFloat(row, "rank"),
+		})
+	}
+	return results, nil
+}
+
+// countCatalogTools counts the tools matching a search
+func countCatalogTools(query *CatalogQuery) (int64, error) {
+	return countWhere(query, "", "")
+}
+
+// countWhere counts the tools matching a search without the skipped facet's filter and with an extra condition
+func countWhere(query *CatalogQuery, skipFacet string, condition string) (int64, error) {
+	conditions, params := catalogConditions(query, skipFacet)
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+
+	rows, err := environment.DB().DB.GetAll(fmt.Sprintf(`
+	SELECT COUNT(*)::bigint AS count FROM ai_tools WHERE %s
+	`, strings.Join(conditions, " AND ")), params)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return common.RowInt(rows[0], "count"), nil
+}
+
+// findCatalogFacets counts the tools every category, business function and tag would match, and how many of them
+// have a free tier or are featured. Each facet is counted with the other filters applied, so picking one category
+// still shows how many tools the others have.
+func findCatalogFacets(query *CatalogQuery) (*CatalogFacets, error) {
+	facets := &CatalogFacets{}
+	var err error
+
+	facets.Categories, err = findFacetCounts(query, facetCategory, `
+	SELECT categories.id::text AS id, categories.name AS name, COUNT(*)::bigint AS count
+	FROM ai_tools
+	JOIN categories ON categories.id = ai_tools.category_id
+	WHERE %s
+	GROUP BY categories.id, categories.name
+	ORDER BY count DESC, categories.name ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.BusinessFunctions, err = findFacetCounts(query, facetBusinessFunction, `
+	SELECT categories.id::text AS id, categories.name AS name, COUNT(*)::bigint AS count
+	FROM ai_tools
+	JOIN categories ON categories.id = ai_tools.business_function_category_id
+	WHERE %s
+	GROUP BY categories.id, categories.name
+	ORDER BY count DESC, categories.name ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.Tags, err = findFacetCounts(query, facetTag, fmt.Sprintf(`
+	SELECT tags.id::text AS id, tags.name AS name, COUNT(DISTINCT ai_tools.id)::bigint AS count
+	FROM ai_tools
+	JOIN object_tags ON object_tags.object_urn = ai_tools.urn
+	JOIN tags ON tags.id = object_tags.tag_id
+	WHERE %%s AND tags.internal = 0
+	GROUP BY tags.id, tags.name
+	ORDER BY count DESC, tags.name ASC
+	LIMIT %d
+	`, CATALOG_TAG_FACETS))
+	if err != nil {
+		return nil, err
+	}
+
+	facets.FreeTier, err = countWhere(query, facetFreeTier, freeTierCondition)
+	if err != nil {
+		return nil, err
+	}
+
+	facets.Featured, err = countWhere(query, facetFeatured, featuredCondition)
+	if err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// findFacetCounts runs a facet count, sql selects id, name and count and has a %s for the conditions
+func findFacetCounts(query *CatalogQuery, facet string, sql string) ([]*FacetCount, error) {
+	conditions, params := catalogConditions(query, facet)
+
+	rows, err := environment.DB().DB.GetAll(fmt.Sprintf(sql, strings.Join(conditions, " AND ")), params)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]*FacetCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, &FacetCount{
+			ID:    types.UUID(common.RowString(row, "id")),
+			Name:  common.RowString(row, "name"),
+			Count: common.RowInt(row, "count"),
+		})
+	}
+	return counts, nil
+}
+
+// normalize applies the default and maximum page sizes
+func (this *CatalogQuery) normalize() {
+	this.Query = strings.TrimSpace(this.Query)
+	if this.Limit <= 0 {
+		this.Limit = CATALOG_DEFAULT_LIMIT
+	}
+	if this.Limit > CATALOG_MAX_LIMIT {
+		this.Limit = CATALOG_MAX_LIMIT
+	}
+	if this.Offset < 0 {
+		this.Offset = 0
+	}
+}
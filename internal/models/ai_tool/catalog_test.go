@@ -0,0 +1,57 @@
+package ai_tool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/griffnb/core/lib/types"
+)
+
+func TestCatalogConditions(t *testing.T) {
+	query := &CatalogQuery{
+		Query:       "copy writing",
+		CategoryIDs: []types.UUID{"c1"},
+		TagIDs:      []types.UUID{"t1"},
+		FreeTier:    true,
+	}
+
+	conditions, params := catalogConditions(query, "")
+	joined := strings.Join(conditions, " AND ")
+	for _, want := range []string{"search_blob_tsv @@", "category_id IN", "object_tags", freeTierCondition} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("conditions %q missing %q", joined, want)
+		}
+	}
+	if strings.Contains(joined, featuredCondition) {
+		t.Errorf("conditions %q should not filter on featured", joined)
+	}
+	if _, ok := params[":business_function_ids:"]; ok {
+		t.Errorf("params should not include business functions")
+	}
+
+	conditions, params = catalogConditions(query, facetCategory)
+	joined = strings.Join(conditions, " AND ")
+	if strings.Contains(joined, "category_id IN") {
+		t.Errorf("category facet should not filter on category, got %q", joined)
+	}
+	if _, ok := params[":category_ids:"]; ok {
+		t.Errorf("category facet should not bind category ids")
+	}
+	if !strings.Contains(joined, freeTierCondition) {
+		t.Errorf("category facet should keep the other filters, got %q", joined)
+	}
+}
+
+func TestCatalogQueryNormalize(t *testing.T) {
+	query := &CatalogQuery{Query: "  chat  ", Limit: 1000, Offset: -5}
+	query.normalize()
+	if query.Query != "chat" || query.Limit != CATALOG_MAX_LIMIT || query.Offset != 0 {
+		t.Errorf("normalize() = %+v", query)
+	}
+
+	query = &CatalogQuery{}
+	query.normalize()
+	if query.Limit != CATALOG_DEFAULT_LIMIT {
+		t.Errorf("Limit = %d, want %d", query.Limit, CATALOG_DEFAULT_LIMIT)
+	}
+}
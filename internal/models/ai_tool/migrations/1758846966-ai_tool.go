@@ -3,6 +3,7 @@ package migrations
 import (
 	"github.com/griffnb/core/lib/model"
 	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
 	"github.com/griffnb/techboss-ai-go/internal/models/base"
 )
 
@@ -17,6 +18,50 @@ func init() {
 			Type: model.CREATE_TABLE,
 		},
 	})
+
+	// search_blob_tsv was a plain cast of the search blob written on save. It is replaced by a column generated for
+	// the catalog search: the name is weighted A, the tagline B, the description C and every other text of the meta
+	// data D, so ts_rank can rank name matches first.
+	model.AddMigration(&model.Migration{
+		ID:    1792283900,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE ai_tools DROP COLUMN IF EXISTS search_blob_tsv;
+			ALTER TABLE ai_tools ADD COLUMN search_blob_tsv tsvector
+				GENERATED ALWAYS AS (
+					setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+					setweight(to_tsvector('english', coalesce(meta_data->>'tagline', '')), 'B') ||
+					setweight(to_tsvector('english', coalesce(description, '')), 'C') ||
+					setweight(jsonb_to_tsvector('english', coalesce(meta_data, '{}'::jsonb), '["string"]'), 'D')
+				) STORED;
+			CREATE INDEX IF NOT EXISTS ai_tools_search_blob_tsv_idx ON ai_tools USING GIN (search_blob_tsv)
+			`, map[string]interface{}{})
+		},
+	})
+
+	// Trigram index for typeahead, matches names by prefix and tolerates typos
+	model.AddMigration(&model.Migration{
+		ID:    1792284000,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			CREATE INDEX IF NOT EXISTS ai_tools_name_trgm_idx ON ai_tools USING GIN (lower(name) gin_trgm_ops)
+			`, map[string]interface{}{})
+		},
+	})
+
+	// Kept up to date from the tool's approved reviews
+	model.AddMigration(&model.Migration{
+		ID:    1792284300,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE ai_tools ADD COLUMN IF NOT EXISTS average_rating numeric(3,2) DEFAULT 0;
+			ALTER TABLE ai_tools ADD COLUMN IF NOT EXISTS review_count integer DEFAULT 0
+			`, map[string]interface{}{})
+		},
+	})
 }
 
 type AiToolV1 struct {
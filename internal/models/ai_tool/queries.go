@@ -16,4 +16,54 @@ type Mocker struct {
 	FindFirst        func(ctx context.Context, options *model.Options) (*AiTool, error)
 	FindFirstJoined  func(ctx context.Context, options *model.Options) (*AiToolJoined, error)
 	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	SearchCatalog   func(ctx context.Context, query *CatalogQuery) (*CatalogResults, error)
+	FindSuggestions func(ctx context.Context, input string, limit int) ([]*Suggestion, error)
+}
+
+// SearchCatalog searches the public catalog, returning a page of tools with the total they were taken from and
+// the facet counts of every filter
+func SearchCatalog(ctx context.Context, query *CatalogQuery) (*CatalogResults, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.SearchCatalog(ctx, query)
+	}
+
+	query.normalize()
+
+	toolObjs, err := findCatalogTools(query)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := countCatalogTools(query)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := findCatalogFacets(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CatalogResults{
+		Tools:  toolObjs,
+		Total:  total,
+		Facets: facets,
+	}, nil
+}
+
+// FindSuggestions returns the tools, categories and tags best matching typeahead input, closest and most popular
+// first. Nothing is returned for input shorter than SUGGESTION_MIN_LENGTH.
+func FindSuggestions(ctx context.Context, input string, limit int) ([]*Suggestion, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindSuggestions(ctx, input, limit)
+	}
+
+	input, ok = normalizeSuggestionInput(input)
+	if !ok {
+		return []*Suggestion{}, nil
+	}
+	return findSuggestions(input, suggestionLimit(limit))
 }
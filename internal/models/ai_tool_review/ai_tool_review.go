@@ -0,0 +1,86 @@
+//go:generate core_gen model AiToolReview
+
+package ai_tool_review
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review/migrations"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const (
+	TABLE        string = "ai_tool_reviews"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+const (
+	MIN_RATING = 1
+	MAX_RATING = 5
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	AiToolID         *fields.UUIDField                          `public:"view" column:"ai_tool_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	AccountID        *fields.UUIDField                          `public:"view" column:"account_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Rating           *fields.IntField                           `public:"edit" column:"rating"            type:"smallint" default:"0"                index:"true"`
+	Pros             *fields.StringField                        `public:"edit" column:"pros"              type:"text"     default:""`
+	Cons             *fields.StringField                        `public:"edit" column:"cons"              type:"text"     default:""`
+	Body             *fields.StringField                        `public:"edit" column:"body"              type:"text"     default:""`
+	IsVerified       *fields.IntField                           `public:"view" column:"is_verified"       type:"smallint" default:"0"`
+	ModerationStatus *fields.IntConstantField[ModerationStatus] `public:"view" column:"moderation_status" type:"smallint" default:"1"                index:"true"`
+	ModerationNote   *fields.StringField                        `              column:"moderation_note"   type:"text"     default:""`
+	HelpfulCount     *fields.IntField                           `public:"view" column:"helpful_count"     type:"integer"  default:"0"                index:"true"`
+	NotHelpfulCount  *fields.IntField                           `public:"view" column:"not_helpful_count" type:"integer"  default:"0"`
+}
+
+type JoinData struct {
+	AccountName *fields.StringField `public:"view" json:"account_name" type:"text"`
+	AiToolName  *fields.StringField `public:"view" json:"ai_tool_name" type:"text"`
+}
+
+type AiToolReview struct {
+	model.BaseModel
+	DBColumns
+}
+
+type AiToolReviewJoined struct {
+	AiToolReview
+	JoinData
+}
+
+func (this *AiToolReview) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+// afterSave refreshes the tool's rating, any change to a review can change which reviews it counts
+func (this *AiToolReview) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	err := UpdateToolRating(ctx, this.AiToolID.Get())
+	if err != nil {
+		log.ErrorContext(err, ctx)
+	}
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
@@ -0,0 +1,53 @@
+package ai_tool_review
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+// UpdateToolRating recalculates a tool's average rating and review count from its approved reviews
+func UpdateToolRating(ctx context.Context, aiToolID types.UUID) error {
+	if tools.Empty(aiToolID) {
+		return nil
+	}
+
+	return environment.DB().DB.InsertWithContext(ctx, `
+	UPDATE ai_tools SET average_rating = ratings.average, review_count = ratings.count
+	FROM (
+		SELECT COALESCE(ROUND(AVG(rating)::numeric, 2), 0) AS average, COUNT(*) AS count
+		FROM ai_tool_reviews
+		WHERE ai_tool_id = :ai_tool_id: AND deleted = 0 AND disabled = 0 AND moderation_status = :approved:
+	) ratings
+	WHERE ai_tools.id = :ai_tool_id:
+	`, map[string]any{
+		":ai_tool_id:": aiToolID,
+		":approved:":   MODERATION_APPROVED,
+	})
+}
+
+// UpdateHelpfulCounts recounts a review's helpful and not helpful votes
+func UpdateHelpfulCounts(ctx context.Context, reviewID types.UUID) error {
+	return environment.DB().DB.InsertWithContext(ctx, `
+	UPDATE ai_tool_reviews SET helpful_count = votes.helpful, not_helpful_count = votes.not_helpful
+	FROM (
+		SELECT
+			COUNT(*) FILTER (WHERE helpful = 1) AS helpful,
+			COUNT(*) FILTER (WHERE helpful = 0) AS not_helpful
+		FROM ai_tool_review_votes
+		WHERE ai_tool_review_id = :ai_tool_review_id:
+	) votes
+	WHERE ai_tool_reviews.id = :ai_tool_review_id:
+	`, map[string]any{
+		":ai_tool_review_id:": reviewID,
+	})
+}
+
+// IsPublic reports whether the review is shown on its tool and counted in its rating
+func (this *AiToolReview) IsPublic() bool {
+	return this.Deleted.Get() == 0 &&
+		this.Disabled.Get() == 0 &&
+		this.ModerationStatus.Get() == MODERATION_APPROVED
+}
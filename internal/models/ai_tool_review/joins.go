@@ -0,0 +1,17 @@
+package ai_tool_review
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{
+		"LEFT JOIN accounts ON ai_tool_reviews.account_id = accounts.id",
+		"LEFT JOIN ai_tools ON ai_tool_reviews.ai_tool_id = ai_tools.id",
+	}...)
+	options.WithIncludeFields([]string{
+		"TRIM(CONCAT(accounts.first_name, ' ', LEFT(accounts.last_name, 1))) AS account_name",
+		"ai_tools.name AS ai_tool_name",
+	}...)
+}
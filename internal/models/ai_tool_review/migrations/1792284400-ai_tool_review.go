@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "ai_tool_reviews"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792284400,
+		Table:       TABLE,
+		TableStruct: &AiToolReviewV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+
+	// One review per account per tool, a deleted review does not stop the account reviewing the tool again
+	model.AddMigration(&model.Migration{
+		ID:    1792284500,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			CREATE UNIQUE INDEX IF NOT EXISTS ai_tool_reviews_tool_account_unique
+				ON ai_tool_reviews (ai_tool_id, account_id) WHERE deleted = 0
+			`, map[string]interface{}{})
+		},
+	})
+}
+
+type AiToolReviewV1 struct {
+	base.Structure
+	AiToolID         *fields.UUIDField             `column:"ai_tool_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	AccountID        *fields.UUIDField             `column:"account_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Rating           *fields.IntField              `column:"rating"            type:"smallint" default:"0"                index:"true"`
+	Pros             *fields.StringField           `column:"pros"              type:"text"     default:""`
+	Cons             *fields.StringField           `column:"cons"              type:"text"     default:""`
+	Body             *fields.StringField           `column:"body"              type:"text"     default:""`
+	IsVerified       *fields.IntField              `column:"is_verified"       type:"smallint" default:"0"`
+	ModerationStatus *fields.IntConstantField[int] `column:"moderation_status" type:"smallint" default:"1"                index:"true"`
+	ModerationNote   *fields.StringField           `column:"moderation_note"   type:"text"     default:""`
+	HelpfulCount     *fields.IntField              `column:"helpful_count"     type:"integer"  default:"0"                index:"true"`
+	NotHelpfulCount  *fields.IntField              `column:"not_helpful_count" type:"integer"  default:"0"`
+}
@@ -0,0 +1,24 @@
+package ai_tool_review
+
+import "slices"
+
+// ModerationStatus is where a review is in moderation, only approved reviews are public and counted in ratings
+type ModerationStatus int
+
+const (
+	MODERATION_PENDING ModerationStatus = iota + 1
+	MODERATION_APPROVED
+	MODERATION_REJECTED
+)
+
+// MODERATION_STATUSES are the statuses a moderator can set
+var MODERATION_STATUSES = []ModerationStatus{
+	MODERATION_PENDING,
+	MODERATION_APPROVED,
+	MODERATION_REJECTED,
+}
+
+// Valid reports whether the status is one of MODERATION_STATUSES
+func (this ModerationStatus) Valid() bool {
+	return slices.Contains(MODERATION_STATUSES, this)
+}
@@ -0,0 +1,38 @@
+package ai_tool_review
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*AiToolReview, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*AiToolReviewJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*AiToolReview, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*AiToolReviewJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*AiToolReview, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*AiToolReviewJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetByToolAndAccount func(ctx context.Context, aiToolID types.UUID, accountID types.UUID) (*AiToolReview, error)
+}
+
+// GetByToolAndAccount returns an account's review of a tool, empty when it has not reviewed it
+func GetByToolAndAccount(ctx context.Context, aiToolID types.UUID, accountID types.UUID) (*AiToolReview, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetByToolAndAccount(ctx, aiToolID, accountID)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :ai_tool_id:", Columns.AiToolID.Column()).
+		WithParam(":ai_tool_id:", aiToolID).
+		WithCondition("%s = :account_id:", Columns.AccountID.Column()).
+		WithParam(":account_id:", accountID).
+		WithCondition("%s = 0", Columns.Deleted.Column())
+
+	return FindFirst(ctx, options)
+}
@@ -0,0 +1,80 @@
+package ai_tool_review
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/sanitize"
+	"github.com/griffnb/core/lib/types"
+)
+
+// FindAllRestrictedJoined returns the session account's own reviews, whatever their moderation status
+func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionAccount coremodel.Model) ([]*AiToolReviewJoined, error) {
+	options.WithCondition("%s.account_id = :account_id:", TABLE)
+	options.WithParam(":account_id:", sessionAccount.ID())
+	options.WithCondition("%s.deleted = 0", TABLE)
+	return FindAllJoined(ctx, options)
+}
+
+// GetRestrictedJoined gets one of the session account's own reviews
+func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionAccount coremodel.Model) (*AiToolReviewJoined, error) {
+	options := model.NewOptions().
+		WithCondition("%s.id = :id:", TABLE).
+		WithParam(":id:", id).
+		WithCondition("%s.account_id = :account_id:", TABLE).
+		WithParam(":account_id:", sessionAccount.ID()).
+		WithCondition("%s.deleted = 0", TABLE)
+
+	return FindFirstJoined(ctx, options)
+}
+
+// FindAllPublicJoined returns a tool's approved reviews
+func FindAllPublicJoined(ctx context.Context, options *model.Options, aiToolID types.UUID) ([]*AiToolReviewJoined, error) {
+	addPublicConditions(options)
+	options.WithCondition("%s.ai_tool_id = :ai_tool_id:", TABLE)
+	options.WithParam(":ai_tool_id:", aiToolID)
+	return FindAllJoined(ctx, options)
+}
+
+// CountPublic counts a tool's approved reviews
+func CountPublic(ctx context.Context, options *model.Options, aiToolID types.UUID) (int64, error) {
+	addPublicConditions(options)
+	options.WithCondition("%s.ai_tool_id = :ai_tool_id:", TABLE)
+	options.WithParam(":ai_tool_id:", aiToolID)
+	return FindResultsCount(ctx, options)
+}
+
+// GetPublicJoined gets an approved review
+func GetPublicJoined(ctx context.Context, id types.UUID) (*AiToolReviewJoined, error) {
+	options := model.NewOptions().
+		WithCondition("%s.id = :id:", TABLE).
+		WithParam(":id:", id)
+	addPublicConditions(options)
+
+	return FindFirstJoined(ctx, options)
+}
+
+func addPublicConditions(options *model.Options) {
+	options.WithCondition("%s.deleted = 0", TABLE)
+	options.WithCondition("%s.disabled = 0", TABLE)
+	options.WithCondition("%s.moderation_status = :moderation_status:", TABLE)
+	options.WithParam(":moderation_status:", MODERATION_APPROVED)
+}
+
+// NewPublic creates a review by the session account from sanitized input, it waits for moderation before it is shown
+func NewPublic(data map[string]any, sessionAccount coremodel.Model) *AiToolReview {
+	obj := New()
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+	obj.AccountID.Set(sessionAccount.ID())
+	obj.ModerationStatus.Set(MODERATION_PENDING)
+	return obj
+}
+
+// UpdatePublic applies the author's changes, an edited review goes back to moderation
+func UpdatePublic(obj *AiToolReview, data map[string]any, _ coremodel.Model) {
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+	obj.ModerationStatus.Set(MODERATION_PENDING)
+}
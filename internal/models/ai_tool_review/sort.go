@@ -0,0 +1,37 @@
+package ai_tool_review
+
+import "github.com/griffnb/core/lib/model"
+
+// Sort orders for listing a tool's reviews
+const (
+	SORT_NEWEST      = "newest"
+	SORT_HELPFUL     = "helpful"
+	SORT_RATING_HIGH = "rating_high"
+	SORT_RATING_LOW  = "rating_low"
+)
+
+// sortOrders are the ORDER BY clauses of each sort, ties go to the newest review
+var sortOrders = map[string]string{
+	SORT_NEWEST:      "ai_tool_reviews.created_at DESC",
+	SORT_HELPFUL:     "ai_tool_reviews.helpful_count DESC, ai_tool_reviews.created_at DESC",
+	SORT_RATING_HIGH: "ai_tool_reviews.rating DESC, ai_tool_reviews.created_at DESC",
+	SORT_RATING_LOW:  "ai_tool_reviews.rating ASC, ai_tool_reviews.created_at DESC",
+}
+
+// ValidSort reports whether sort is one of the sort orders, empty is allowed and sorts by newest
+func ValidSort(sort string) bool {
+	if sort == "" {
+		return true
+	}
+	_, ok := sortOrders[sort]
+	return ok
+}
+
+// AddSort orders the options by one of the sort orders, newest first when sort is empty or unknown
+func AddSort(options *model.Options, sort string) {
+	order, ok := sortOrders[sort]
+	if !ok {
+		order = sortOrders[SORT_NEWEST]
+	}
+	options.WithOrder(order)
+}
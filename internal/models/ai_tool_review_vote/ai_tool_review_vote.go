@@ -0,0 +1,74 @@
+//go:generate core_gen model AiToolReviewVote
+
+package ai_tool_review_vote
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review_vote/migrations"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const (
+	TABLE        string = "ai_tool_review_votes"
+	CHANGE_LOGS         = false
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	AiToolReviewID *fields.UUIDField `public:"view" column:"ai_tool_review_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	AccountID      *fields.UUIDField `public:"view" column:"account_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Helpful        *fields.IntField  `public:"edit" column:"helpful"           type:"smallint" default:"0"`
+}
+
+type JoinData struct{}
+
+type AiToolReviewVote struct {
+	model.BaseModel
+	DBColumns
+}
+
+type AiToolReviewVoteJoined struct {
+	AiToolReviewVote
+	JoinData
+}
+
+func (this *AiToolReviewVote) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+// afterSave recounts the review's votes
+func (this *AiToolReviewVote) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	if !tools.Empty(this.AiToolReviewID.Get()) {
+		err := ai_tool_review.UpdateHelpfulCounts(ctx, this.AiToolReviewID.Get())
+		if err != nil {
+			log.ErrorContext(err, ctx)
+		}
+	}
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
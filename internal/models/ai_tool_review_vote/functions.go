@@ -0,0 +1,19 @@
+package ai_tool_review_vote
+
+import (
+	"context"
+
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review"
+)
+
+// Delete removes the vote and recounts its review's votes
+func (this *AiToolReviewVote) Delete(ctx context.Context) error {
+	err := environment.DB().DB.InsertWithContext(ctx, "DELETE FROM ai_tool_review_votes WHERE id = :id:", map[string]any{
+		":id:": this.ID(),
+	})
+	if err != nil {
+		return err
+	}
+	return ai_tool_review.UpdateHelpfulCounts(ctx, this.AiToolReviewID.Get())
+}
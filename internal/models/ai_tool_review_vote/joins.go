@@ -0,0 +1,11 @@
+package ai_tool_review_vote
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{}...)
+	options.WithIncludeFields([]string{}...)
+}
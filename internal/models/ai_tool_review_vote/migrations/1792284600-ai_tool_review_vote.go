@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "ai_tool_review_votes"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792284600,
+		Table:       TABLE,
+		TableStruct: &AiToolReviewVoteV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+
+	// One vote per account per review, changing a vote updates it
+	model.AddMigration(&model.Migration{
+		ID:    1792284700,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE ai_tool_review_votes
+				ADD CONSTRAINT ai_tool_review_votes_review_account_unique UNIQUE (ai_tool_review_id, account_id)
+			`, map[string]interface{}{})
+		},
+	})
+}
+
+type AiToolReviewVoteV1 struct {
+	base.Structure
+	AiToolReviewID *fields.UUIDField `column:"ai_tool_review_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	AccountID      *fields.UUIDField `column:"account_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Helpful        *fields.IntField  `column:"helpful"           type:"smallint" default:"0"`
+}
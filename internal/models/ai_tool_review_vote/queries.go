@@ -0,0 +1,37 @@
+package ai_tool_review_vote
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*AiToolReviewVote, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*AiToolReviewVoteJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*AiToolReviewVote, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*AiToolReviewVoteJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*AiToolReviewVote, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*AiToolReviewVoteJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetByReviewAndAccount func(ctx context.Context, reviewID types.UUID, accountID types.UUID) (*AiToolReviewVote, error)
+}
+
+// GetByReviewAndAccount returns an account's vote on a review, empty when it has not voted
+func GetByReviewAndAccount(ctx context.Context, reviewID types.UUID, accountID types.UUID) (*AiToolReviewVote, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetByReviewAndAccount(ctx, reviewID, accountID)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :ai_tool_review_id:", Columns.AiToolReviewID.Column()).
+		WithParam(":ai_tool_review_id:", reviewID).
+		WithCondition("%s = :account_id:", Columns.AccountID.Column()).
+		WithParam(":account_id:", accountID)
+
+	return FindFirst(ctx, options)
+}
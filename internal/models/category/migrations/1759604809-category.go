@@ -3,6 +3,7 @@ package migrations
 import (
 	"github.com/griffnb/core/lib/model"
 	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
 	"github.com/griffnb/techboss-ai-go/internal/models/base"
 )
 
@@ -17,6 +18,17 @@ func init() {
 			Type: model.CREATE_TABLE,
 		},
 	})
+
+	// Trigram index for the catalog typeahead
+	model.AddMigration(&model.Migration{
+		ID:    1792284100,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			CREATE INDEX IF NOT EXISTS categories_name_trgm_idx ON categories USING GIN (lower(name) gin_trgm_ops)
+			`, map[string]interface{}{})
+		},
+	})
 }
 
 type CategoryV1 struct {
@@ -10,6 +10,7 @@ import (
 	"github.com/griffnb/techboss-ai-go/internal/common"
 	"github.com/griffnb/techboss-ai-go/internal/environment"
 	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/conversation/migrations"
 )
 
 const (
@@ -28,11 +29,18 @@ type Structure struct {
 
 type DBColumns struct {
 	base.Structure
-	AccountID *fields.UUIDField `column:"account_id" type:"uuid" default:"null" null:"true" index:"true"`
-	AgentID   *fields.UUIDField `column:"agent_id"   type:"uuid" default:"null" null:"true" index:"true"`
+	AccountID      *fields.UUIDField                `public:"view" column:"account_id"      type:"uuid"     default:"null" null:"true" index:"true"`
+	OrganizationID *fields.UUIDField                `public:"view" column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	AgentID        *fields.UUIDField                `public:"view" column:"agent_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Source         *fields.IntConstantField[Source] `public:"view" column:"source"          type:"smallint" default:"0"                index:"true"`
+	ExternalID     *fields.StringField              `public:"view" column:"external_id"     type:"text"     default:"null" null:"true" index:"true"`
+	ThreadID       *fields.StringField              `public:"view" column:"thread_id"       type:"text"     default:"null" null:"true" index:"true"`
+	WidgetLeadID   *fields.UUIDField                `public:"view" column:"widget_lead_id"  type:"uuid"     default:"null" null:"true" index:"true"`
 }
 
-type JoinData struct{}
+type JoinData struct {
+	AgentName *fields.StringField `public:"view" json:"agent_name" type:"text"`
+}
 
 type Conversation struct {
 	model.BaseModel
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "conversations"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792282000,
+		Table:       TABLE,
+		TableStruct: &ConversationV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+
+	model.AddMigration(&model.Migration{
+		ID:    1792282100,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE conversations ADD COLUMN IF NOT EXISTS thread_id text DEFAULT NULL;
+			CREATE INDEX IF NOT EXISTS conversations_thread_id_idx ON conversations (thread_id)
+			`, map[string]interface{}{})
+		},
+	})
+
+	model.AddMigration(&model.Migration{
+		ID:    1792282200,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE conversations ADD COLUMN IF NOT EXISTS widget_lead_id uuid DEFAULT NULL;
+			CREATE INDEX IF NOT EXISTS conversations_widget_lead_id_idx ON conversations (widget_lead_id)
+			`, map[string]interface{}{})
+		},
+	})
+}
+
+type ConversationV1 struct {
+	base.Structure
+	AccountID      *fields.UUIDField             `column:"account_id"      type:"uuid"     default:"null" null:"true" index:"true"`
+	OrganizationID *fields.UUIDField             `column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	AgentID        *fields.UUIDField             `column:"agent_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Source         *fields.IntConstantField[int] `column:"source"          type:"smallint" default:"0"                index:"true"`
+	ExternalID     *fields.StringField           `column:"external_id"     type:"text"     default:"null" null:"true" index:"true"`
+}
@@ -16,4 +16,21 @@ type Mocker struct {
 	FindFirst        func(ctx context.Context, options *model.Options) (*Conversation, error)
 	FindFirstJoined  func(ctx context.Context, options *model.Options) (*ConversationJoined, error)
 	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetBySourceExternalID func(ctx context.Context, source Source, externalID string) (*Conversation, error)
+}
+
+// GetBySourceExternalID returns the conversation an outside channel tracks under its own id, nil when none exists
+func GetBySourceExternalID(ctx context.Context, source Source, externalID string) (*Conversation, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetBySourceExternalID(ctx, source, externalID)
+	}
+
+	return FindFirst(ctx, model.NewOptions().
+		WithCondition("%s = :source:", Columns.Source.Column()).
+		WithCondition("%s = :external_id:", Columns.ExternalID.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":source:", source).
+		WithParam(":external_id:", externalID))
 }
@@ -0,0 +1,12 @@
+package conversation
+
+// Source is the channel a conversation was started from
+type Source int
+
+const (
+	SOURCE_CHATKIT Source = iota + 1
+	SOURCE_ASSISTANT
+	SOURCE_WIDGET
+	SOURCE_SLACK
+	SOURCE_EMAIL
+)
@@ -1,4 +1,5 @@
 //go:generate core_gen model Document
+
 package document
 
 import (
@@ -9,6 +10,8 @@ import (
 	"github.com/griffnb/techboss-ai-go/internal/common"
 	"github.com/griffnb/techboss-ai-go/internal/environment"
 	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/document/migrations"
+	"github.com/griffnb/techboss-ai-go/internal/models/object_tag"
 )
 
 const (
@@ -26,17 +29,32 @@ type Structure struct {
 
 type DBColumns struct {
 	base.Structure
-	AccountID      *fields.IntField                       `column:"account_id"       type:"integer"  default:"0"  index:"true"`
-	DocumentType   *fields.IntConstantField[DocumentType] `column:"document_type"    type:"smallint" default:"0"  index:"true"`
-	Name           *fields.StringField                    `column:"name"             type:"text"     default:""`
-	ProcessedS3URL *fields.StringField                    `column:"processed_s3_url" type:"text"     default:""`
-	RawS3URL       *fields.StringField                    `column:"raw_s3_url"       type:"text"     default:""`
-	MetaData       *fields.StructField[*MetaData]         `column:"meta_data"        type:"jsonb"    default:"{}"`
+	OrganizationID  *fields.UUIDField                      `public:"view" column:"organization_id"   type:"uuid"     default:"null" null:"true" index:"true"`
+	AccountID       *fields.UUIDField                      `public:"view" column:"account_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Sharing         *fields.IntConstantField[Sharing]      `public:"edit" column:"sharing"           type:"smallint" default:"1"                index:"true"`
+	ParentID        *fields.UUIDField                      `public:"view" column:"parent_id"         type:"uuid"     default:"null" null:"true" index:"true"`
+	DocumentGroupID *fields.UUIDField                      `public:"view" column:"document_group_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	DocumentType    *fields.IntConstantField[DocumentType] `public:"view" column:"document_type"     type:"smallint" default:"0"                index:"true"`
+	Name            *fields.StringField                    `public:"edit" column:"name"              type:"text"     default:""`
+	FileName        *fields.StringField                    `public:"view" column:"file_name"         type:"text"     default:""`
+	ContentType     *fields.StringField                    `public:"view" column:"content_type"      type:"text"     default:""`
+	ProcessedS3URL  *fields.StringField                    `public:"view" column:"processed_s3_url"  type:"text"     default:""`
+	RawS3URL        *fields.StringField                    `public:"view" column:"raw_s3_url"        type:"text"     default:""`
+	ChunkCount      *fields.IntField                       `public:"view" column:"chunk_count"       type:"integer"  default:"0"`
+	ErrorReason     *fields.StringField                    `public:"view" column:"error_reason"      type:"text"     default:""`
+	RefetchAtTS     *fields.IntField                       `public:"view" column:"refetch_at_ts"     type:"bigint"   default:"0"                index:"true"`
+	MetaData        *fields.StructField[*MetaData]         `public:"view" column:"meta_data"         type:"jsonb"    default:"{}"`
+	ContentHash     *fields.StringField                    `public:"view" column:"content_hash"      type:"text"     default:""                 index:"true"`
+	RootID          *fields.UUIDField                      `public:"view" column:"root_id"           type:"uuid"     default:"null" null:"true" index:"true"`
+	Version         *fields.IntField                       `public:"view" column:"version"           type:"integer"  default:"1"`
+	SupersededByID  *fields.UUIDField                      `public:"view" column:"superseded_by_id"  type:"uuid"     default:"null" null:"true" index:"true"`
+	DuplicateOfID   *fields.UUIDField                      `public:"view" column:"duplicate_of_id"   type:"uuid"     default:"null" null:"true" index:"true"`
 }
 
 type JoinData struct {
-	DocumentGroupName      *fields.StringField `public:"view" json:"document_group_name"`
-	DocumentGroupGroupType *fields.IntField    `public:"view" json:"document_group_group_type"`
+	DocumentGroupName      *fields.StringField                          `public:"view" json:"document_group_name"`
+	DocumentGroupGroupType *fields.IntField                             `public:"view" json:"document_group_group_type"`
+	Tags                   *fields.StructField[[]*object_tag.JoinedTag] `public:"view" json:"tags"                      type:"jsonb"`
 }
 
 type Document struct {
@@ -7,4 +7,8 @@ const (
 	DOCUMENT_TYPE_PDF
 	DOCUMENT_TYPE_TEXT
 	DOCUMENT_TYPE_SITEMAP
+	DOCUMENT_TYPE_DOCX
+	DOCUMENT_TYPE_HTML
+	DOCUMENT_TYPE_MARKDOWN
+	DOCUMENT_TYPE_CSV
 )
@@ -3,8 +3,10 @@ package document
 import (
 	"context"
 	"fmt"
+	"path"
 	"strings"
 
+	"github.com/griffnb/core/lib/types"
 	"github.com/griffnb/techboss-ai-go/internal/environment"
 )
 
@@ -30,5 +32,57 @@ func BuildS3URL(documentGroupKey string, documentKey, extension string) string {
 }
 
 func (this *Document) GetS3Data(field string) ([]byte, error) {
-	return environment.GetS3().DownloadToBuffer(context.Background(), environment.GetConfig().S3Config.Buckets["documents"], this.GetFilePath(field))
+	return environment.GetS3().DownloadToBuffer(context.Background(), Bucket(), this.GetFilePath(field))
+}
+
+// Bucket is the S3 bucket documents are stored in
+func Bucket() string {
+	return environment.GetConfig().S3Config.Buckets["documents"]
+}
+
+// ObjectURL is the stored URL of a key in the documents bucket, readable back with GetFilePath
+func ObjectURL(key string) string {
+	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", Bucket(), key)
+}
+
+// RawS3Key is where the uploaded file of the document is kept
+func (this *Document) RawS3Key() string {
+	extension := strings.TrimPrefix(path.Ext(this.FileName.Get()), ".")
+	if extension == "" {
+		extension = "bin"
+	}
+	return BuildS3URL("raw", this.ID().String(), strings.ToLower(extension))
+}
+
+// ProcessedS3Key is where the extracted text and chunks of the document are kept
+func (this *Document) ProcessedS3Key() string {
+	return BuildS3URL("processed", this.ID().String(), "json")
+}
+
+// ClearGroup takes every document out of a group that is being deleted
+func ClearGroup(ctx context.Context, groupID types.UUID) error {
+	return environment.DB().DB.InsertWithContext(ctx,
+		"UPDATE documents SET document_group_id = NULL WHERE document_group_id = :document_group_id:",
+		map[string]any{":document_group_id:": groupID},
+	)
+}
+
+// FindOrganizationIDs returns every organization that has documents
+func FindOrganizationIDs(ctx context.Context) ([]types.UUID, error) {
+	rows, err := environment.DB().DB.GetAll(
+		"SELECT DISTINCT organization_id::text AS organization_id FROM documents WHERE organization_id IS NOT NULL",
+		map[string]any{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIDs := make([]types.UUID, 0, len(rows))
+	for _, row := range rows {
+		organizationID, _ := row["organization_id"].(string)
+		if organizationID != "" {
+			organizationIDs = append(organizationIDs, types.UUID(organizationID))
+		}
+	}
+	return organizationIDs, nil
 }
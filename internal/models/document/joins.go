@@ -2,10 +2,18 @@ package document
 
 import (
 	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/techboss-ai-go/internal/models/object_tag"
 )
 
 // AddJoinData adds in the join data
 func AddJoinData(options *model.Options) {
-	options.WithPrependJoins([]string{}...)
-	options.WithIncludeFields([]string{}...)
+	options.WithPrependJoins([]string{
+		"LEFT JOIN document_groups ON document_groups.id = documents.document_group_id",
+		object_tag.PublicJoinQuery(TABLE),
+	}...)
+	options.WithIncludeFields([]string{
+		"document_groups.name AS document_group_name",
+		"document_groups.group_type AS document_group_group_type",
+		object_tag.JoinField(),
+	}...)
 }
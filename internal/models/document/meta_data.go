@@ -1,8 +1,54 @@
 package document
 
 type MetaData struct {
-	WebpageURL     string   `json:"webpage_url,omitempty"`
-	FileOpenAIID   string   `json:"file_openai_id,omitempty"`
-	VectorOpenAIID string   `json:"vector_openai_id,omitempty"`
-	Tags           []string `json:"tags,omitempty"`
+	WebpageURL string   `json:"webpage_url,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+
+	// Provider vector store, see vector_store_service
+	FileOpenAIID       string `json:"file_openai_id,omitempty"`   // uploaded copy of the extracted text
+	FileOpenAIHash     string `json:"file_openai_hash,omitempty"` // hash of the text uploaded, a change uploads it again
+	VectorOpenAIID     string `json:"vector_openai_id,omitempty"` // store the file is attached to
+	VectorOpenAIStatus string `json:"vector_openai_status,omitempty"`
+	VectorOpenAIError  string `json:"vector_openai_error,omitempty"`
+	VectorSyncedAtTS   int64  `json:"vector_synced_at_ts,omitempty"`
+
+	// Uploaded files
+	ExtractionErrors []string `json:"extraction_errors,omitempty"` // parts of the file that could not be read, the rest is indexed
+
+	// Webpage captures
+	Title        string   `json:"title,omitempty"`
+	Summary      string   `json:"summary,omitempty"`
+	KeyFacts     []string `json:"key_facts,omitempty"`
+	ContentHash  string   `json:"content_hash,omitempty"`
+	RefetchHours int      `json:"refetch_hours,omitempty"` // 0 captures the page once
+	FetchedAtTS  int64    `json:"fetched_at_ts,omitempty"`
+	ChangedAtTS  int64    `json:"changed_at_ts,omitempty"`
+	FetchError   string   `json:"fetch_error,omitempty"` // last failed re-fetch, the indexed content is kept
+
+	// Sitemap crawls
+	Crawl         *CrawlSettings `json:"crawl,omitempty"`
+	CrawlProgress *CrawlProgress `json:"crawl_progress,omitempty"`
+}
+
+// CrawlSettings limit which pages of a sitemap are captured. Patterns match the URL path, * matches anything.
+type CrawlSettings struct {
+	Include  []string `json:"include,omitempty"`
+	Exclude  []string `json:"exclude,omitempty"`
+	MaxPages int      `json:"max_pages,omitempty"`
+	DelayMS  int      `json:"delay_ms,omitempty"` // wait between requests to the same host, raised to the robots.txt Crawl-delay
+}
+
+// CrawlProgress is the state of the last crawl of a sitemap
+type CrawlProgress struct {
+	Stage       string `json:"stage,omitempty"`
+	Found       int    `json:"found"`
+	Processed   int    `json:"processed"`
+	Created     int    `json:"created"`
+	Updated     int    `json:"updated"`
+	Unchanged   int    `json:"unchanged"`
+	Skipped     int    `json:"skipped"` // excluded by a pattern or robots.txt
+	Failed      int    `json:"failed"`
+	StartedAtTS int64  `json:"started_at_ts,omitempty"`
+	UpdatedAtTS int64  `json:"updated_at_ts,omitempty"`
+	Error       string `json:"error,omitempty"`
 }
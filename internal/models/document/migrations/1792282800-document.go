@@ -0,0 +1,103 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "documents"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792282800,
+		Table:       TABLE,
+		TableStruct: &DocumentV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+
+	model.AddMigration(&model.Migration{
+		ID:    1792282900,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS refetch_at_ts bigint DEFAULT 0;
+			CREATE INDEX IF NOT EXISTS documents_refetch_at_ts_idx ON documents (refetch_at_ts)
+			`, map[string]interface{}{})
+		},
+	})
+
+	model.AddMigration(&model.Migration{
+		ID:    1792283000,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS parent_id uuid DEFAULT NULL;
+			CREATE INDEX IF NOT EXISTS documents_parent_id_idx ON documents (parent_id)
+			`, map[string]interface{}{})
+		},
+	})
+
+	model.AddMigration(&model.Migration{
+		ID:    1792283400,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS document_group_id uuid DEFAULT NULL;
+			CREATE INDEX IF NOT EXISTS documents_document_group_id_idx ON documents (document_group_id)
+			`, map[string]interface{}{})
+		},
+	})
+
+	// Documents belonged to their uploader, they now belong to the uploader's organization and are shared with it
+	model.AddMigration(&model.Migration{
+		ID:    1792283500,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS organization_id uuid DEFAULT NULL;
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS sharing smallint DEFAULT 1;
+			CREATE INDEX IF NOT EXISTS documents_organization_id_idx ON documents (organization_id);
+			CREATE INDEX IF NOT EXISTS documents_sharing_idx ON documents (sharing);
+			UPDATE documents SET organization_id = accounts.organization_id
+				FROM accounts
+				WHERE accounts.id = documents.account_id AND documents.organization_id IS NULL
+			`, map[string]interface{}{})
+		},
+	})
+
+	model.AddMigration(&model.Migration{
+		ID:    1792283600,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS content_hash text DEFAULT '';
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS root_id uuid DEFAULT NULL;
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS version integer DEFAULT 1;
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS superseded_by_id uuid DEFAULT NULL;
+			ALTER TABLE documents ADD COLUMN IF NOT EXISTS duplicate_of_id uuid DEFAULT NULL;
+			CREATE INDEX IF NOT EXISTS documents_content_hash_idx ON documents (content_hash);
+			CREATE INDEX IF NOT EXISTS documents_root_id_idx ON documents (root_id);
+			CREATE INDEX IF NOT EXISTS documents_superseded_by_id_idx ON documents (superseded_by_id);
+			CREATE INDEX IF NOT EXISTS documents_duplicate_of_id_idx ON documents (duplicate_of_id)
+			`, map[string]interface{}{})
+		},
+	})
+}
+
+type DocumentV1 struct {
+	base.Structure
+	AccountID      *fields.UUIDField             `column:"account_id"       type:"uuid"     default:"null" null:"true" index:"true"`
+	DocumentType   *fields.IntConstantField[int] `column:"document_type"    type:"smallint" default:"0"                index:"true"`
+	Name           *fields.StringField           `column:"name"             type:"text"     default:""`
+	FileName       *fields.StringField           `column:"file_name"        type:"text"     default:""`
+	ContentType    *fields.StringField           `column:"content_type"     type:"text"     default:""`
+	ProcessedS3URL *fields.StringField           `column:"processed_s3_url" type:"text"     default:""`
+	RawS3URL       *fields.StringField           `column:"raw_s3_url"       type:"text"     default:""`
+	ChunkCount     *fields.IntField              `column:"chunk_count"      type:"integer"  default:"0"`
+	ErrorReason    *fields.StringField           `column:"error_reason"     type:"text"     default:""`
+	MetaData       *fields.StructField[any]      `column:"meta_data"        type:"jsonb"    default:"{}"`
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/griffnb/core/lib/model"
 	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
 )
 
 type Mocker struct {
@@ -16,4 +18,180 @@ type Mocker struct {
 	FindFirst        func(ctx context.Context, options *model.Options) (*Document, error)
 	FindFirstJoined  func(ctx context.Context, options *model.Options) (*DocumentJoined, error)
 	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	FindDueRefetches  func(ctx context.Context, nowTS int64) ([]*Document, error)
+	FindChildren      func(ctx context.Context, parentID types.UUID) ([]*Document, error)
+	FindVersions      func(ctx context.Context, rootID types.UUID) ([]*Document, error)
+	FindIdentical     func(ctx context.Context, documentObj *Document) (*Document, error)
+	FindVectorSynced  func(ctx context.Context, organizationID types.UUID) ([]*Document, error)
+	FindVectorFileIDs func(ctx context.Context, organizationID types.UUID) ([]string, error)
+	FindByGroup       func(ctx context.Context, groupID types.UUID) ([]*Document, error)
+	FindExpired       func(ctx context.Context, organizationID types.UUID, days int64, tagName string, rawFilesOnly bool) ([]*Document, error)
+}
+
+// FindDueRefetches returns a batch of indexed webpage captures and sitemaps whose re-fetch time has passed, oldest first
+func FindDueRefetches(ctx context.Context, nowTS int64) ([]*Document, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindDueRefetches(ctx, nowTS)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s IN (:document_types:)", Columns.DocumentType.Column()).
+		WithCondition("%s = :status:", Columns.Status.Column()).
+		WithCondition("%s > 0 AND %s <= :now:", Columns.RefetchAtTS.Column(), Columns.RefetchAtTS.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":document_types:", []int{int(DOCUMENT_TYPE_WEBPAGE_SUMMARY), int(DOCUMENT_TYPE_SITEMAP)}).
+		WithParam(":status:", STATUS_INDEXED).
+		WithParam(":now:", nowTS).
+		WithOrder("%s asc", Columns.RefetchAtTS.Column())
+	options.Limit = constants.SYSTEM_LIMIT
+	return FindAll(ctx, options)
+}
+
+// FindChildren returns the pages a sitemap crawl created
+func FindChildren(ctx context.Context, parentID types.UUID) ([]*Document, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindChildren(ctx, parentID)
+	}
+
+	return FindAll(ctx, model.NewOptions().
+		WithCondition("%s = :parent_id:", Columns.ParentID.Column()).
+		WithParam(":parent_id:", parentID))
+}
+
+// FindVersions returns every version of a document, newest first
+func FindVersions(ctx context.Context, rootID types.UUID) ([]*Document, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindVersions(ctx, rootID)
+	}
+
+	options := model.NewOptions().
+		WithCondition("(%s = :root_id: OR %s = :root_id:)", Columns.ID_.Column(), Columns.RootID.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":root_id:", rootID).
+		WithOrder("%s desc", Columns.Version.Column())
+	options.Limit = constants.SYSTEM_LIMIT
+	return FindAll(ctx, options)
+}
+
+// FindIdentical returns an indexed, current document with the same content the given one could reuse the
+// processing of. Only documents anyone who can see the given one can also see are considered: the same uploader's,
+// or ones shared with the organization when the given one is shared too.
+func FindIdentical(ctx context.Context, documentObj *Document) (*Document, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindIdentical(ctx, documentObj)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("%s = :content_hash:", Columns.ContentHash.Column()).
+		WithCondition("%s <> :id:", Columns.ID_.Column()).
+		WithCondition("%s = :status:", Columns.Status.Column()).
+		WithCondition("%s IS NULL", Columns.SupersededByID.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":organization_id:", documentObj.OrganizationID.Get()).
+		WithParam(":content_hash:", documentObj.ContentHash.Get()).
+		WithParam(":id:", documentObj.ID()).
+		WithParam(":status:", STATUS_INDEXED).
+		WithParam(":account_id:", documentObj.AccountID.Get())
+	if documentObj.Sharing.Get() == SHARING_ORGANIZATION {
+		options.
+			WithCondition("(%s = :account_id: OR %s = :sharing:)", Columns.AccountID.Column(), Columns.Sharing.Column()).
+			WithParam(":sharing:", SHARING_ORGANIZATION)
+	} else {
+		options.WithCondition("%s = :account_id:", Columns.AccountID.Column())
+	}
+	return FindFirst(ctx, options)
+}
+
+// FindVectorSynced returns the organization's documents that have a file in a provider vector store or are
+// indexed and could have one, deleted ones included so their files can be removed
+func FindVectorSynced(ctx context.Context, organizationID types.UUID) ([]*Document, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindVectorSynced(ctx, organizationID)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("(%s->>'file_openai_id' <> '' OR (%s = :status: AND %s = 0))",
+			Columns.MetaData.Column(), Columns.Status.Column(), Columns.Deleted.Column()).
+		WithParam(":organization_id:", organizationID).
+		WithParam(":status:", STATUS_INDEXED)
+	options.Limit = constants.SYSTEM_LIMIT
+	return FindAll(ctx, options)
+}
+
+// FindVectorFileIDs returns every provider file the organization's documents point at, deleted ones included. It is
+// not limited like FindVectorSynced, so a store file missing from it really has no document.
+func FindVectorFileIDs(ctx context.Context, organizationID types.UUID) ([]string, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindVectorFileIDs(ctx, organizationID)
+	}
+
+	rows, err := environment.DB().DB.GetAll(
+		"SELECT DISTINCT meta_data->>'file_openai_id' AS file_openai_id FROM documents "+
+			"WHERE organization_id = :organization_id: AND meta_data->>'file_openai_id' <> ''",
+		map[string]any{":organization_id:": organizationID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	fileIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		fileID, _ := row["file_openai_id"].(string)
+		if fileID != "" {
+			fileIDs = append(fileIDs, fileID)
+		}
+	}
+	return fileIDs, nil
+}
+
+// FindByGroup returns the documents in a group
+func FindByGroup(ctx context.Context, groupID types.UUID) ([]*Document, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindByGroup(ctx, groupID)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :document_group_id:", Columns.DocumentGroupID.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":document_group_id:", groupID)
+	options.Limit = constants.SYSTEM_LIMIT
+	return FindAll(ctx, options)
+}
+
+// FindExpired returns a batch of an organization's documents uploaded more than days ago, oldest first. tagName
+// limits it to documents with that tag, and rawFilesOnly to documents whose uploaded file is still stored.
+func FindExpired(ctx context.Context, organizationID types.UUID, days int64, tagName string, rawFilesOnly bool) ([]*Document, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindExpired(ctx, organizationID, days, tagName, rawFilesOnly)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("%s < NOW() - make_interval(days => :days:)", Columns.CreatedAt.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":organization_id:", organizationID).
+		WithParam(":days:", days).
+		WithOrder("%s asc", Columns.CreatedAt.Column())
+	if tagName != "" {
+		options.
+			WithCondition("%s IN (SELECT object_tags.object_urn FROM object_tags "+
+				"JOIN tags ON tags.id = object_tags.tag_id WHERE lower(tags.name) = lower(:tag_name:))", Columns.URN.Column()).
+			WithParam(":tag_name:", tagName)
+	}
+	if rawFilesOnly {
+		options.WithCondition("%s <> ''", Columns.RawS3URL.Column())
+	}
+	options.Limit = constants.SYSTEM_LIMIT
+	return FindAll(ctx, options)
 }
@@ -7,29 +7,62 @@ import (
 	"github.com/griffnb/core/lib/model/coremodel"
 	"github.com/griffnb/core/lib/sanitize"
 	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
 )
 
+// FindAllRestrictedJoined returns the documents of the session account's organization it can see, those shared
+// with the organization and its own private ones
 func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionUser coremodel.Model) ([]*DocumentJoined, error) {
-	options.WithCondition("%s = :account_id:", Columns.AccountID.Column()).WithParam(":account_id:", sessionUser.ID())
+	addRestriction(options, sessionUser)
+	// older versions are only listed in a document's version history
+	options.WithCondition("%s IS NULL", Columns.SupersededByID.Column())
 	return FindAllJoined(ctx, options)
 }
 
+// FindVersionsRestrictedJoined returns the version history of a document the session account can see, newest first
+func FindVersionsRestrictedJoined(ctx context.Context, rootID types.UUID, sessionUser coremodel.Model) ([]*DocumentJoined, error) {
+	options := model.NewOptions().
+		WithCondition("(%s = :root_id: OR %s = :root_id:)", Columns.ID_.Column(), Columns.RootID.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":root_id:", rootID).
+		WithOrder("%s desc", Columns.Version.Column())
+	options.Limit = constants.SYSTEM_LIMIT
+	addRestriction(options, sessionUser)
+	return FindAllJoined(ctx, options)
+}
+
+// GetRestrictedJoined gets a document of the session account's organization that it can see
 func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionUser coremodel.Model) (*DocumentJoined, error) {
 	options := model.NewOptions().
-		WithCondition("%s = :id: AND %s = :account_id:", Columns.ID_.Column(), Columns.AccountID.Column()).
-		WithParam(":id:", id).
-		WithParam(":account_id:", sessionUser.ID())
+		WithCondition("%s = :id:", Columns.ID_.Column()).
+		WithParam(":id:", id)
+	addRestriction(options, sessionUser)
 
 	return FindFirstJoined(ctx, options)
 }
 
-// NewPublic creates a new model instance with sanitized input and session account context
-// TODO: Add any session-specific initialization
-func NewPublic(data map[string]any, _ coremodel.Model) *Document {
+// addRestriction limits a query to the documents the session account can see, deleted ones are never visible
+func addRestriction(options *model.Options, sessionUser coremodel.Model) {
+	options.
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithCondition("(%s = :sharing: OR %s = :account_id:)", Columns.Sharing.Column(), Columns.AccountID.Column()).
+		WithParam(":organization_id:", sessionUser.GetString("organization_id")).
+		WithParam(":sharing:", SHARING_ORGANIZATION).
+		WithParam(":account_id:", sessionUser.ID())
+}
+
+// NewPublic creates a new document uploaded by the session account to its organization, waiting for its upload
+func NewPublic(data map[string]any, sessionAccount coremodel.Model) *Document {
 	obj := New()
 	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
 	obj.MergeData(data)
-	// obj.AccountID.Set(sessionAccount.ID())
+	obj.OrganizationID.Set(types.UUID(sessionAccount.GetString("organization_id")))
+	obj.AccountID.Set(sessionAccount.ID())
+	if !obj.Sharing.Get().Valid() {
+		obj.Sharing.Set(SHARING_ORGANIZATION)
+	}
+	obj.Status.Set(STATUS_PENDING)
 	return obj
 }
 
@@ -0,0 +1,67 @@
+package document_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/testtools"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/account"
+	testmodel "github.com/griffnb/techboss-ai-go/internal/models/document"
+)
+
+func TestRestrictedExcludesDeleted(t *testing.T) {
+	ctx := context.Background()
+	organizationID := types.UUID(tools.GUID())
+
+	sessionUser := account.New()
+	sessionUser.ID_.Set(types.UUID(tools.GUID()))
+	sessionUser.OrganizationID.Set(organizationID)
+
+	visible := testmodel.New()
+	visible.OrganizationID.Set(organizationID)
+	visible.AccountID.Set(sessionUser.ID())
+	visible.Sharing.Set(testmodel.SHARING_ORGANIZATION)
+	err := visible.Save(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testtools.CleanupModel(visible)
+
+	deleted := testmodel.New()
+	deleted.OrganizationID.Set(organizationID)
+	deleted.AccountID.Set(sessionUser.ID())
+	deleted.Sharing.Set(testmodel.SHARING_ORGANIZATION)
+	deleted.Deleted.Set(1)
+	err = deleted.Save(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testtools.CleanupModel(deleted)
+
+	found, err := testmodel.GetRestrictedJoined(ctx, deleted.ID(), sessionUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tools.Empty(found) {
+		t.Errorf("expected the deleted document to be hidden, got %s", found.ID())
+	}
+
+	found, err = testmodel.GetRestrictedJoined(ctx, visible.ID(), sessionUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tools.Empty(found) {
+		t.Error("expected the visible document to be found")
+	}
+
+	objs, err := testmodel.FindAllRestrictedJoined(ctx, model.NewOptions(), sessionUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 1 || objs[0].ID() != visible.ID() {
+		t.Errorf("expected only the visible document, got %d documents", len(objs))
+	}
+}
@@ -0,0 +1,36 @@
+package document
+
+import (
+	"slices"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+)
+
+// Sharing controls who in the organization can see a document
+type Sharing int
+
+const (
+	SHARING_ORGANIZATION Sharing = iota + 1 // everyone in the organization
+	SHARING_PRIVATE                         // only the uploader
+)
+
+// SHARING_LEVELS are the sharing levels a document can be set to
+var SHARING_LEVELS = []Sharing{
+	SHARING_ORGANIZATION,
+	SHARING_PRIVATE,
+}
+
+// Valid reports whether the level is one of SHARING_LEVELS
+func (this Sharing) Valid() bool {
+	return slices.Contains(SHARING_LEVELS, this)
+}
+
+// EditableBy reports whether an account may rename, retag, reshare or delete the document. The uploader always
+// can, organization admins can for documents shared with the organization.
+func (this *Document) EditableBy(accountID types.UUID, role constants.Role) bool {
+	if this.AccountID.Get() == accountID {
+		return true
+	}
+	return this.Sharing.Get() == SHARING_ORGANIZATION && role >= constants.ROLE_ORG_ADMIN
+}
@@ -0,0 +1,37 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+)
+
+func TestDocument_EditableBy(t *testing.T) {
+	uploader := types.UUID("0b8a7c1e-4f7d-4c36-9d7e-3f1a2b3c4d5e")
+	other := types.UUID("7d9e0f1a-2b3c-4d5e-8f9a-0b1c2d3e4f5a")
+
+	tests := []struct {
+		name    string
+		sharing Sharing
+		account types.UUID
+		role    constants.Role
+		want    bool
+	}{
+		{"uploader of a private document", SHARING_PRIVATE, uploader, constants.ROLE_USER, true},
+		{"member of a shared document", SHARING_ORGANIZATION, other, constants.ROLE_USER, false},
+		{"admin of a shared document", SHARING_ORGANIZATION, other, constants.ROLE_ORG_ADMIN, true},
+		{"admin of a private document", SHARING_PRIVATE, other, constants.ROLE_ORG_OWNER, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := New()
+			obj.AccountID.Set(uploader)
+			obj.Sharing.Set(tt.sharing)
+			if got := obj.EditableBy(tt.account, tt.role); got != tt.want {
+				t.Errorf("EditableBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
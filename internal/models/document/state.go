@@ -0,0 +1,60 @@
+package document
+
+import (
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/pkg/errors"
+)
+
+// MAX_ERROR_REASON_LENGTH keeps provider and parser messages from bloating the row
+const MAX_ERROR_REASON_LENGTH = 1000
+
+// transitions lists the pipeline statuses each status may move to
+var transitions = map[constants.Status][]constants.Status{
+	STATUS_PENDING:   {STATUS_RAW, STATUS_ERROR},
+	STATUS_RAW:       {STATUS_PROCESSED, STATUS_INDEXED, STATUS_ERROR}, // sitemaps are indexed through their pages
+	STATUS_PROCESSED: {STATUS_INDEXED, STATUS_ERROR},
+	STATUS_INDEXED:   {STATUS_RAW, STATUS_PROCESSED}, // reprocessed or replaced with new content
+	STATUS_ERROR:     {STATUS_RAW, STATUS_PROCESSED},
+}
+
+// CanTransition reports whether the pipeline may move a document from one status to another
+func CanTransition(from constants.Status, to constants.Status) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the document to the given status, clearing any previous error
+func (this *Document) Transition(to constants.Status) error {
+	from := this.Status.Get()
+	if !CanTransition(from, to) {
+		return errors.Errorf("document %s cannot move from status %d to %d", this.ID(), from, to)
+	}
+
+	this.Status.Set(to)
+	if to != STATUS_ERROR {
+		this.ErrorReason.Set("")
+	}
+	return nil
+}
+
+// Fail moves the document to the error status and records why
+func (this *Document) Fail(reason string) {
+	if len(reason) > MAX_ERROR_REASON_LENGTH {
+		reason = reason[:MAX_ERROR_REASON_LENGTH]
+	}
+	this.Status.Set(STATUS_ERROR)
+	this.ErrorReason.Set(reason)
+}
+
+// RetryStatus returns the status a failed document restarts from, skipping extraction when the processed
+// artifact was already written
+func (this *Document) RetryStatus() constants.Status {
+	if this.ProcessedS3URL.Get() != "" {
+		return STATUS_PROCESSED
+	}
+	return STATUS_RAW
+}
@@ -1,11 +1,11 @@
 package document
 
 const (
-	STATUS_PENDING   = 1
-	STATUS_RAW       = 100
-	STATUS_PROCESSED = 101
+	STATUS_PENDING   = 1   // created, waiting for the raw file to be uploaded
+	STATUS_RAW       = 100 // raw file uploaded, waiting for text extraction
+	STATUS_PROCESSED = 101 // text extracted and chunked, waiting to be indexed
 	STATUS_INDEXED   = 102
-	STATUS_ERROR     = 103
+	STATUS_ERROR     = 103 // see ErrorReason
 	STATUS_DISABLED  = 200
 	STATUS_DELETED   = 300
 )
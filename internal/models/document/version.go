@@ -0,0 +1,20 @@
+package document
+
+import (
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+)
+
+// VersionRootID identifies the version history the document belongs to, the id of its first version
+func (this *Document) VersionRootID() types.UUID {
+	if !tools.Empty(this.RootID.Get()) {
+		return this.RootID.Get()
+	}
+	return this.ID()
+}
+
+// IsCurrent reports whether the document is the latest indexed version, older versions are kept for download
+// but are not searched
+func (this *Document) IsCurrent() bool {
+	return tools.Empty(this.SupersededByID.Get())
+}
@@ -0,0 +1,76 @@
+//go:generate core_gen model DocumentChunk
+
+package document_chunk
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/document_chunk/migrations"
+)
+
+const (
+	TABLE string = "document_chunks"
+
+	IS_VERSIONED = false
+	CLIENT       = environment.CLIENT_DEFAULT
+	// Chunks are rebuilt whenever their document is indexed
+	CHANGE_LOGS = false
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	OrganizationID  *fields.UUIDField   `public:"view" column:"organization_id"   type:"uuid"    default:"null" null:"true" index:"true"`
+	DocumentID      *fields.UUIDField   `public:"view" column:"document_id"       type:"uuid"    default:"null" null:"true" index:"true"`
+	DocumentGroupID *fields.UUIDField   `public:"view" column:"document_group_id" type:"uuid"    default:"null" null:"true" index:"true"`
+	ChunkIndex      *fields.IntField    `public:"view" column:"chunk_index"       type:"integer" default:"0"`
+	Content         *fields.StringField `public:"view" column:"content"           type:"text"    default:""`
+	StartOffset     *fields.IntField    `public:"view" column:"start_offset"      type:"integer" default:"0"`
+	EndOffset       *fields.IntField    `public:"view" column:"end_offset"        type:"integer" default:"0"`
+	Page            *fields.IntField    `public:"view" column:"page"              type:"integer" default:"0"`
+	Heading         *fields.StringField `public:"view" column:"heading"           type:"text"    default:""`
+	EmbeddingModel  *fields.StringField `public:"view" column:"embedding_model"   type:"text"    default:""                 index:"true"`
+	Embedding       *fields.StringField `              column:"embedding"         type:"vector"  default:"null" null:"true"`
+}
+
+type JoinData struct {
+	DocumentName *fields.StringField `public:"view" json:"document_name" type:"text"`
+}
+
+type DocumentChunk struct {
+	model.BaseModel
+	DBColumns
+}
+
+type DocumentChunkJoined struct {
+	DocumentChunk
+	JoinData
+}
+
+func (this *DocumentChunk) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *DocumentChunk) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
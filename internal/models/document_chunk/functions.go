@@ -0,0 +1,60 @@
+package document_chunk
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+// DeleteByDocument removes a document's chunks, done before it is indexed again and when it is deleted
+func DeleteByDocument(ctx context.Context, documentID types.UUID) error {
+	return environment.DB().DB.InsertWithContext(ctx,
+		"DELETE FROM document_chunks WHERE document_id = :document_id:",
+		map[string]any{":document_id:": documentID},
+	)
+}
+
+// SetGroupByDocument moves a document's chunks into a group, an empty group takes them out of any group
+func SetGroupByDocument(ctx context.Context, documentID types.UUID, groupID types.UUID) error {
+	if tools.Empty(groupID) {
+		return environment.DB().DB.InsertWithContext(ctx,
+			"UPDATE document_chunks SET document_group_id = NULL WHERE document_id = :document_id:",
+			map[string]any{":document_id:": documentID},
+		)
+	}
+	return environment.DB().DB.InsertWithContext(ctx,
+		"UPDATE document_chunks SET document_group_id = :document_group_id: WHERE document_id = :document_id:",
+		map[string]any{":document_id:": documentID, ":document_group_id:": groupID},
+	)
+}
+
+// ClearGroup takes every chunk out of a group that is being deleted
+func ClearGroup(ctx context.Context, groupID types.UUID) error {
+	return environment.DB().DB.InsertWithContext(ctx,
+		"UPDATE document_chunks SET document_group_id = NULL WHERE document_group_id = :document_group_id:",
+		map[string]any{":document_group_id:": groupID},
+	)
+}
+
+// SetEmbedding stores a vector in the embedding column
+func (this *DocumentChunk) SetEmbedding(vector []float32) {
+	this.Embedding.Set(FormatVector(vector))
+}
+
+// FormatVector writes a vector in the pgvector text format, [1,2,3]
+func FormatVector(vector []float32) string {
+	builder := strings.Builder{}
+	builder.WriteString("[")
+	for i, value := range vector {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(strconv.FormatFloat(float64(value), 'f', -1, 32))
+	}
+	builder.WriteString("]")
+	return builder.String()
+}
@@ -0,0 +1,15 @@
+package document_chunk
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{
+		"LEFT JOIN documents ON documents.id = document_chunks.document_id",
+	}...)
+	options.WithIncludeFields([]string{
+		"documents.name AS document_name",
+	}...)
+}
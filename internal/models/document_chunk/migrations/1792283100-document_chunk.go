@@ -0,0 +1,73 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "document_chunks"
+
+func init() {
+	// The embedding column has no fixed dimensions so models can change, searches always filter by embedding_model.
+	// HNSW needs a fixed dimension, so each model searched in production gets a partial index on the column cast to
+	// its dimension, see document_chunk.INDEXED_DIMENSIONS. Models without one fall back to a scan.
+	model.AddMigration(&model.Migration{
+		ID:          1792283100,
+		Table:       TABLE,
+		TableStruct: &DocumentChunkV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+
+	model.AddMigration(&model.Migration{
+		ID:    1792283101,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			CREATE INDEX IF NOT EXISTS document_chunks_embedding_openai_1536_idx ON document_chunks
+				USING hnsw ((embedding::vector(1536)) vector_cosine_ops)
+				WHERE embedding_model = 'openai/text-embedding-3-small/1536'
+			`, map[string]interface{}{})
+		},
+	})
+
+	// Generated from content for keyword search, so it is not one of the model's columns
+	model.AddMigration(&model.Migration{
+		ID:    1792283200,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS content_tsv tsvector
+				GENERATED ALWAYS AS (to_tsvector('english', content)) STORED;
+			CREATE INDEX IF NOT EXISTS document_chunks_content_tsv_idx ON document_chunks USING GIN (content_tsv)
+			`, map[string]interface{}{})
+		},
+	})
+
+	model.AddMigration(&model.Migration{
+		ID:    1792283700,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS page integer DEFAULT 0;
+			ALTER TABLE document_chunks ADD COLUMN IF NOT EXISTS heading text DEFAULT ''
+			`, map[string]interface{}{})
+		},
+	})
+}
+
+type DocumentChunkV1 struct {
+	base.Structure
+	OrganizationID  *fields.UUIDField   `column:"organization_id"   type:"uuid"    default:"null" null:"true" index:"true"`
+	DocumentID      *fields.UUIDField   `column:"document_id"       type:"uuid"    default:"null" null:"true" index:"true"`
+	DocumentGroupID *fields.UUIDField   `column:"document_group_id" type:"uuid"    default:"null" null:"true" index:"true"`
+	ChunkIndex      *fields.IntField    `column:"chunk_index"       type:"integer" default:"0"`
+	Content         *fields.StringField `column:"content"           type:"text"    default:""`
+	StartOffset     *fields.IntField    `column:"start_offset"      type:"integer" default:"0"`
+	EndOffset       *fields.IntField    `column:"end_offset"        type:"integer" default:"0"`
+	EmbeddingModel  *fields.StringField `column:"embedding_model"   type:"text"    default:""                 index:"true"`
+	Embedding       *fields.StringField `column:"embedding"         type:"vector"  default:"null" null:"true"`
+}
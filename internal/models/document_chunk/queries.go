@@ -0,0 +1,223 @@
+package document_chunk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*DocumentChunk, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*DocumentChunkJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*DocumentChunk, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*DocumentChunkJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*DocumentChunk, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*DocumentChunkJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	FindByDocument func(ctx context.Context, documentID types.UUID) ([]*DocumentChunk, error)
+	FindSimilar    func(ctx context.Context, query *SimilarityQuery) ([]*ChunkMatch, error)
+	FindKeyword    func(ctx context.Context, query *KeywordQuery) ([]*ChunkMatch, error)
+}
+
+// ChunkScope limits a search to one organization's chunks and optionally to groups and tags
+type ChunkScope struct {
+	OrganizationID   types.UUID
+	AccountID        types.UUID   // optional, the searcher whose own private documents are included
+	DocumentGroupIDs []types.UUID // optional, limits the search to these groups
+	TagIDs           []types.UUID // optional, limits the search to documents with any of these tags
+	Limit            int
+}
+
+// SimilarityQuery is a nearest neighbour search
+type SimilarityQuery struct {
+	ChunkScope
+	// EmbeddingModel must be the model the query was embedded with, vectors of other models are not comparable
+	EmbeddingModel string
+	Embedding      []float32
+}
+
+// KeywordQuery is a full text search, Query takes web search syntax, "quoted phrases", or and -excluded words
+type KeywordQuery struct {
+	ChunkScope
+	Query string
+}
+
+// ChunkMatch is a search hit. Score is the cosine similarity for similarity searches and the text rank for keyword
+// searches, the two are not comparable.
+type ChunkMatch struct {
+	ID           types.UUID `json:"id"`
+	DocumentID   types.UUID `json:"document_id"`
+	DocumentName string     `json:"document_name"`
+	ChunkIndex   int        `json:"chunk_index"`
+	Content      string     `json:"content"`
+	StartOffset  int        `json:"start_offset"`
+	EndOffset    int        `json:"end_offset"`
+	Page         int        `json:"page,omitempty"`
+	Heading      string     `json:"heading,omitempty"`
+	Score        float64    `json:"score"`
+}
+
+// FindByDocument returns a document's chunks in order
+func FindByDocument(ctx context.Context, documentID types.UUID) ([]*DocumentChunk, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindByDocument(ctx, documentID)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :document_id:", Columns.DocumentID.Column()).
+		WithParam(":document_id:", documentID).
+		WithOrder("%s asc", Columns.ChunkIndex.Column())
+	options.Limit = constants.SYSTEM_LIMIT
+	return FindAll(ctx, options)
+}
+
+// INDEXED_DIMENSIONS are the embedding models with an HNSW index, keyed to the dimension the index casts to.
+// The search has to cast the same way for the planner to use the index.
+var INDEXED_DIMENSIONS = map[string]int{
+	"openai/text-embedding-3-small/1536": 1536,
+}
+
+// vectorType is the type embeddings of a model are compared as, sized when the model has an index
+func vectorType(embeddingModel string) string {
+	dimensions, ok := INDEXED_DIMENSIONS[embeddingModel]
+	if !ok {
+		return "vector"
+	}
+	return fmt.Sprintf("vector(%d)", dimensions)
+}
+
+// FindSimilar returns the chunks closest to the query embedding by cosine distance, best first
+func FindSimilar(ctx context.Context, query *SimilarityQuery) ([]*ChunkMatch, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindSimilar(ctx, query)
+	}
+
+	conditions, params := scopeConditions(&query.ChunkScope)
+	conditions = append(conditions,
+		"document_chunks.embedding_model = :embedding_model:",
+		"document_chunks.embedding IS NOT NULL",
+	)
+	params[":embedding_model:"] = query.EmbeddingModel
+	params[":embedding:"] = FormatVector(query.Embedding)
+
+	vector := vectorType(query.EmbeddingModel)
+	distance := fmt.Sprintf("CAST(document_chunks.embedding AS %s) <=> CAST(:embedding: AS %s)", vector, vector)
+
+	return findMatches(
+		fmt.Sprintf("(1 - (%s))::float8", distance),
+		conditions,
+		distance,
+		params,
+	)
+}
+
+// FindKeyword returns the chunks matching a full text query, best ranked first
+func FindKeyword(ctx context.Context, query *KeywordQuery) ([]*ChunkMatch, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindKeyword(ctx, query)
+	}
+
+	conditions, params := scopeConditions(&query.ChunkScope)
+	conditions = append(conditions, "document_chunks.content_tsv @@ websearch_to_tsquery('english', :query:)")
+	params[":query:"] = query.Query
+
+	return findMatches(
+		"ts_rank_cd(document_chunks.content_tsv, websearch_to_tsquery('english', :query:))::float8",
+		conditions,
+		"score DESC",
+		params,
+	)
+}
+
+// scopeConditions builds the conditions shared by every chunk search. Chunks of deleted, disabled or replaced
+// documents, and of documents kept private by someone other than the searcher, are never returned.
+func scopeConditions(scope *ChunkScope) ([]string, map[string]any) {
+	limit := scope.Limit
+	if limit <= 0 || limit > constants.SYSTEM_LIMIT {
+		limit = constants.SYSTEM_LIMIT
+	}
+
+	conditions := []string{
+		"document_chunks.organization_id = :organization_id:",
+		"document_chunks.deleted = 0",
+		"documents.deleted = 0",
+		"documents.disabled = 0",
+		"documents.superseded_by_id IS NULL",
+	}
+	params := map[string]any{
+		":organization_id:": scope.OrganizationID,
+		":sharing:":         document.SHARING_ORGANIZATION,
+		":limit:":           limit,
+	}
+	if tools.Empty(scope.AccountID) {
+		conditions = append(conditions, "documents.sharing = :sharing:")
+	} else {
+		conditions = append(conditions, "(documents.sharing = :sharing: OR documents.account_id = :account_id:)")
+		params[":account_id:"] = scope.AccountID
+	}
+	if len(scope.DocumentGroupIDs) > 0 {
+		conditions = append(conditions, "document_chunks.document_group_id IN (:document_group_ids:)")
+		params[":document_group_ids:"] = scope.DocumentGroupIDs
+	}
+	if len(scope.TagIDs) > 0 {
+		conditions = append(conditions,
+			"documents.urn IN (SELECT object_tags.object_urn FROM object_tags WHERE object_tags.tag_id IN (:tag_ids:))")
+		params[":tag_ids:"] = scope.TagIDs
+	}
+	return conditions, params
+}
+
+// findMatches runs a chunk search, score is the expression selected as the third column
+func findMatches(score string, conditions []string, order string, params map[string]any) ([]*ChunkMatch, error) {
+	rows, err := environment.DB().DB.GetAll(fmt.Sprintf(`
+	SELECT
+		document_chunks.id::text AS id,
+		document_chunks.document_id::text AS document_id,
+		%s AS score,
+		documents.name AS document_name,
+		document_chunks.chunk_index::bigint AS chunk_index,
+		document_chunks.content AS content,
+		document_chunks.start_offset::bigint AS start_offset,
+		document_chunks.end_offset::bigint AS end_offset,
+		document_chunks.page::bigint AS page,
+		document_chunks.heading AS heading
+	FROM document_chunks
+	JOIN documents ON documents.id = document_chunks.document_id
+	WHERE %s
+	ORDER BY %s
+	LIMIT :limit:
+	`, score, strings.Join(conditions, " AND "), order), params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ChunkMatch, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, &ChunkMatch{
+			ID:           types.UUID(common.RowString(row, "id")),
+			DocumentID:   types.UUID(common.RowString(row, "document_id")),
+			DocumentName: common.RowString(row, "document_name"),
+			ChunkIndex:   int(common.RowInt(row, "chunk_index")),
+			Content:      common.RowString(row, "content"),
+			StartOffset:  int(common.RowInt(row, "start_offset")),
+			EndOffset:    int(common.RowInt(row, "end_offset")),
+			Page:         int(common.RowInt(row, "page")),
+			Heading:      common.RowString(row, "heading"),
+			Score:        common.RowFloat(row, "score"),
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,67 @@
+//go:generate core_gen model DocumentGroup
+
+package document_group
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/document_group/migrations"
+)
+
+const (
+	TABLE        string = "document_groups"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	OrganizationID *fields.UUIDField                   `public:"view" column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	Name           *fields.StringField                 `public:"edit" column:"name"            type:"text"     default:""`
+	Description    *fields.StringField                 `public:"edit" column:"description"     type:"text"     default:""`
+	GroupType      *fields.IntConstantField[GroupType] `public:"edit" column:"group_type"      type:"smallint" default:"0"                index:"true"`
+}
+
+type JoinData struct {
+	DocumentCount *fields.IntField `public:"view" json:"document_count" type:"integer"`
+}
+
+type DocumentGroup struct {
+	model.BaseModel
+	DBColumns
+}
+
+type DocumentGroupJoined struct {
+	DocumentGroup
+	JoinData
+}
+
+func (this *DocumentGroup) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *DocumentGroup) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
@@ -0,0 +1,23 @@
+package document_group
+
+import "slices"
+
+type GroupType int
+
+const (
+	GROUP_TYPE_KNOWLEDGE_BASE GroupType = iota + 1
+	GROUP_TYPE_POLICY
+	GROUP_TYPE_PRODUCT_CATALOG
+)
+
+// GROUP_TYPES are the types a group can be created with
+var GROUP_TYPES = []GroupType{
+	GROUP_TYPE_KNOWLEDGE_BASE,
+	GROUP_TYPE_POLICY,
+	GROUP_TYPE_PRODUCT_CATALOG,
+}
+
+// Valid reports whether the type is one of GROUP_TYPES
+func (this GroupType) Valid() bool {
+	return slices.Contains(GROUP_TYPES, this)
+}
@@ -0,0 +1,12 @@
+package document_group
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithIncludeFields([]string{
+		"(SELECT count(*) FROM documents WHERE documents.document_group_id = document_groups.id AND documents.deleted = 0) AS document_count",
+	}...)
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "document_groups"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792283300,
+		Table:       TABLE,
+		TableStruct: &DocumentGroupV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type DocumentGroupV1 struct {
+	base.Structure
+	OrganizationID *fields.UUIDField             `column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	Name           *fields.StringField           `column:"name"            type:"text"     default:""`
+	Description    *fields.StringField           `column:"description"     type:"text"     default:""`
+	GroupType      *fields.IntConstantField[int] `column:"group_type"      type:"smallint" default:"0"                index:"true"`
+}
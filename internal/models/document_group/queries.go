@@ -0,0 +1,39 @@
+package document_group
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*DocumentGroup, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*DocumentGroupJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*DocumentGroup, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*DocumentGroupJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*DocumentGroup, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*DocumentGroupJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	FindByTypes func(ctx context.Context, organizationID types.UUID, groupTypes []GroupType) ([]*DocumentGroup, error)
+}
+
+// FindByTypes returns an organization's groups of the given types
+func FindByTypes(ctx context.Context, organizationID types.UUID, groupTypes []GroupType) ([]*DocumentGroup, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindByTypes(ctx, organizationID, groupTypes)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("%s IN (:group_types:)", Columns.GroupType.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":organization_id:", organizationID).
+		WithParam(":group_types:", groupTypes)
+	options.Limit = constants.SYSTEM_LIMIT
+	return FindAll(ctx, options)
+}
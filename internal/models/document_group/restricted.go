@@ -0,0 +1,44 @@
+package document_group
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/sanitize"
+	"github.com/griffnb/core/lib/types"
+)
+
+// FindAllRestrictedJoined returns the groups of the session account's organization that have not been deleted
+func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionAccount coremodel.Model) ([]*DocumentGroupJoined, error) {
+	options.WithCondition("%s = :organization_id:", Columns.OrganizationID.Column())
+	options.WithCondition("%s.deleted = 0", TABLE)
+	options.WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+	return FindAllJoined(ctx, options)
+}
+
+// GetRestrictedJoined gets a group belonging to the session account's organization, deleted groups are not found
+func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionAccount coremodel.Model) (*DocumentGroupJoined, error) {
+	options := model.NewOptions().
+		WithCondition("%s.id = :id:", TABLE).
+		WithParam(":id:", id).
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithParam(":organization_id:", sessionAccount.GetString("organization_id")).
+		WithCondition("%s.deleted = 0", TABLE)
+
+	return FindFirstJoined(ctx, options)
+}
+
+// NewPublic creates a new group for the session account's organization from sanitized input
+func NewPublic(data map[string]any, sessionAccount coremodel.Model) *DocumentGroup {
+	obj := New()
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+	obj.OrganizationID.Set(types.UUID(sessionAccount.GetString("organization_id")))
+	return obj
+}
+
+func UpdatePublic(obj *DocumentGroup, data map[string]any, _ coremodel.Model) {
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+}
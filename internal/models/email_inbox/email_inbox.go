@@ -0,0 +1,70 @@
+//go:generate core_gen model EmailInbox
+
+package email_inbox
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/email_inbox/migrations"
+)
+
+const (
+	TABLE        string = "email_inboxes"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	OrganizationID  *fields.UUIDField   `public:"view" column:"organization_id"  type:"uuid"     default:"null" null:"true" index:"true"`
+	AgentID         *fields.UUIDField   `public:"edit" column:"agent_id"         type:"uuid"     default:"null" null:"true" index:"true"`
+	Address         *fields.StringField `public:"edit" column:"address"          type:"text"     default:""                 index:"true"`
+	Name            *fields.StringField `public:"edit" column:"name"             type:"text"     default:""`
+	RequireApproval *fields.IntField    `public:"edit" column:"require_approval" type:"smallint" default:"1"`
+}
+
+type JoinData struct {
+	OrganizationName *fields.StringField `public:"view" json:"organization_name" type:"text"`
+	AgentName        *fields.StringField `public:"view" json:"agent_name"        type:"text"`
+	BillingPlanLevel *fields.IntField    `              json:"billing_plan_level" type:"smallint"`
+}
+
+type EmailInbox struct {
+	model.BaseModel
+	DBColumns
+}
+
+type EmailInboxJoined struct {
+	EmailInbox
+	JoinData
+}
+
+func (this *EmailInbox) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *EmailInbox) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
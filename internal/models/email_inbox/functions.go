@@ -0,0 +1,20 @@
+package email_inbox
+
+import (
+	"strings"
+)
+
+// NormalizeAddress lowercases and trims a mailbox address so lookups match regardless of how it was typed
+func NormalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// IsActive reports whether mail to the inbox should be answered
+func (this *EmailInbox) IsActive() bool {
+	return this.Status.Get() == STATUS_ACTIVE && this.Disabled.Get() == 0 && this.Deleted.Get() == 0
+}
+
+// NeedsApproval reports whether replies are held as drafts for a person to approve
+func (this *EmailInbox) NeedsApproval() bool {
+	return this.RequireApproval.Get() == 1
+}
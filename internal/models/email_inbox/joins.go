@@ -0,0 +1,20 @@
+package email_inbox
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{
+		"LEFT JOIN organizations ON organizations.id = email_inboxes.organization_id",
+		"LEFT JOIN agents ON agents.id = email_inboxes.agent_id",
+		"LEFT JOIN billing_plan_prices ON billing_plan_prices.id = organizations.billing_plan_price_id",
+		"LEFT JOIN billing_plans ON billing_plans.id = billing_plan_prices.billing_plan_id",
+	}...)
+	options.WithIncludeFields([]string{
+		"organizations.name AS organization_name",
+		"agents.name AS agent_name",
+		"billing_plans.level AS billing_plan_level",
+	}...)
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "email_inboxes"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792282600,
+		Table:       TABLE,
+		TableStruct: &EmailInboxV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type EmailInboxV1 struct {
+	base.Structure
+	OrganizationID  *fields.UUIDField   `column:"organization_id"  type:"uuid"     default:"null" null:"true" index:"true"`
+	AgentID         *fields.UUIDField   `column:"agent_id"         type:"uuid"     default:"null" null:"true" index:"true"`
+	Address         *fields.StringField `column:"address"          type:"text"     default:""                 index:"true"`
+	Name            *fields.StringField `column:"name"             type:"text"     default:""`
+	RequireApproval *fields.IntField    `column:"require_approval" type:"smallint" default:"1"`
+}
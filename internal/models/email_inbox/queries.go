@@ -0,0 +1,34 @@
+package email_inbox
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*EmailInbox, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*EmailInboxJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*EmailInbox, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*EmailInboxJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*EmailInbox, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*EmailInboxJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetByAddress func(ctx context.Context, address string) (*EmailInboxJoined, error)
+}
+
+// GetByAddress returns the inbox receiving mail for an address, nil when no organization has claimed it
+func GetByAddress(ctx context.Context, address string) (*EmailInboxJoined, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetByAddress(ctx, address)
+	}
+
+	return FindFirstJoined(ctx, model.NewOptions().
+		WithCondition("%s = :address:", Columns.Address.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":address:", NormalizeAddress(address)))
+}
@@ -0,0 +1,45 @@
+package email_inbox
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/sanitize"
+	"github.com/griffnb/core/lib/types"
+)
+
+// FindAllRestrictedJoined returns the inboxes of the session account's organization
+func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionAccount coremodel.Model) ([]*EmailInboxJoined, error) {
+	options.WithCondition("%s = :organization_id:", Columns.OrganizationID.Column())
+	options.WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+	return FindAllJoined(ctx, options)
+}
+
+// GetRestrictedJoined gets an inbox belonging to the session account's organization
+func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionAccount coremodel.Model) (*EmailInboxJoined, error) {
+	options := model.NewOptions().
+		WithCondition("%s.id = :id:", TABLE).
+		WithParam(":id:", id).
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+
+	return FindFirstJoined(ctx, options)
+}
+
+// NewPublic creates a new inbox for the session account's organization from sanitized input
+func NewPublic(data map[string]any, sessionAccount coremodel.Model) *EmailInbox {
+	obj := New()
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+	obj.OrganizationID.Set(types.UUID(sessionAccount.GetString("organization_id")))
+	obj.Address.Set(NormalizeAddress(obj.Address.Get()))
+	obj.Status.Set(STATUS_ACTIVE)
+	return obj
+}
+
+func UpdatePublic(obj *EmailInbox, data map[string]any, _ coremodel.Model) {
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+	obj.Address.Set(NormalizeAddress(obj.Address.Get()))
+}
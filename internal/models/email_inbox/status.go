@@ -0,0 +1,7 @@
+package email_inbox
+
+const (
+	STATUS_ACTIVE   = 1
+	STATUS_DISABLED = 200
+	STATUS_DELETED  = 300
+)
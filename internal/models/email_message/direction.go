@@ -0,0 +1,9 @@
+package email_message
+
+// Direction is whether a message was received by the inbox or written by its agent
+type Direction int
+
+const (
+	DIRECTION_INBOUND Direction = iota + 1
+	DIRECTION_OUTBOUND
+)
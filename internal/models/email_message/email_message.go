@@ -0,0 +1,77 @@
+//go:generate core_gen model EmailMessage
+
+package email_message
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/email_message/migrations"
+)
+
+const (
+	TABLE        string = "email_messages"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	OrganizationID *fields.UUIDField                   `public:"view" column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	InboxID        *fields.UUIDField                   `public:"view" column:"inbox_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	ConversationID *fields.UUIDField                   `public:"view" column:"conversation_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	Direction      *fields.IntConstantField[Direction] `public:"view" column:"direction"       type:"smallint" default:"0"`
+	MessageID      *fields.StringField                 `public:"view" column:"message_id"      type:"text"     default:""                 index:"true"`
+	InReplyTo      *fields.StringField                 `public:"view" column:"in_reply_to"     type:"text"     default:""`
+	FromAddress    *fields.StringField                 `public:"view" column:"from_address"    type:"text"     default:""`
+	ToAddress      *fields.StringField                 `public:"view" column:"to_address"      type:"text"     default:""`
+	Subject        *fields.StringField                 `public:"edit" column:"subject"         type:"text"     default:""`
+	Body           *fields.StringField                 `public:"edit" column:"body"            type:"text"     default:""`
+	ApprovedByID   *fields.UUIDField                   `public:"view" column:"approved_by_id"  type:"uuid"     default:"null" null:"true"`
+	SentAtTS       *fields.IntField                    `public:"view" column:"sent_at_ts"      type:"bigint"   default:"0"`
+	Error          *fields.StringField                 `public:"view" column:"error"           type:"text"     default:""`
+}
+
+type JoinData struct {
+	InboxAddress *fields.StringField `public:"view" json:"inbox_address" type:"text"`
+	InboxName    *fields.StringField `public:"view" json:"inbox_name"    type:"text"`
+}
+
+type EmailMessage struct {
+	model.BaseModel
+	DBColumns
+}
+
+type EmailMessageJoined struct {
+	EmailMessage
+	JoinData
+}
+
+func (this *EmailMessage) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *EmailMessage) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
@@ -0,0 +1,7 @@
+package email_message
+
+// CanSend reports whether the message is an agent reply that has not gone out yet
+func (this *EmailMessage) CanSend() bool {
+	return this.Direction.Get() == DIRECTION_OUTBOUND &&
+		(this.Status.Get() == STATUS_DRAFT || this.Status.Get() == STATUS_FAILED)
+}
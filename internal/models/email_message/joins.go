@@ -0,0 +1,16 @@
+package email_message
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{
+		"LEFT JOIN email_inboxes ON email_inboxes.id = email_messages.inbox_id",
+	}...)
+	options.WithIncludeFields([]string{
+		"email_inboxes.address AS inbox_address",
+		"email_inboxes.name AS inbox_name",
+	}...)
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "email_messages"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792282700,
+		Table:       TABLE,
+		TableStruct: &EmailMessageV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type EmailMessageV1 struct {
+	base.Structure
+	OrganizationID *fields.UUIDField             `column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	InboxID        *fields.UUIDField             `column:"inbox_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	ConversationID *fields.UUIDField             `column:"conversation_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	Direction      *fields.IntConstantField[int] `column:"direction"       type:"smallint" default:"0"`
+	MessageID      *fields.StringField           `column:"message_id"      type:"text"     default:""                 index:"true"`
+	InReplyTo      *fields.StringField           `column:"in_reply_to"     type:"text"     default:""`
+	FromAddress    *fields.StringField           `column:"from_address"    type:"text"     default:""`
+	ToAddress      *fields.StringField           `column:"to_address"      type:"text"     default:""`
+	Subject        *fields.StringField           `column:"subject"         type:"text"     default:""`
+	Body           *fields.StringField           `column:"body"            type:"text"     default:""`
+	ApprovedByID   *fields.UUIDField             `column:"approved_by_id"  type:"uuid"     default:"null" null:"true"`
+	SentAtTS       *fields.IntField              `column:"sent_at_ts"      type:"bigint"   default:"0"`
+	Error          *fields.StringField           `column:"error"           type:"text"     default:""`
+}
@@ -0,0 +1,74 @@
+package email_message
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*EmailMessage, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*EmailMessageJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*EmailMessage, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*EmailMessageJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*EmailMessage, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*EmailMessageJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetInbound            func(ctx context.Context, inboxID types.UUID, messageID string) (*EmailMessage, error)
+	FindFirstByMessageIDs func(ctx context.Context, inboxID types.UUID, messageIDs []string) (*EmailMessage, error)
+	GetLatestInbound      func(ctx context.Context, conversationID types.UUID) (*EmailMessage, error)
+}
+
+// GetInbound returns a message the inbox already received, used to drop redelivered mail
+func GetInbound(ctx context.Context, inboxID types.UUID, messageID string) (*EmailMessage, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetInbound(ctx, inboxID, messageID)
+	}
+
+	return FindFirst(ctx, model.NewOptions().
+		WithCondition("%s = :inbox_id:", Columns.InboxID.Column()).
+		WithCondition("%s = :message_id:", Columns.MessageID.Column()).
+		WithCondition("%s = :direction:", Columns.Direction.Column()).
+		WithParam(":inbox_id:", inboxID).
+		WithParam(":message_id:", messageID).
+		WithParam(":direction:", DIRECTION_INBOUND))
+}
+
+// FindFirstByMessageIDs returns the most recent message of the inbox matching any of the ids,
+// the ids come from a reply's In-Reply-To and References headers
+func FindFirstByMessageIDs(ctx context.Context, inboxID types.UUID, messageIDs []string) (*EmailMessage, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindFirstByMessageIDs(ctx, inboxID, messageIDs)
+	}
+
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+
+	return FindFirst(ctx, model.NewOptions().
+		WithCondition("%s = :inbox_id:", Columns.InboxID.Column()).
+		WithCondition("%s IN (:message_ids:)", Columns.MessageID.Column()).
+		WithParam(":inbox_id:", inboxID).
+		WithParam(":message_ids:", messageIDs).
+		WithOrder("%s desc", Columns.CreatedAt.Column()))
+}
+
+// GetLatestInbound returns the last message received in a conversation, the one a reply answers
+func GetLatestInbound(ctx context.Context, conversationID types.UUID) (*EmailMessage, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetLatestInbound(ctx, conversationID)
+	}
+
+	return FindFirst(ctx, model.NewOptions().
+		WithCondition("%s = :conversation_id:", Columns.ConversationID.Column()).
+		WithCondition("%s = :direction:", Columns.Direction.Column()).
+		WithParam(":conversation_id:", conversationID).
+		WithParam(":direction:", DIRECTION_INBOUND).
+		WithOrder("%s desc", Columns.CreatedAt.Column()))
+}
@@ -0,0 +1,34 @@
+package email_message
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/sanitize"
+	"github.com/griffnb/core/lib/types"
+)
+
+// FindAllRestrictedJoined returns the email messages of the session account's organization
+func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionAccount coremodel.Model) ([]*EmailMessageJoined, error) {
+	options.WithCondition("%s = :organization_id:", Columns.OrganizationID.Column())
+	options.WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+	return FindAllJoined(ctx, options)
+}
+
+// GetRestrictedJoined gets an email message belonging to the session account's organization
+func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionAccount coremodel.Model) (*EmailMessageJoined, error) {
+	options := model.NewOptions().
+		WithCondition("%s.id = :id:", TABLE).
+		WithParam(":id:", id).
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+
+	return FindFirstJoined(ctx, options)
+}
+
+// UpdatePublic merges the draft fields a reviewer may edit before approving
+func UpdatePublic(obj *EmailMessage, data map[string]any, _ coremodel.Model) {
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+}
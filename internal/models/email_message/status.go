@@ -0,0 +1,11 @@
+package email_message
+
+const (
+	STATUS_RECEIVED  = 1
+	STATUS_DRAFT     = 2 // agent reply waiting for a person to approve it
+	STATUS_SENT      = 3
+	STATUS_DISCARDED = 4
+	STATUS_FAILED    = 5
+	STATUS_DISABLED  = 200
+	STATUS_DELETED   = 300
+)
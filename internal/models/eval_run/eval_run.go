@@ -0,0 +1,76 @@
+//go:generate core_gen model EvalRun
+
+package eval_run
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/eval_run/migrations"
+)
+
+const (
+	TABLE        string = "eval_runs"
+	CHANGE_LOGS         = false
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	SuiteID        *fields.UUIDField                    `column:"suite_id"         type:"uuid"     default:"null" null:"true" index:"true"`
+	AgentID        *fields.UUIDField                    `column:"agent_id"         type:"uuid"     default:"null" null:"true" index:"true"`
+	Provider       *fields.StringField                  `column:"provider"         type:"text"     default:""`
+	Label          *fields.StringField                  `column:"label"            type:"text"     default:""`
+	AgentSettings  *fields.StructField[*agent.Settings] `column:"agent_settings"   type:"jsonb"    default:"{}"`
+	Results        *fields.StructField[[]*CaseResult]   `column:"results"          type:"jsonb"    default:"[]"`
+	PassedCount    *fields.IntField                     `column:"passed_count"     type:"integer"  default:"0"`
+	FailedCount    *fields.IntField                     `column:"failed_count"     type:"integer"  default:"0"`
+	TotalLatencyMS *fields.IntField                     `column:"total_latency_ms" type:"integer"  default:"0"`
+	TotalCost      *fields.DecimalField                 `column:"total_cost"       type:"numeric"  default:"0"    scale:"6" precision:"12"`
+	Error          *fields.StringField                  `column:"error"            type:"text"     default:""`
+}
+
+type JoinData struct {
+	SuiteName *fields.StringField `json:"suite_name" type:"text"`
+	AgentName *fields.StringField `json:"agent_name" type:"text"`
+}
+
+type EvalRun struct {
+	model.BaseModel
+	DBColumns
+}
+
+type EvalRunJoined struct {
+	EvalRun
+	JoinData
+}
+
+func (this *EvalRun) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *EvalRun) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
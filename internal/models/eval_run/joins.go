@@ -0,0 +1,17 @@
+package eval_run
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{
+		"LEFT JOIN eval_suites ON eval_suites.id = eval_runs.suite_id",
+		"LEFT JOIN agents ON agents.id = eval_runs.agent_id",
+	}...)
+	options.WithIncludeFields([]string{
+		"eval_suites.name AS suite_name",
+		"agents.name AS agent_name",
+	}...)
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "eval_runs"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792281900,
+		Table:       TABLE,
+		TableStruct: &EvalRunV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type EvalRunV1 struct {
+	base.Structure
+	SuiteID        *fields.UUIDField        `column:"suite_id"         type:"uuid"    default:"null" null:"true" index:"true"`
+	AgentID        *fields.UUIDField        `column:"agent_id"         type:"uuid"    default:"null" null:"true" index:"true"`
+	Provider       *fields.StringField      `column:"provider"         type:"text"    default:""`
+	Label          *fields.StringField      `column:"label"            type:"text"    default:""`
+	AgentSettings  *fields.StructField[any] `column:"agent_settings"   type:"jsonb"   default:"{}"`
+	Results        *fields.StructField[any] `column:"results"          type:"jsonb"   default:"[]"`
+	PassedCount    *fields.IntField         `column:"passed_count"     type:"integer" default:"0"`
+	FailedCount    *fields.IntField         `column:"failed_count"     type:"integer" default:"0"`
+	TotalLatencyMS *fields.IntField         `column:"total_latency_ms" type:"integer" default:"0"`
+	TotalCost      *fields.DecimalField     `column:"total_cost"       type:"numeric" default:"0"    scale:"6" precision:"12"`
+	Error          *fields.StringField      `column:"error"            type:"text"    default:""`
+}
@@ -0,0 +1,19 @@
+package eval_run
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*EvalRun, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*EvalRunJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*EvalRun, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*EvalRunJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*EvalRun, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*EvalRunJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+}
@@ -0,0 +1,86 @@
+package eval_run
+
+// CaseResult is the outcome of a single eval case
+type CaseResult struct {
+	CaseName     string             `json:"case_name"`
+	Passed       bool               `json:"passed"`
+	Output       string             `json:"output"`
+	Assertions   []*AssertionResult `json:"assertions"`
+	LatencyMS    int64              `json:"latency_ms"`
+	InputTokens  int                `json:"input_tokens"`
+	OutputTokens int                `json:"output_tokens"`
+	Cost         float64            `json:"cost"`
+	Error        string             `json:"error,omitempty"`
+}
+
+type AssertionResult struct {
+	Type   string  `json:"type"`
+	Passed bool    `json:"passed"`
+	Score  float64 `json:"score,omitempty"`
+	Detail string  `json:"detail,omitempty"`
+}
+
+type ComparisonChange string
+
+const (
+	CHANGE_SAME      ComparisonChange = "same"
+	CHANGE_FIXED     ComparisonChange = "fixed"
+	CHANGE_REGRESSED ComparisonChange = "regressed"
+	CHANGE_ADDED     ComparisonChange = "added"
+	CHANGE_REMOVED   ComparisonChange = "removed"
+)
+
+// CaseComparison lines up the same case from two runs
+type CaseComparison struct {
+	CaseName string           `json:"case_name"`
+	Change   ComparisonChange `json:"change"`
+	Base     *CaseResult      `json:"base"`
+	Compare  *CaseResult      `json:"compare"`
+}
+
+// CompareResults pairs cases by name, keeping the base run's order and appending cases only found in the compare run
+func CompareResults(base []*CaseResult, compare []*CaseResult) []*CaseComparison {
+	compareByName := map[string]*CaseResult{}
+	for _, result := range compare {
+		compareByName[result.CaseName] = result
+	}
+
+	comparisons := []*CaseComparison{}
+	seen := map[string]bool{}
+	for _, baseResult := range base {
+		seen[baseResult.CaseName] = true
+		compareResult := compareByName[baseResult.CaseName]
+		comparisons = append(comparisons, &CaseComparison{
+			CaseName: baseResult.CaseName,
+			Change:   compareChange(baseResult, compareResult),
+			Base:     baseResult,
+			Compare:  compareResult,
+		})
+	}
+
+	for _, compareResult := range compare {
+		if seen[compareResult.CaseName] {
+			continue
+		}
+		comparisons = append(comparisons, &CaseComparison{
+			CaseName: compareResult.CaseName,
+			Change:   CHANGE_ADDED,
+			Compare:  compareResult,
+		})
+	}
+
+	return comparisons
+}
+
+func compareChange(base *CaseResult, compare *CaseResult) ComparisonChange {
+	switch {
+	case compare == nil:
+		return CHANGE_REMOVED
+	case base.Passed == compare.Passed:
+		return CHANGE_SAME
+	case compare.Passed:
+		return CHANGE_FIXED
+	default:
+		return CHANGE_REGRESSED
+	}
+}
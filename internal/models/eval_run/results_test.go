@@ -0,0 +1,43 @@
+package eval_run
+
+import (
+	"testing"
+)
+
+func TestCompareResults(t *testing.T) {
+	base := []*CaseResult{
+		{CaseName: "a", Passed: true},
+		{CaseName: "b", Passed: false},
+		{CaseName: "c", Passed: true},
+		{CaseName: "d", Passed: true},
+	}
+	compare := []*CaseResult{
+		{CaseName: "a", Passed: true},
+		{CaseName: "b", Passed: true},
+		{CaseName: "c", Passed: false},
+		{CaseName: "e", Passed: true},
+	}
+
+	comparisons := CompareResults(base, compare)
+
+	expected := []struct {
+		name   string
+		change ComparisonChange
+	}{
+		{"a", CHANGE_SAME},
+		{"b", CHANGE_FIXED},
+		{"c", CHANGE_REGRESSED},
+		{"d", CHANGE_REMOVED},
+		{"e", CHANGE_ADDED},
+	}
+
+	if len(comparisons) != len(expected) {
+		t.Fatalf("expected %d comparisons, got %d", len(expected), len(comparisons))
+	}
+
+	for i, want := range expected {
+		if comparisons[i].CaseName != want.name || comparisons[i].Change != want.change {
+			t.Errorf("comparison %d: expected %s/%s, got %s/%s", i, want.name, want.change, comparisons[i].CaseName, comparisons[i].Change)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package eval_run
+
+const (
+	STATUS_PENDING   = 1
+	STATUS_RUNNING   = 2
+	STATUS_COMPLETED = 100
+	STATUS_FAILED    = 103
+	STATUS_DISABLED  = 200
+	STATUS_DELETED   = 300
+)
+
+const (
+	PROVIDER_OPENAI = "openai"
+	PROVIDER_STUB   = "stub"
+)
@@ -0,0 +1,92 @@
+package eval_suite
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type AssertionType string
+
+const (
+	ASSERTION_CONTAINS    AssertionType = "contains"
+	ASSERTION_REGEX       AssertionType = "regex"
+	ASSERTION_JSON_SCHEMA AssertionType = "json_schema"
+	ASSERTION_LLM_JUDGE   AssertionType = "llm_judge"
+)
+
+// EvalCase is a single conversation sent to the agent and the checks run against its reply
+type EvalCase struct {
+	Name         string         `json:"name"`
+	Messages     []*EvalMessage `json:"messages"`
+	Assertions   []*Assertion   `json:"assertions"`
+	StubResponse string         `json:"stub_response,omitempty"` // reply returned by the stub provider for offline runs
+}
+
+type EvalMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type Assertion struct {
+	Type     AssertionType  `json:"type"`
+	Value    string         `json:"value,omitempty"`     // substring for contains, pattern for regex
+	Schema   map[string]any `json:"schema,omitempty"`    // schema for json_schema
+	Rubric   string         `json:"rubric,omitempty"`    // grading rubric for llm_judge
+	MinScore float64        `json:"min_score,omitempty"` // passing llm_judge score between 0 and 1
+}
+
+// ValidateCases checks that every case is runnable before it is saved
+func ValidateCases(cases []*EvalCase) error {
+	problems := []string{}
+	names := map[string]bool{}
+
+	for i, evalCase := range cases {
+		label := evalCase.Name
+		if label == "" {
+			label = fmt.Sprintf("case %d", i+1)
+			problems = append(problems, fmt.Sprintf("%s has no name", label))
+		} else if names[label] {
+			problems = append(problems, fmt.Sprintf("%s is a duplicate name", label))
+		}
+		names[label] = true
+
+		if len(evalCase.Messages) == 0 {
+			problems = append(problems, fmt.Sprintf("%s has no messages", label))
+		}
+
+		for _, assertion := range evalCase.Assertions {
+			switch assertion.Type {
+			case ASSERTION_CONTAINS:
+				if assertion.Value == "" {
+					problems = append(problems, fmt.Sprintf("%s contains assertion has no value", label))
+				}
+			case ASSERTION_REGEX:
+				if _, err := regexp.Compile(assertion.Value); err != nil {
+					problems = append(problems, fmt.Sprintf("%s regex assertion is invalid: %s", label, err.Error()))
+				}
+			case ASSERTION_JSON_SCHEMA:
+				if len(assertion.Schema) == 0 {
+					problems = append(problems, fmt.Sprintf("%s json_schema assertion has no schema", label))
+				}
+			case ASSERTION_LLM_JUDGE:
+				if assertion.Rubric == "" {
+					problems = append(problems, fmt.Sprintf("%s llm_judge assertion has no rubric", label))
+				}
+				if assertion.MinScore < 0 || assertion.MinScore > 1 {
+					problems = append(problems, fmt.Sprintf("%s llm_judge min_score must be between 0 and 1", label))
+				}
+			default:
+				problems = append(problems, fmt.Sprintf("%s has unknown assertion type %q", label, assertion.Type))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package eval_suite
+
+import (
+	"testing"
+)
+
+func TestValidateCases(t *testing.T) {
+	valid := &EvalCase{
+		Name:     "greeting",
+		Messages: []*EvalMessage{{Role: "user", Content: "hi"}},
+		Assertions: []*Assertion{
+			{Type: ASSERTION_CONTAINS, Value: "hello"},
+			{Type: ASSERTION_REGEX, Value: `(?i)welcome`},
+			{Type: ASSERTION_JSON_SCHEMA, Schema: map[string]any{"type": "object"}},
+			{Type: ASSERTION_LLM_JUDGE, Rubric: "Is it friendly?", MinScore: 0.5},
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if err := ValidateCases([]*EvalCase{valid}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("duplicate names", func(t *testing.T) {
+		if err := ValidateCases([]*EvalCase{valid, valid}); err == nil {
+			t.Error("expected duplicate name error")
+		}
+	})
+
+	t.Run("invalid assertions", func(t *testing.T) {
+		invalid := &EvalCase{
+			Name:     "broken",
+			Messages: []*EvalMessage{{Role: "user", Content: "hi"}},
+			Assertions: []*Assertion{
+				{Type: ASSERTION_REGEX, Value: "("},
+				{Type: ASSERTION_LLM_JUDGE, Rubric: "x", MinScore: 2},
+				{Type: "unknown"},
+			},
+		}
+		if err := ValidateCases([]*EvalCase{invalid}); err == nil {
+			t.Error("expected validation error")
+		}
+	})
+}
@@ -0,0 +1,77 @@
+//go:generate core_gen model EvalSuite
+
+package eval_suite
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/eval_suite/migrations"
+)
+
+const (
+	TABLE        string = "eval_suites"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	AgentID     *fields.UUIDField                `column:"agent_id"    type:"uuid"  default:"null" null:"true" index:"true"`
+	Name        *fields.StringField              `column:"name"        type:"text"  default:""`
+	Description *fields.StringField              `column:"description" type:"text"  default:""`
+	Cases       *fields.StructField[[]*EvalCase] `column:"cases"       type:"jsonb" default:"[]"`
+}
+
+type JoinData struct {
+	AgentName *fields.StringField `json:"agent_name" type:"text"`
+}
+
+type EvalSuite struct {
+	model.BaseModel
+	DBColumns
+}
+
+type EvalSuiteJoined struct {
+	EvalSuite
+	JoinData
+}
+
+func (this *EvalSuite) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+
+	cases, err := this.Cases.Get()
+	if err != nil {
+		return err
+	}
+	err = ValidateCases(cases)
+	if err != nil {
+		return err
+	}
+
+	return this.ValidateSubStructs()
+}
+
+func (this *EvalSuite) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
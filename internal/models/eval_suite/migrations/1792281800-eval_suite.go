@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "eval_suites"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792281800,
+		Table:       TABLE,
+		TableStruct: &EvalSuiteV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type EvalSuiteV1 struct {
+	base.Structure
+	AgentID     *fields.UUIDField        `column:"agent_id"    type:"uuid"  default:"null" null:"true" index:"true"`
+	Name        *fields.StringField      `column:"name"        type:"text"  default:""`
+	Description *fields.StringField      `column:"description" type:"text"  default:""`
+	Cases       *fields.StructField[any] `column:"cases"       type:"jsonb" default:"[]"`
+}
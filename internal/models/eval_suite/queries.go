@@ -0,0 +1,19 @@
+package eval_suite
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*EvalSuite, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*EvalSuiteJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*EvalSuite, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*EvalSuiteJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*EvalSuite, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*EvalSuiteJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+}
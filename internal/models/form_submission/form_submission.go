@@ -0,0 +1,70 @@
+//go:generate core_gen model FormSubmission
+
+package form_submission
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/form_submission/migrations"
+)
+
+const (
+	TABLE        string = "form_submissions"
+	CHANGE_LOGS         = false
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	AccountID      *fields.UUIDField                   `public:"view" column:"account_id"      type:"uuid"     default:"null" null:"true" index:"true"`
+	OrganizationID *fields.UUIDField                   `public:"view" column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	AgentID        *fields.UUIDField                   `public:"view" column:"agent_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Input          *fields.StructField[map[string]any] `public:"view" column:"input"           type:"jsonb"    default:"{}"`
+	Output         *fields.StructField[map[string]any] `public:"view" column:"output"          type:"jsonb"    default:"{}"`
+	Attempts       *fields.IntField                    `public:"view" column:"attempts"        type:"smallint" default:"0"`
+	Error          *fields.StringField                 `public:"view" column:"error"           type:"text"     default:""`
+}
+
+type JoinData struct {
+	AgentName *fields.StringField `public:"view" json:"agent_name" type:"text"`
+}
+
+type FormSubmission struct {
+	model.BaseModel
+	DBColumns
+}
+
+type FormSubmissionJoined struct {
+	FormSubmission
+	JoinData
+}
+
+func (this *FormSubmission) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *FormSubmission) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "form_submissions"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792281700,
+		Table:       TABLE,
+		TableStruct: &FormSubmissionV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type FormSubmissionV1 struct {
+	base.Structure
+	AccountID      *fields.UUIDField        `column:"account_id"      type:"uuid"     default:"null" null:"true" index:"true"`
+	OrganizationID *fields.UUIDField        `column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	AgentID        *fields.UUIDField        `column:"agent_id"        type:"uuid"     default:"null" null:"true" index:"true"`
+	Input          *fields.StructField[any] `column:"input"           type:"jsonb"    default:"{}"`
+	Output         *fields.StructField[any] `column:"output"          type:"jsonb"    default:"{}"`
+	Attempts       *fields.IntField         `column:"attempts"        type:"smallint" default:"0"`
+	Error          *fields.StringField      `column:"error"           type:"text"     default:""`
+}
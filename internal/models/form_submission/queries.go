@@ -0,0 +1,19 @@
+package form_submission
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*FormSubmission, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*FormSubmissionJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*FormSubmission, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*FormSubmissionJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*FormSubmission, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*FormSubmissionJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+}
@@ -0,0 +1,25 @@
+package form_submission
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/types"
+)
+
+// FindAllRestrictedJoined returns the session account's submissions
+func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionUser coremodel.Model) ([]*FormSubmissionJoined, error) {
+	options.WithCondition("%s = :account_id:", Columns.AccountID.Column()).WithParam(":account_id:", sessionUser.ID())
+	return FindAllJoined(ctx, options)
+}
+
+// GetRestrictedJoined gets a submission owned by the session account
+func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionUser coremodel.Model) (*FormSubmissionJoined, error) {
+	options := model.NewOptions().
+		WithCondition("%s = :id: AND %s = :account_id:", Columns.ID_.Column(), Columns.AccountID.Column()).
+		WithParam(":id:", id).
+		WithParam(":account_id:", sessionUser.ID())
+
+	return FindFirstJoined(ctx, options)
+}
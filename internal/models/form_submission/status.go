@@ -0,0 +1,9 @@
+package form_submission
+
+const (
+	STATUS_PENDING   = 1
+	STATUS_COMPLETED = 100
+	STATUS_FAILED    = 103
+	STATUS_DISABLED  = 200
+	STATUS_DELETED   = 300
+)
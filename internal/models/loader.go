@@ -9,18 +9,35 @@ import (
 	"github.com/griffnb/techboss-ai-go/internal/models/account"
 	"github.com/griffnb/techboss-ai-go/internal/models/admin"
 	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent_attribute"
 	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review"
+	"github.com/griffnb/techboss-ai-go/internal/models/ai_tool_review_vote"
 	"github.com/griffnb/techboss-ai-go/internal/models/billing_plan"
 	"github.com/griffnb/techboss-ai-go/internal/models/billing_plan_price"
 	"github.com/griffnb/techboss-ai-go/internal/models/category"
 	"github.com/griffnb/techboss-ai-go/internal/models/change_log"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_group"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_inbox"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_message"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_run"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+	"github.com/griffnb/techboss-ai-go/internal/models/form_submission"
 	"github.com/griffnb/techboss-ai-go/internal/models/global_config"
 	"github.com/griffnb/techboss-ai-go/internal/models/lead"
 	"github.com/griffnb/techboss-ai-go/internal/models/migrations"
 	"github.com/griffnb/techboss-ai-go/internal/models/object_tag"
 	"github.com/griffnb/techboss-ai-go/internal/models/organization"
+	"github.com/griffnb/techboss-ai-go/internal/models/retention_policy"
+	"github.com/griffnb/techboss-ai-go/internal/models/slack_installation"
 	"github.com/griffnb/techboss-ai-go/internal/models/subscription"
 	"github.com/griffnb/techboss-ai-go/internal/models/tag"
+	"github.com/griffnb/techboss-ai-go/internal/models/webhook_delivery"
+	"github.com/griffnb/techboss-ai-go/internal/models/webhook_endpoint"
+	"github.com/griffnb/techboss-ai-go/internal/models/widget_lead"
 
 	"github.com/pkg/errors"
 )
@@ -47,19 +64,36 @@ func LoadModels() (err error) {
 	defaultClient := environment.GetDBClient(environment.CLIENT_DEFAULT)
 
 	models := map[string]any{
-		account.TABLE:            &account.Structure{},
-		admin.TABLE:              &admin.Structure{},
-		agent.TABLE:              &agent.Structure{},
-		ai_tool.TABLE:            &ai_tool.Structure{},
-		billing_plan.TABLE:       &billing_plan.Structure{},
-		billing_plan_price.TABLE: &billing_plan_price.Structure{},
-		category.TABLE:           &category.Structure{},
-		lead.TABLE:               &lead.Structure{},
-		subscription.TABLE:       &subscription.Structure{},
-		tag.TABLE:                &tag.Structure{},
-		object_tag.TABLE:         &object_tag.Structure{},
-		global_config.TABLE:      &global_config.Structure{},
-		organization.TABLE:       &organization.Structure{},
+		account.TABLE:             &account.Structure{},
+		admin.TABLE:               &admin.Structure{},
+		agent.TABLE:               &agent.Structure{},
+		agent_attribute.TABLE:     &agent_attribute.Structure{},
+		ai_tool.TABLE:             &ai_tool.Structure{},
+		ai_tool_review.TABLE:      &ai_tool_review.Structure{},
+		ai_tool_review_vote.TABLE: &ai_tool_review_vote.Structure{},
+		billing_plan.TABLE:        &billing_plan.Structure{},
+		billing_plan_price.TABLE:  &billing_plan_price.Structure{},
+		category.TABLE:            &category.Structure{},
+		conversation.TABLE:        &conversation.Structure{},
+		document.TABLE:            &document.Structure{},
+		document_chunk.TABLE:      &document_chunk.Structure{},
+		document_group.TABLE:      &document_group.Structure{},
+		email_inbox.TABLE:         &email_inbox.Structure{},
+		email_message.TABLE:       &email_message.Structure{},
+		eval_run.TABLE:            &eval_run.Structure{},
+		eval_suite.TABLE:          &eval_suite.Structure{},
+		form_submission.TABLE:     &form_submission.Structure{},
+		lead.TABLE:                &lead.Structure{},
+		slack_installation.TABLE:  &slack_installation.Structure{},
+		subscription.TABLE:        &subscription.Structure{},
+		tag.TABLE:                 &tag.Structure{},
+		object_tag.TABLE:          &object_tag.Structure{},
+		global_config.TABLE:       &global_config.Structure{},
+		organization.TABLE:        &organization.Structure{},
+		retention_policy.TABLE:    &retention_policy.Structure{},
+		webhook_delivery.TABLE:    &webhook_delivery.Structure{},
+		webhook_endpoint.TABLE:    &webhook_endpoint.Structure{},
+		widget_lead.TABLE:         &widget_lead.Structure{},
 	}
 
 	for table, structure := range models {
@@ -0,0 +1,14 @@
+package message
+
+import "github.com/griffnb/core/lib/types"
+
+// Citation points an assistant answer at the document passage it drew on, offsets are characters into the
+// document's extracted text
+type Citation struct {
+	DocumentID   types.UUID `json:"document_id"`
+	DocumentName string     `json:"document_name"`
+	ChunkID      types.UUID `json:"chunk_id"`
+	StartOffset  int        `json:"start_offset"`
+	EndOffset    int        `json:"end_offset"`
+	Quote        string     `json:"quote"`
+}
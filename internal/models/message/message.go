@@ -11,12 +11,14 @@ import (
 )
 
 type Message struct {
-	Key            string     `json:"key"`
-	ConversationID types.UUID `json:"conversation_id"`
-	Body           string     `json:"body"`
-	Role           int64      `json:"role"`
-	Timestamp      int64      `json:"timestamp"`
-	Tokens         int64      `json:"tokens"`
+	Key            string      `json:"key"`
+	ConversationID types.UUID  `json:"conversation_id"`
+	Body           string      `json:"body"`
+	Role           int64       `json:"role"`
+	Timestamp      int64       `json:"timestamp"`
+	Tokens         int64       `json:"tokens"`
+	ExternalID     string      `json:"external_id,omitempty"`
+	Citations      []*Citation `json:"citations,omitempty"` // document passages an assistant answer drew on
 }
 
 func (this *Message) Save(ctx context.Context) error {
@@ -0,0 +1,7 @@
+package message
+
+// Message roles
+const (
+	ROLE_USER      int64 = 1
+	ROLE_ASSISTANT int64 = 2
+)
@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/griffnb/core/lib/model"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:           5,
+		Table:        "",
+		SQLMigration: `CREATE EXTENSION IF NOT EXISTS vector;`,
+	})
+}
@@ -1,3 +1,80 @@
 package organization
 
 // This file contains additional helper functions for the Organization model
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/pkg/errors"
+)
+
+// ClaimVectorStore saves a vector store under a key of the organization's meta data only if the key has none yet,
+// returning the store the key ends up with. Workers creating a store at the same time all get the first one saved,
+// and the rest of the meta data is left as it is.
+func ClaimVectorStore(ctx context.Context, organizationID types.UUID, key string, storeID string) (string, error) {
+	params := map[string]any{
+		":organization_id:": organizationID,
+		":key:":             key,
+		":store_id:":        storeID,
+	}
+
+	err := environment.DB().DB.InsertWithContext(ctx, `
+	UPDATE organizations SET meta_data = jsonb_set(
+		COALESCE(meta_data, '{}'),
+		'{vector_store_id}',
+		COALESCE(meta_data->'vector_store_id', '{}') || jsonb_build_object(CAST(:key: AS text), CAST(:store_id: AS text))
+	)
+	WHERE id = :organization_id: AND COALESCE(meta_data->'vector_store_id'->>CAST(:key: AS text), '') = ''
+	`, params)
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := environment.DB().DB.GetAll(
+		"SELECT meta_data->'vector_store_id'->>CAST(:key: AS text) AS store_id FROM organizations WHERE id = :organization_id:",
+		params,
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", errors.Errorf("organization %s not found", organizationID)
+	}
+	claimedID, _ := rows[0]["store_id"].(string)
+	if claimedID == "" {
+		return "", errors.Errorf("vector store %s of organization %s was not saved", key, organizationID)
+	}
+	return claimedID, nil
+}
+
+// RemoveVectorStore takes a vector store key out of the organization's meta data, leaving the rest as it is
+func RemoveVectorStore(ctx context.Context, organizationID types.UUID, key string) error {
+	return environment.DB().DB.InsertWithContext(ctx, `
+	UPDATE organizations SET meta_data = jsonb_set(meta_data, '{vector_store_id}', (meta_data->'vector_store_id') - CAST(:key: AS text))
+	WHERE id = :organization_id: AND meta_data->'vector_store_id' IS NOT NULL
+	`, map[string]any{
+		":organization_id:": organizationID,
+		":key:":             key,
+	})
+}
+
+// GetPlanLevel returns the level of the organization's billing plan, 0 when it has none
+func GetPlanLevel(ctx context.Context, organizationID types.UUID) (int64, error) {
+	rows, err := environment.DB().DB.GetAll(`
+	SELECT billing_plans.level AS billing_plan_level
+	FROM organizations
+	JOIN billing_plan_prices ON billing_plan_prices.id = organizations.billing_plan_price_id
+	JOIN billing_plans ON billing_plans.id = billing_plan_prices.billing_plan_id
+	WHERE organizations.id = :organization_id:
+	`, map[string]any{":organization_id:": organizationID})
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return common.RowInt(rows[0], "billing_plan_level"), nil
+}
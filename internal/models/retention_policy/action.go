@@ -0,0 +1,33 @@
+package retention_policy
+
+import "slices"
+
+// Action is what a policy does to the documents it has expired
+type Action int
+
+const (
+	ACTION_DELETE_RAW_FILES Action = iota + 1 // deletes the uploaded file, the document stays searchable
+	ACTION_DELETE_DOCUMENTS                   // deletes the document with its files, embeddings and provider copy
+)
+
+// ACTIONS are the actions a policy can be created with
+var ACTIONS = []Action{
+	ACTION_DELETE_RAW_FILES,
+	ACTION_DELETE_DOCUMENTS,
+}
+
+// Valid reports whether the action is one of ACTIONS
+func (this Action) Valid() bool {
+	return slices.Contains(ACTIONS, this)
+}
+
+// String describes the action for people, e.g. in the summary emailed to owners
+func (this Action) String() string {
+	switch this {
+	case ACTION_DELETE_RAW_FILES:
+		return "Delete uploaded files"
+	case ACTION_DELETE_DOCUMENTS:
+		return "Delete documents"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,11 @@
+package retention_policy
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{}...)
+	options.WithIncludeFields([]string{}...)
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "retention_policies"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792283800,
+		Table:       TABLE,
+		TableStruct: &RetentionPolicyV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type RetentionPolicyV1 struct {
+	base.Structure
+	OrganizationID  *fields.UUIDField             `column:"organization_id"   type:"uuid"     default:"null" null:"true" index:"true"`
+	Name            *fields.StringField           `column:"name"              type:"text"     default:""`
+	Action          *fields.IntConstantField[int] `column:"action"            type:"smallint" default:"0"                index:"true"`
+	Days            *fields.IntField              `column:"days"              type:"integer"  default:"0"`
+	TagName         *fields.StringField           `column:"tag_name"          type:"text"     default:""`
+	LastRunAtTS     *fields.IntField              `column:"last_run_at_ts"    type:"bigint"   default:"0"`
+	LastPurgedCount *fields.IntField              `column:"last_purged_count" type:"integer"  default:"0"`
+}
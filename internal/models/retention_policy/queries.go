@@ -0,0 +1,67 @@
+package retention_policy
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*RetentionPolicy, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*RetentionPolicyJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*RetentionPolicy, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*RetentionPolicyJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*RetentionPolicy, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*RetentionPolicyJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	FindActive          func(ctx context.Context, organizationID types.UUID) ([]*RetentionPolicy, error)
+	FindOrganizationIDs func(ctx context.Context) ([]types.UUID, error)
+}
+
+// FindActive returns an organization's enabled policies
+func FindActive(ctx context.Context, organizationID types.UUID) ([]*RetentionPolicy, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindActive(ctx, organizationID)
+	}
+
+	options := model.NewOptions().
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithCondition("%s = 0", Columns.Disabled.Column()).
+		WithParam(":organization_id:", organizationID).
+		WithOrder("%s asc", Columns.CreatedAt.Column())
+	options.Limit = constants.SYSTEM_LIMIT
+	return FindAll(ctx, options)
+}
+
+// FindOrganizationIDs returns every organization with an enabled policy
+func FindOrganizationIDs(ctx context.Context) ([]types.UUID, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindOrganizationIDs(ctx)
+	}
+
+	rows, err := environment.DB().DB.GetAll(
+		"SELECT DISTINCT organization_id::text AS organization_id FROM retention_policies "+
+			"WHERE organization_id IS NOT NULL AND deleted = 0 AND disabled = 0",
+		map[string]any{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIDs := make([]types.UUID, 0, len(rows))
+	for _, row := range rows {
+		organizationID, _ := row["organization_id"].(string)
+		if organizationID != "" {
+			organizationIDs = append(organizationIDs, types.UUID(organizationID))
+		}
+	}
+	return organizationIDs, nil
+}
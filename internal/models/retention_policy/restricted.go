@@ -0,0 +1,42 @@
+package retention_policy
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/sanitize"
+	"github.com/griffnb/core/lib/types"
+)
+
+// FindAllRestrictedJoined returns the policies of the session account's organization
+func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionAccount coremodel.Model) ([]*RetentionPolicyJoined, error) {
+	options.WithCondition("%s = :organization_id:", Columns.OrganizationID.Column())
+	options.WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+	return FindAllJoined(ctx, options)
+}
+
+// GetRestrictedJoined gets a policy belonging to the session account's organization
+func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionAccount coremodel.Model) (*RetentionPolicyJoined, error) {
+	options := model.NewOptions().
+		WithCondition("%s.id = :id:", TABLE).
+		WithParam(":id:", id).
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+
+	return FindFirstJoined(ctx, options)
+}
+
+// NewPublic creates a new policy for the session account's organization from sanitized input
+func NewPublic(data map[string]any, sessionAccount coremodel.Model) *RetentionPolicy {
+	obj := New()
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+	obj.OrganizationID.Set(types.UUID(sessionAccount.GetString("organization_id")))
+	return obj
+}
+
+func UpdatePublic(obj *RetentionPolicy, data map[string]any, _ coremodel.Model) {
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+}
@@ -0,0 +1,71 @@
+//go:generate core_gen model RetentionPolicy
+
+package retention_policy
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/retention_policy/migrations"
+)
+
+const (
+	TABLE        string = "retention_policies"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+// MAX_DAYS is the longest a policy can keep documents for, about a hundred years
+const MAX_DAYS = 36500
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	OrganizationID  *fields.UUIDField                `public:"view" column:"organization_id"   type:"uuid"     default:"null" null:"true" index:"true"`
+	Name            *fields.StringField              `public:"edit" column:"name"              type:"text"     default:""`
+	Action          *fields.IntConstantField[Action] `public:"edit" column:"action"            type:"smallint" default:"0"                index:"true"`
+	Days            *fields.IntField                 `public:"edit" column:"days"              type:"integer"  default:"0"`
+	TagName         *fields.StringField              `public:"edit" column:"tag_name"          type:"text"     default:""`
+	LastRunAtTS     *fields.IntField                 `public:"view" column:"last_run_at_ts"    type:"bigint"   default:"0"`
+	LastPurgedCount *fields.IntField                 `public:"view" column:"last_purged_count" type:"integer"  default:"0"`
+}
+
+type JoinData struct{}
+
+type RetentionPolicy struct {
+	model.BaseModel
+	DBColumns
+}
+
+type RetentionPolicyJoined struct {
+	RetentionPolicy
+	JoinData
+}
+
+func (this *RetentionPolicy) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *RetentionPolicy) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
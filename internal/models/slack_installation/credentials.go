@@ -0,0 +1,17 @@
+package slack_installation
+
+import (
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+)
+
+// Credentials holds the encrypted bot token returned by the OAuth install
+type Credentials struct {
+	BotToken common.EncryptedString `json:"bot_token,omitempty"`
+}
+
+// ChannelAgent routes messages in a Slack channel to an agent
+type ChannelAgent struct {
+	ChannelID string     `json:"channel_id"`
+	AgentID   types.UUID `json:"agent_id"`
+}
@@ -0,0 +1,48 @@
+package slack_installation
+
+import (
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/pkg/errors"
+)
+
+// AgentForChannel returns the agent that answers in a channel, direct messages fall back to the DM agent
+func (this *SlackInstallation) AgentForChannel(channelID string, isDirectMessage bool) (types.UUID, error) {
+	channels, err := this.Channels.Get()
+	if err != nil {
+		return "", err
+	}
+	for _, channel := range channels {
+		if channel.ChannelID == channelID {
+			return channel.AgentID, nil
+		}
+	}
+
+	if isDirectMessage {
+		return this.DMAgentID.Get(), nil
+	}
+	return "", nil
+}
+
+// BotToken returns the decrypted bot token
+func (this *SlackInstallation) BotToken() (string, error) {
+	credentials, err := this.Credentials.Get()
+	if err != nil {
+		return "", err
+	}
+	if credentials == nil || tools.Empty(credentials.BotToken) {
+		return "", errors.Errorf("slack installation %s has no bot token", this.ID())
+	}
+	return credentials.BotToken.Decrypt()
+}
+
+// SetBotToken stores the bot token, it is encrypted when the credentials are saved
+func (this *SlackInstallation) SetBotToken(token string) {
+	this.Credentials.Set(&Credentials{BotToken: common.EncryptedString(token)})
+}
+
+// IsActive reports whether events for the installation should be answered
+func (this *SlackInstallation) IsActive() bool {
+	return this.Status.Get() == STATUS_ACTIVE && this.Disabled.Get() == 0 && this.Deleted.Get() == 0
+}
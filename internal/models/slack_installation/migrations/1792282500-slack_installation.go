@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "slack_installations"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792282500,
+		Table:       TABLE,
+		TableStruct: &SlackInstallationV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type SlackInstallationV1 struct {
+	base.Structure
+	OrganizationID *fields.UUIDField        `column:"organization_id" type:"uuid"  default:"null" null:"true" index:"true"`
+	TeamID         *fields.StringField      `column:"team_id"         type:"text"  default:""     index:"true"`
+	TeamName       *fields.StringField      `column:"team_name"       type:"text"  default:""`
+	AppID          *fields.StringField      `column:"app_id"          type:"text"  default:""`
+	BotUserID      *fields.StringField      `column:"bot_user_id"     type:"text"  default:""`
+	Scope          *fields.StringField      `column:"scope"           type:"text"  default:""`
+	InstalledByID  *fields.UUIDField        `column:"installed_by_id" type:"uuid"  default:"null" null:"true"`
+	Credentials    *fields.StructField[any] `column:"credentials"     type:"jsonb" default:"{}"`
+	Channels       *fields.StructField[any] `column:"channels"        type:"jsonb" default:"[]"`
+	DMAgentID      *fields.UUIDField        `column:"dm_agent_id"     type:"uuid"  default:"null" null:"true"`
+}
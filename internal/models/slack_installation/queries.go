@@ -0,0 +1,34 @@
+package slack_installation
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*SlackInstallation, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*SlackInstallationJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*SlackInstallation, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*SlackInstallationJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*SlackInstallation, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*SlackInstallationJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetByTeamID func(ctx context.Context, teamID string) (*SlackInstallationJoined, error)
+}
+
+// GetByTeamID returns the installation for a Slack workspace, nil when the app is not installed there
+func GetByTeamID(ctx context.Context, teamID string) (*SlackInstallationJoined, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetByTeamID(ctx, teamID)
+	}
+
+	return FindFirstJoined(ctx, model.NewOptions().
+		WithCondition("%s = :team_id:", Columns.TeamID.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":team_id:", teamID))
+}
@@ -0,0 +1,34 @@
+package slack_installation
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/sanitize"
+	"github.com/griffnb/core/lib/types"
+)
+
+// FindAllRestrictedJoined returns the Slack installations of the session account's organization
+func FindAllRestrictedJoined(ctx context.Context, options *model.Options, sessionAccount coremodel.Model) ([]*SlackInstallationJoined, error) {
+	options.WithCondition("%s = :organization_id:", Columns.OrganizationID.Column())
+	options.WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+	return FindAllJoined(ctx, options)
+}
+
+// GetRestrictedJoined gets an installation belonging to the session account's organization
+func GetRestrictedJoined(ctx context.Context, id types.UUID, sessionAccount coremodel.Model) (*SlackInstallationJoined, error) {
+	options := model.NewOptions().
+		WithCondition("%s.id = :id:", TABLE).
+		WithParam(":id:", id).
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithParam(":organization_id:", sessionAccount.GetString("organization_id"))
+
+	return FindFirstJoined(ctx, options)
+}
+
+// UpdatePublic merges the channel mapping fields an organization admin may change
+func UpdatePublic(obj *SlackInstallation, data map[string]any, _ coremodel.Model) {
+	data = sanitize.SanitizeModelInput(data, obj, &Structure{})
+	obj.MergeData(data)
+}
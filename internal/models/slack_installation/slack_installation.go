@@ -0,0 +1,75 @@
+//go:generate core_gen model SlackInstallation
+
+package slack_installation
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/slack_installation/migrations"
+)
+
+const (
+	TABLE        string = "slack_installations"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	OrganizationID *fields.UUIDField                    `public:"view" column:"organization_id" type:"uuid"  default:"null" null:"true" index:"true"`
+	TeamID         *fields.StringField                  `public:"view" column:"team_id"         type:"text"  default:""     index:"true"`
+	TeamName       *fields.StringField                  `public:"view" column:"team_name"       type:"text"  default:""`
+	AppID          *fields.StringField                  `public:"view" column:"app_id"          type:"text"  default:""`
+	BotUserID      *fields.StringField                  `public:"view" column:"bot_user_id"     type:"text"  default:""`
+	Scope          *fields.StringField                  `public:"view" column:"scope"           type:"text"  default:""`
+	InstalledByID  *fields.UUIDField                    `public:"view" column:"installed_by_id" type:"uuid"  default:"null" null:"true"`
+	Credentials    *fields.StructField[*Credentials]    `column:"credentials"     type:"jsonb" default:"{}"`
+	Channels       *fields.StructField[[]*ChannelAgent] `public:"edit" column:"channels"        type:"jsonb" default:"[]"`
+	DMAgentID      *fields.UUIDField                    `public:"edit" column:"dm_agent_id"     type:"uuid"  default:"null" null:"true"`
+}
+
+type JoinData struct {
+	OrganizationName *fields.StringField `public:"view" json:"organization_name" type:"text"`
+	DMAgentName      *fields.StringField `public:"view" json:"dm_agent_name"     type:"text"`
+	BillingPlanLevel *fields.IntField    `              json:"billing_plan_level" type:"smallint"`
+}
+
+type SlackInstallation struct {
+	model.BaseModel
+	DBColumns
+}
+
+type SlackInstallationJoined struct {
+	SlackInstallation
+	JoinData
+}
+
+func (this *SlackInstallation) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *SlackInstallation) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
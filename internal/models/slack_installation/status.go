@@ -0,0 +1,8 @@
+package slack_installation
+
+const (
+	STATUS_ACTIVE   = 1
+	STATUS_REVOKED  = 2 // app uninstalled or token revoked from the Slack side
+	STATUS_DISABLED = 200
+	STATUS_DELETED  = 300
+)
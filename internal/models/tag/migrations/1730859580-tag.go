@@ -29,6 +29,17 @@ func init() {
 			`, map[string]interface{}{})
 		},
 	})
+
+	// Trigram index for the catalog typeahead
+	model.AddMigration(&model.Migration{
+		ID:    1792284200,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			CREATE INDEX IF NOT EXISTS tags_name_trgm_idx ON tags USING GIN (lower(name) gin_trgm_ops)
+			`, map[string]interface{}{})
+		},
+	})
 }
 
 type TagV1 struct {
@@ -0,0 +1,15 @@
+package webhook_delivery
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{
+		"LEFT JOIN webhook_endpoints ON webhook_endpoints.id = webhook_deliveries.endpoint_id",
+	}...)
+	options.WithIncludeFields([]string{
+		"webhook_endpoints.url AS endpoint_url",
+	}...)
+}
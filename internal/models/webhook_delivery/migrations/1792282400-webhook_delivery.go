@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "webhook_deliveries"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792282400,
+		Table:       TABLE,
+		TableStruct: &WebhookDeliveryV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type WebhookDeliveryV1 struct {
+	base.Structure
+	EndpointID     *fields.UUIDField        `column:"endpoint_id"     type:"uuid"     default:"null" null:"true" index:"true"`
+	OrganizationID *fields.UUIDField        `column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	EventID        *fields.StringField      `column:"event_id"        type:"text"     default:""                 index:"true"`
+	EventType      *fields.StringField      `column:"event_type"      type:"text"     default:""                 index:"true"`
+	Payload        *fields.StructField[any] `column:"payload"         type:"jsonb"    default:"{}"`
+	Attempts       *fields.IntField         `column:"attempts"        type:"smallint" default:"0"`
+	ResponseStatus *fields.IntField         `column:"response_status" type:"integer"  default:"0"`
+	ResponseBody   *fields.StringField      `column:"response_body"   type:"text"     default:""`
+	Error          *fields.StringField      `column:"error"           type:"text"     default:""`
+	NextAttemptAt  *fields.IntField         `column:"next_attempt_at" type:"bigint"   default:"0"`
+	DeliveredAt    *fields.IntField         `column:"delivered_at"    type:"bigint"   default:"0"`
+	ReplayOfID     *fields.UUIDField        `column:"replay_of_id"    type:"uuid"     default:"null" null:"true" index:"true"`
+}
@@ -0,0 +1,19 @@
+package webhook_delivery
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*WebhookDelivery, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*WebhookDeliveryJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*WebhookDelivery, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*WebhookDeliveryJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*WebhookDelivery, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*WebhookDeliveryJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+}
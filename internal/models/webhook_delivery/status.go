@@ -0,0 +1,10 @@
+package webhook_delivery
+
+const (
+	STATUS_PENDING   = 1
+	STATUS_RETRYING  = 2
+	STATUS_DELIVERED = 100
+	STATUS_FAILED    = 103
+	STATUS_DISABLED  = 200
+	STATUS_DELETED   = 300
+)
@@ -0,0 +1,75 @@
+//go:generate core_gen model WebhookDelivery
+
+package webhook_delivery
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/webhook_delivery/migrations"
+)
+
+const (
+	TABLE        string = "webhook_deliveries"
+	CHANGE_LOGS         = false
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	EndpointID     *fields.UUIDField                   `public:"view" column:"endpoint_id"     type:"uuid"     default:"null" null:"true" index:"true"`
+	OrganizationID *fields.UUIDField                   `public:"view" column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	EventID        *fields.StringField                 `public:"view" column:"event_id"        type:"text"     default:""                 index:"true"`
+	EventType      *fields.StringField                 `public:"view" column:"event_type"      type:"text"     default:""                 index:"true"`
+	Payload        *fields.StructField[map[string]any] `public:"view" column:"payload"         type:"jsonb"    default:"{}"`
+	Attempts       *fields.IntField                    `public:"view" column:"attempts"        type:"smallint" default:"0"`
+	ResponseStatus *fields.IntField                    `public:"view" column:"response_status" type:"integer"  default:"0"`
+	ResponseBody   *fields.StringField                 `public:"view" column:"response_body"   type:"text"     default:""`
+	Error          *fields.StringField                 `public:"view" column:"error"           type:"text"     default:""`
+	NextAttemptAt  *fields.IntField                    `public:"view" column:"next_attempt_at" type:"bigint"   default:"0"`
+	DeliveredAt    *fields.IntField                    `public:"view" column:"delivered_at"    type:"bigint"   default:"0"`
+	ReplayOfID     *fields.UUIDField                   `public:"view" column:"replay_of_id"    type:"uuid"     default:"null" null:"true" index:"true"`
+}
+
+type JoinData struct {
+	EndpointURL *fields.StringField `public:"view" json:"endpoint_url" type:"text"`
+}
+
+type WebhookDelivery struct {
+	model.BaseModel
+	DBColumns
+}
+
+type WebhookDeliveryJoined struct {
+	WebhookDelivery
+	JoinData
+}
+
+func (this *WebhookDelivery) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *WebhookDelivery) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
@@ -0,0 +1,54 @@
+package webhook_endpoint
+
+import (
+	"slices"
+	"time"
+)
+
+// Subscribes reports whether the endpoint wants the event type, an empty filter receives every event
+func (this *WebhookEndpoint) Subscribes(eventType string) bool {
+	eventTypes, err := this.EventTypes.Get()
+	if err != nil {
+		return false
+	}
+	return len(eventTypes) == 0 || slices.Contains(eventTypes, eventType)
+}
+
+// RotateSecret generates a new signing secret and returns it, it is only readable at this point
+func (this *WebhookEndpoint) RotateSecret() (string, error) {
+	secret, err := NewSecret()
+	if err != nil {
+		return "", err
+	}
+
+	secrets, err := this.Secrets.Get()
+	if err != nil {
+		return "", err
+	}
+	if secrets == nil {
+		secrets = &SigningSecrets{}
+	}
+	secrets.Rotate(secret, time.Now())
+	this.Secrets.Set(secrets)
+
+	return secret, nil
+}
+
+// RecordFailure counts a failed delivery and disables the endpoint once it keeps failing
+func (this *WebhookEndpoint) RecordFailure(reason string) {
+	this.FailureCount.Set(this.FailureCount.Get() + 1)
+	if this.FailureCount.Get() >= AUTO_DISABLE_FAILURES {
+		this.Status.Set(STATUS_AUTO_DISABLED)
+		this.DisabledReason.Set(reason)
+	}
+}
+
+// RecordSuccess resets the consecutive failure count
+func (this *WebhookEndpoint) RecordSuccess() {
+	this.FailureCount.Set(0)
+}
+
+// IsActive reports whether deliveries should be sent to the endpoint
+func (this *WebhookEndpoint) IsActive() bool {
+	return this.Status.Get() == STATUS_ACTIVE && this.Disabled.Get() == 0 && this.Deleted.Get() == 0
+}
@@ -0,0 +1,15 @@
+package webhook_endpoint
+
+import (
+	"github.com/griffnb/core/lib/model"
+)
+
+// AddJoinData adds in the join data
+func AddJoinData(options *model.Options) {
+	options.WithPrependJoins([]string{
+		"LEFT JOIN organizations ON organizations.id = webhook_endpoints.organization_id",
+	}...)
+	options.WithIncludeFields([]string{
+		"organizations.name AS organization_name",
+	}...)
+}
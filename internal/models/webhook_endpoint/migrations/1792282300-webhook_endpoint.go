@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "webhook_endpoints"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792282300,
+		Table:       TABLE,
+		TableStruct: &WebhookEndpointV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+}
+
+type WebhookEndpointV1 struct {
+	base.Structure
+	OrganizationID *fields.UUIDField        `column:"organization_id" type:"uuid"    default:"null" null:"true" index:"true"`
+	URL            *fields.StringField      `column:"url"             type:"text"    default:""`
+	Description    *fields.StringField      `column:"description"     type:"text"    default:""`
+	EventTypes     *fields.StructField[any] `column:"event_types"     type:"jsonb"   default:"[]"`
+	Secrets        *fields.StructField[any] `column:"secrets"         type:"jsonb"   default:"{}"`
+	FailureCount   *fields.IntField         `column:"failure_count"   type:"integer" default:"0"`
+	DisabledReason *fields.StringField      `column:"disabled_reason" type:"text"    default:""`
+}
@@ -0,0 +1,37 @@
+package webhook_endpoint
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*WebhookEndpoint, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*WebhookEndpointJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*WebhookEndpoint, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*WebhookEndpointJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*WebhookEndpoint, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*WebhookEndpointJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	FindActiveByOrganization func(ctx context.Context, organizationID types.UUID) ([]*WebhookEndpoint, error)
+}
+
+// FindActiveByOrganization returns the organization's endpoints that should receive deliveries
+func FindActiveByOrganization(ctx context.Context, organizationID types.UUID) ([]*WebhookEndpoint, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.FindActiveByOrganization(ctx, organizationID)
+	}
+
+	return FindAll(ctx, model.NewOptions().
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("%s = :status:", Columns.Status.Column()).
+		WithCondition("%s = 0", Columns.Disabled.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":organization_id:", organizationID).
+		WithParam(":status:", STATUS_ACTIVE))
+}
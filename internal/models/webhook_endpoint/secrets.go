@@ -0,0 +1,67 @@
+package webhook_endpoint
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SECRET_PREFIX marks webhook signing secrets
+	SECRET_PREFIX = "whsec_"
+	// SECRET_ROTATION_GRACE is how long the previous secret keeps signing deliveries after a rotation
+	SECRET_ROTATION_GRACE = 24 * time.Hour
+)
+
+// SigningSecrets holds the encrypted HMAC secrets for an endpoint, the previous secret stays valid for a grace period after rotation
+type SigningSecrets struct {
+	Current           common.EncryptedString `json:"current,omitempty"`
+	Previous          common.EncryptedString `json:"previous,omitempty"`
+	PreviousExpiresAt int64                  `json:"previous_expires_at,omitempty"`
+}
+
+// NewSecret generates a random signing secret
+func NewSecret() (string, error) {
+	bytes := make([]byte, 32)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return SECRET_PREFIX + hex.EncodeToString(bytes), nil
+}
+
+// Rotate replaces the current secret, keeping the old one valid until the grace period ends
+func (this *SigningSecrets) Rotate(secret string, now time.Time) {
+	if this.Current != "" {
+		this.Previous = this.Current
+		this.PreviousExpiresAt = now.Add(SECRET_ROTATION_GRACE).Unix()
+	}
+	this.Current = common.EncryptedString(secret)
+}
+
+// Active returns the decrypted secrets deliveries should be signed with, current first
+func (this *SigningSecrets) Active(now time.Time) ([]string, error) {
+	secrets := []string{}
+	if this.Current == "" {
+		return secrets, nil
+	}
+
+	current, err := this.Current.Decrypt()
+	if err != nil {
+		return nil, err
+	}
+	secrets = append(secrets, current)
+
+	if this.Previous != "" && now.Unix() < this.PreviousExpiresAt {
+		previous, err := this.Previous.Decrypt()
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, previous)
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,41 @@
+package webhook_endpoint
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSigningSecretsRotate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	secrets := &SigningSecrets{}
+	secrets.Rotate("whsec_one", now)
+	if secrets.Current != "whsec_one" || secrets.Previous != "" {
+		t.Fatalf("Expected only a current secret, got %+v", secrets)
+	}
+
+	secrets.Rotate("whsec_two", now)
+	if secrets.Current != "whsec_two" || secrets.Previous != "whsec_one" {
+		t.Fatalf("Expected previous secret to be kept, got %+v", secrets)
+	}
+
+	t.Run("previous secret active during grace", func(t *testing.T) {
+		active, err := secrets.Active(now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(active) != 2 || active[0] != "whsec_two" || active[1] != "whsec_one" {
+			t.Errorf("Expected both secrets, got %v", active)
+		}
+	})
+
+	t.Run("previous secret expires", func(t *testing.T) {
+		active, err := secrets.Active(now.Add(SECRET_ROTATION_GRACE + time.Second))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(active) != 1 || active[0] != "whsec_two" {
+			t.Errorf("Expected only the current secret, got %v", active)
+		}
+	})
+}
@@ -0,0 +1,11 @@
+package webhook_endpoint
+
+const (
+	STATUS_ACTIVE        = 1
+	STATUS_AUTO_DISABLED = 2 // too many consecutive failed deliveries
+	STATUS_DISABLED      = 200
+	STATUS_DELETED       = 300
+)
+
+// AUTO_DISABLE_FAILURES is how many consecutive failed deliveries disable an endpoint
+const AUTO_DISABLE_FAILURES = 15
@@ -0,0 +1,70 @@
+//go:generate core_gen model WebhookEndpoint
+
+package webhook_endpoint
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/webhook_endpoint/migrations"
+)
+
+const (
+	TABLE        string = "webhook_endpoints"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	OrganizationID *fields.UUIDField                    `public:"view" column:"organization_id" type:"uuid"     default:"null" null:"true" index:"true"`
+	URL            *fields.StringField                  `public:"edit" column:"url"             type:"text"     default:""`
+	Description    *fields.StringField                  `public:"edit" column:"description"     type:"text"     default:""`
+	EventTypes     *fields.StructField[[]string]        `public:"edit" column:"event_types"     type:"jsonb"    default:"[]"`
+	Secrets        *fields.StructField[*SigningSecrets] `column:"secrets"         type:"jsonb"    default:"{}"`
+	FailureCount   *fields.IntField                     `public:"view" column:"failure_count"   type:"integer"  default:"0"`
+	DisabledReason *fields.StringField                  `public:"view" column:"disabled_reason" type:"text"     default:""`
+}
+
+type JoinData struct {
+	OrganizationName *fields.StringField `public:"view" json:"organization_name" type:"text"`
+}
+
+type WebhookEndpoint struct {
+	model.BaseModel
+	DBColumns
+}
+
+type WebhookEndpointJoined struct {
+	WebhookEndpoint
+	JoinData
+}
+
+func (this *WebhookEndpoint) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *WebhookEndpoint) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+)
+
+const TABLE string = "widget_leads"
+
+func init() {
+	model.AddMigration(&model.Migration{
+		ID:          1792285000,
+		Table:       TABLE,
+		TableStruct: &WidgetLeadV1{},
+		TableMigration: &model.TableMigration{
+			Type: model.CREATE_TABLE,
+		},
+	})
+
+	// One lead per email per organization, emails are stored lower cased
+	model.AddMigration(&model.Migration{
+		ID:    1792285100,
+		Table: TABLE,
+		DataTransform: func() error {
+			return environment.DB().DB.Insert(`
+			CREATE UNIQUE INDEX IF NOT EXISTS widget_leads_organization_email_unique
+				ON widget_leads (organization_id, email) WHERE deleted = 0
+			`, map[string]interface{}{})
+		},
+	})
+}
+
+type WidgetLeadV1 struct {
+	base.Structure
+	OrganizationID *fields.UUIDField   `column:"organization_id" type:"uuid" default:"null" null:"true" index:"true"`
+	AgentID        *fields.UUIDField   `column:"agent_id"        type:"uuid" default:"null" null:"true" index:"true"`
+	Email          *fields.StringField `column:"email"           type:"text" default:""`
+	Name           *fields.StringField `column:"name"            type:"text" default:""`
+	Phone          *fields.StringField `column:"phone"           type:"text" default:""`
+}
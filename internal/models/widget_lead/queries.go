@@ -0,0 +1,46 @@
+package widget_lead
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+)
+
+type Mocker struct {
+	// Standard Functions
+	Get              func(ctx context.Context, id types.UUID) (*WidgetLead, error)
+	GetJoined        func(ctx context.Context, id types.UUID) (*WidgetLeadJoined, error)
+	FindAll          func(ctx context.Context, options *model.Options) ([]*WidgetLead, error)
+	FindAllJoined    func(ctx context.Context, options *model.Options) ([]*WidgetLeadJoined, error)
+	FindFirst        func(ctx context.Context, options *model.Options) (*WidgetLead, error)
+	FindFirstJoined  func(ctx context.Context, options *model.Options) (*WidgetLeadJoined, error)
+	FindResultsCount func(ctx context.Context, options *model.Options) (int64, error)
+	// Custom Functions
+	GetByOrganizationAndEmail func(ctx context.Context, organizationID types.UUID, email string) (*WidgetLead, error)
+}
+
+// GetByOrganizationAndEmail finds an organization's lead for a lower cased email, returns nil when none exists
+func GetByOrganizationAndEmail(ctx context.Context, organizationID types.UUID, email string) (*WidgetLead, error) {
+	mocker, ok := model.GetMocker[*Mocker](ctx, PACKAGE)
+	if ok {
+		return mocker.GetByOrganizationAndEmail(ctx, organizationID, email)
+	}
+
+	obj, err := FindFirst(ctx, model.NewOptions().
+		WithCondition("%s = :organization_id:", Columns.OrganizationID.Column()).
+		WithCondition("%s = :email:", Columns.Email.Column()).
+		WithCondition("%s = 0", Columns.Deleted.Column()).
+		WithParam(":organization_id:", organizationID).
+		WithParam(":email:", email))
+	if err != nil {
+		return nil, err
+	}
+
+	if tools.Empty(obj) {
+		return nil, nil
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,70 @@
+//go:generate core_gen model WidgetLead
+
+package widget_lead
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model"
+	"github.com/griffnb/core/lib/model/fields"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/base"
+	_ "github.com/griffnb/techboss-ai-go/internal/models/widget_lead/migrations"
+)
+
+const (
+	TABLE        string = "widget_leads"
+	CHANGE_LOGS         = true
+	IS_VERSIONED        = false
+	CLIENT              = environment.CLIENT_DEFAULT
+)
+
+type Structure struct {
+	DBColumns
+	JoinData
+}
+
+type DBColumns struct {
+	base.Structure
+	OrganizationID *fields.UUIDField   `public:"view" column:"organization_id" type:"uuid" default:"null" null:"true" index:"true"`
+	AgentID        *fields.UUIDField   `public:"view" column:"agent_id"        type:"uuid" default:"null" null:"true" index:"true"`
+	Email          *fields.StringField `public:"view" column:"email"           type:"text" default:""`
+	Name           *fields.StringField `public:"view" column:"name"            type:"text" default:""`
+	Phone          *fields.StringField `public:"view" column:"phone"           type:"text" default:""`
+}
+
+type JoinData struct {
+	AgentName *fields.StringField `public:"view" json:"agent_name" type:"text"`
+}
+
+// WidgetLead is a visitor who left their details in an organization's chat widget. Leads are kept per organization,
+// a visitor leaving the same email on two organizations' widgets is two leads.
+type WidgetLead struct {
+	model.BaseModel
+	DBColumns
+}
+
+type WidgetLeadJoined struct {
+	WidgetLead
+	JoinData
+}
+
+func (this *WidgetLead) beforeSave(ctx context.Context) error {
+	this.BaseBeforeSave(ctx)
+	common.GenerateURN(this)
+	common.SetDisabledDeleted(this)
+	return this.ValidateSubStructs()
+}
+
+func (this *WidgetLead) afterSave(ctx context.Context) {
+	this.BaseAfterSave(ctx)
+	/*
+		go func() {
+			err := this.UpdateCache()
+			if err != nil {
+				log.Error(err)
+			}
+		}()
+	*/
+}
@@ -0,0 +1,306 @@
+package agent_service
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/account"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/message"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/pkg/errors"
+)
+
+// ASSISTANT_MAX_TOOL_ROUNDS caps how many times a single run may stop for tool calls
+const ASSISTANT_MAX_TOOL_ROUNDS = 8
+
+// AssistantStream is a stream of provider run events, satisfied by *ssestream.Stream[openai.AssistantStreamEventUnion]
+type AssistantStream interface {
+	Next() bool
+	Current() openai.AssistantStreamEventUnion
+	Err() error
+	Close() error
+}
+
+// AssistantBackend is the provider side of an assistant conversation
+type AssistantBackend interface {
+	CreateThread(ctx context.Context) (string, error)
+	AddUserMessage(ctx context.Context, threadID string, body string) error
+	StreamRun(ctx context.Context, threadID string, assistantID string, instructions string) AssistantStream
+	SubmitToolOutputs(ctx context.Context, threadID string, runID string, outputs []*AssistantToolOutput) AssistantStream
+}
+
+// OpenAIAssistantBackend runs assistants through the OpenAI threads and runs API
+type OpenAIAssistantBackend struct {
+	client openai.Client
+}
+
+// NewOpenAIAssistantBackend builds a backend from the configured OpenAI key
+func NewOpenAIAssistantBackend() *OpenAIAssistantBackend {
+	return &OpenAIAssistantBackend{
+		client: openai.NewClient(
+			option.WithAPIKey(environment.GetConfig().AIKeys.OpenAI.APIKey),
+		),
+	}
+}
+
+// CreateThread starts an empty provider thread
+func (this *OpenAIAssistantBackend) CreateThread(ctx context.Context) (string, error) {
+	thread, err := this.client.Beta.Threads.New(ctx, openai.BetaThreadNewParams{})
+	if err != nil {
+		return "", err
+	}
+	return thread.ID, nil
+}
+
+// AddUserMessage posts a user message onto the thread
+func (this *OpenAIAssistantBackend) AddUserMessage(ctx context.Context, threadID string, body string) error {
+	_, err := this.client.Beta.Threads.Messages.New(ctx, threadID, openai.BetaThreadMessageNewParams{
+		Role: openai.BetaThreadMessageNewParamsRoleUser,
+		Content: openai.BetaThreadMessageNewParamsContentUnion{
+			OfString: openai.String(body),
+		},
+	})
+	return err
+}
+
+// StreamRun starts a streamed run of the assistant on the thread
+func (this *OpenAIAssistantBackend) StreamRun(ctx context.Context, threadID string, assistantID string, instructions string) AssistantStream {
+	params := openai.BetaThreadRunNewParams{
+		AssistantID: assistantID,
+	}
+	if instructions != "" {
+		params.AdditionalInstructions = openai.String(instructions)
+	}
+	return this.client.Beta.Threads.Runs.NewStreaming(ctx, threadID, params)
+}
+
+// SubmitToolOutputs answers a run waiting in requires_action and continues streaming it
+func (this *OpenAIAssistantBackend) SubmitToolOutputs(ctx context.Context, threadID string, runID string, outputs []*AssistantToolOutput) AssistantStream {
+	toolOutputs := []openai.BetaThreadRunSubmitToolOutputsParamsToolOutput{}
+	for _, output := range outputs {
+		toolOutputs = append(toolOutputs, openai.BetaThreadRunSubmitToolOutputsParamsToolOutput{
+			ToolCallID: openai.String(output.ToolCallID),
+			Output:     openai.String(output.Output),
+		})
+	}
+	return this.client.Beta.Threads.Runs.SubmitToolOutputsStreaming(ctx, threadID, runID, openai.BetaThreadRunSubmitToolOutputsParams{
+		ToolOutputs: toolOutputs,
+	})
+}
+
+// GetAssistantID returns the provider assistant an agent runs on
+func GetAssistantID(agentObj *agent.Agent) (string, error) {
+	if agentObj.Type.Get() != agent.AGENT_TYPE_OPENAI_ASSISTANT {
+		return "", errors.Errorf("agent %s is not an assistant agent", agentObj.ID())
+	}
+
+	settings, err := agentObj.Settings.Get()
+	if err != nil {
+		return "", err
+	}
+	if settings == nil || settings.OpenAIAssistantID == "" {
+		return "", errors.Errorf("agent %s has no assistant configured", agentObj.ID())
+	}
+
+	return settings.OpenAIAssistantID, nil
+}
+
+// GetOrStartAssistantConversation loads the caller's conversation with the agent, or records a new one when no id is given
+func GetOrStartAssistantConversation(
+	ctx context.Context,
+	agentObj *agent.Agent,
+	accountObj *account.AccountWithFeatures,
+	conversationID types.UUID,
+) (*conversation.Conversation, error) {
+	if tools.Empty(conversationID) {
+		conversationObj := conversation.New()
+		conversationObj.AccountID.Set(accountObj.ID())
+		conversationObj.OrganizationID.Set(accountObj.OrganizationID.Get())
+		conversationObj.AgentID.Set(agentObj.ID())
+		conversationObj.Source.Set(conversation.SOURCE_ASSISTANT)
+		err := conversationObj.Save(accountObj)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook_service.PublishAndLog(ctx, conversationObj.OrganizationID.Get(), webhook_service.EVENT_CONVERSATION_STARTED,
+			webhook_service.NewConversationData(conversationObj))
+		return conversationObj, nil
+	}
+
+	conversationObj, err := conversation.Get(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if tools.Empty(conversationObj) ||
+		conversationObj.AccountID.Get() != accountObj.ID() ||
+		conversationObj.AgentID.Get() != agentObj.ID() {
+		return nil, errors.Errorf("conversation %s not found", conversationID)
+	}
+
+	return conversationObj, nil
+}
+
+// StreamAssistantMessage posts the user's message to the conversation's thread and streams the run back through emit.
+// Tool calls are answered from the registered assistant tools, and both sides of the exchange are mirrored into the
+// message store so history does not depend on the provider keeping the thread.
+func StreamAssistantMessage(
+	ctx context.Context,
+	backend AssistantBackend,
+	agentObj *agent.Agent,
+	accountObj *account.AccountWithFeatures,
+	conversationObj *conversation.Conversation,
+	body string,
+	emit func(event *AssistantEvent) error,
+) error {
+	if !agentObj.CanBeUsedBy(accountObj.OrganizationID.Get(), int64(accountObj.BillingPlanLevel.Get())) {
+		return ErrAgentAccessDenied
+	}
+
+	assistantID, err := GetAssistantID(agentObj)
+	if err != nil {
+		return err
+	}
+
+	instructions, err := BuildInstructions(ctx, agentObj, accountObj.ID())
+	if err != nil {
+		return err
+	}
+
+	threadID := conversationObj.ThreadID.Get()
+	if threadID == "" {
+		threadID, err = backend.CreateThread(ctx)
+		if err != nil {
+			return err
+		}
+		conversationObj.ThreadID.Set(threadID)
+		err = conversationObj.Save(accountObj)
+		if err != nil {
+			return err
+		}
+	}
+
+	userMessage := &message.Message{
+		ConversationID: conversationObj.ID(),
+		Body:           body,
+		Role:           message.ROLE_USER,
+	}
+	err = userMessage.Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = backend.AddUserMessage(ctx, threadID, body)
+	if err != nil {
+		return err
+	}
+
+	run := &assistantRun{
+		backend:  backend,
+		threadID: threadID,
+		toolContext: &AssistantToolContext{
+			AccountID:      accountObj.ID().String(),
+			OrganizationID: accountObj.OrganizationID.Get().String(),
+			AgentID:        agentObj.ID().String(),
+			ConversationID: conversationObj.ID().String(),
+		},
+		emit: func(event *AssistantEvent) error {
+			event.ConversationID = conversationObj.ID().String()
+			return emit(event)
+		},
+		mirror: func(ctx context.Context, event *AssistantEvent) error {
+			assistantMessage := &message.Message{
+				ConversationID: conversationObj.ID(),
+				Body:           event.Text,
+				Role:           message.ROLE_ASSISTANT,
+				ExternalID:     event.MessageID,
+				Citations:      event.Citations,
+			}
+			return assistantMessage.Save(ctx)
+		},
+	}
+
+	return run.execute(ctx, backend.StreamRun(ctx, threadID, assistantID, instructions))
+}
+
+// assistantRun drives one run through any number of tool call rounds
+type assistantRun struct {
+	backend     AssistantBackend
+	threadID    string
+	toolContext *AssistantToolContext
+	emit        func(event *AssistantEvent) error
+	mirror      func(ctx context.Context, event *AssistantEvent) error
+}
+
+func (this *assistantRun) execute(ctx context.Context, stream AssistantStream) error {
+	for round := 0; ; round++ {
+		pending, err := this.consume(ctx, stream)
+		if err != nil {
+			return err
+		}
+		if pending == nil {
+			return nil
+		}
+
+		if round >= ASSISTANT_MAX_TOOL_ROUNDS {
+			return errors.Errorf("run %s exceeded %d tool rounds", pending.RunID, ASSISTANT_MAX_TOOL_ROUNDS)
+		}
+
+		cited := len(this.toolContext.Citations())
+		outputs := runAssistantTools(ctx, this.toolContext, pending.ToolCalls)
+
+		// Citations stream as soon as the tools found them, ahead of the answer that uses them
+		for _, citation := range this.toolContext.Citations()[cited:] {
+			err = this.emit(&AssistantEvent{Type: ASSISTANT_EVENT_CITATION, RunID: pending.RunID, Citation: citation})
+			if err != nil {
+				return err
+			}
+		}
+
+		stream = this.backend.SubmitToolOutputs(ctx, this.threadID, pending.RunID, outputs)
+	}
+}
+
+// consume relays one stream to the client and returns the tool call event if the run stopped for tools
+func (this *assistantRun) consume(ctx context.Context, stream AssistantStream) (*AssistantEvent, error) {
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	var pending *AssistantEvent
+	for stream.Next() {
+		event := NormalizeAssistantEvent(stream.Current())
+		if event == nil {
+			continue
+		}
+
+		switch event.Type {
+		case ASSISTANT_EVENT_MESSAGE_COMPLETED:
+			event.Citations = this.toolContext.Citations()
+			err := this.mirror(ctx, event)
+			if err != nil {
+				return nil, err
+			}
+		case ASSISTANT_EVENT_TOOL_CALLS:
+			pending = event
+		}
+
+		err := this.emit(event)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := stream.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
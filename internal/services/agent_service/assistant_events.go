@@ -0,0 +1,115 @@
+package agent_service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/message"
+	"github.com/openai/openai-go/v3"
+)
+
+// Normalized assistant event types, independent of the provider's own event names
+const (
+	ASSISTANT_EVENT_RUN_STARTED       = "run.started"
+	ASSISTANT_EVENT_MESSAGE_DELTA     = "message.delta"
+	ASSISTANT_EVENT_MESSAGE_COMPLETED = "message.completed"
+	ASSISTANT_EVENT_TOOL_CALLS        = "tool_calls"
+	ASSISTANT_EVENT_CITATION          = "citation"
+	ASSISTANT_EVENT_RUN_COMPLETED     = "run.completed"
+	ASSISTANT_EVENT_RUN_FAILED        = "run.failed"
+	ASSISTANT_EVENT_ERROR             = "error"
+)
+
+// AssistantToolCall is a function call the assistant needs answered before the run can continue
+type AssistantToolCall struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// AssistantEvent is a provider agnostic event streamed to the client
+type AssistantEvent struct {
+	Type           string               `json:"type"`
+	ConversationID string               `json:"conversation_id,omitempty"`
+	RunID          string               `json:"run_id,omitempty"`
+	MessageID      string               `json:"message_id,omitempty"`
+	Text           string               `json:"text,omitempty"`
+	ToolCalls      []*AssistantToolCall `json:"tool_calls,omitempty"`
+	InputTokens    int64                `json:"input_tokens,omitempty"`
+	OutputTokens   int64                `json:"output_tokens,omitempty"`
+	Error          string               `json:"error,omitempty"`
+	Citation       *message.Citation    `json:"citation,omitempty"`  // set on citation events
+	Citations      []*message.Citation  `json:"citations,omitempty"` // set on message.completed when documents were used
+}
+
+// NormalizeAssistantEvent maps a provider stream event onto an AssistantEvent, returning nil for events the client does not need
+func NormalizeAssistantEvent(event openai.AssistantStreamEventUnion) *AssistantEvent {
+	data := event.Data
+
+	switch event.Event {
+	case "thread.run.created":
+		return &AssistantEvent{Type: ASSISTANT_EVENT_RUN_STARTED, RunID: data.ID}
+	case "thread.message.delta":
+		text := ""
+		for _, content := range data.Delta.Content {
+			if content.Type == "text" {
+				text += content.Text.Value
+			}
+		}
+		if text == "" {
+			return nil
+		}
+		return &AssistantEvent{Type: ASSISTANT_EVENT_MESSAGE_DELTA, MessageID: data.ID, Text: text}
+	case "thread.message.completed":
+		parts := []string{}
+		for _, content := range data.Content {
+			if content.Type == "text" {
+				parts = append(parts, content.Text.Value)
+			}
+		}
+		return &AssistantEvent{
+			Type:      ASSISTANT_EVENT_MESSAGE_COMPLETED,
+			RunID:     data.RunID,
+			MessageID: data.ID,
+			Text:      strings.Join(parts, "\n"),
+		}
+	case "thread.run.requires_action":
+		toolCalls := []*AssistantToolCall{}
+		for _, toolCall := range data.RequiredAction.SubmitToolOutputs.ToolCalls {
+			toolCalls = append(toolCalls, &AssistantToolCall{
+				ID:        toolCall.ID,
+				Name:      toolCall.Function.Name,
+				Arguments: toolCall.Function.Arguments,
+			})
+		}
+		return &AssistantEvent{Type: ASSISTANT_EVENT_TOOL_CALLS, RunID: data.ID, ToolCalls: toolCalls}
+	case "thread.run.completed":
+		return &AssistantEvent{
+			Type:         ASSISTANT_EVENT_RUN_COMPLETED,
+			RunID:        data.ID,
+			InputTokens:  data.Usage.PromptTokens,
+			OutputTokens: data.Usage.CompletionTokens,
+		}
+	case "thread.run.failed", "thread.run.cancelled", "thread.run.expired", "thread.run.incomplete":
+		message := data.LastError.Message
+		if message == "" {
+			message = strings.TrimPrefix(event.Event, "thread.run.")
+		}
+		return &AssistantEvent{Type: ASSISTANT_EVENT_RUN_FAILED, RunID: data.ID, Error: message}
+	}
+
+	return nil
+}
+
+// WriteSSE writes the event as a single server sent event
+func (this *AssistantEvent) WriteSSE(w io.Writer) error {
+	payload, err := json.Marshal(this)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", this.Type, payload)
+	return err
+}
@@ -0,0 +1,255 @@
+package agent_service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/message"
+	"github.com/openai/openai-go/v3"
+)
+
+type fakeAssistantStream struct {
+	events  []openai.AssistantStreamEventUnion
+	current openai.AssistantStreamEventUnion
+	closed  bool
+}
+
+func (this *fakeAssistantStream) Next() bool {
+	if len(this.events) == 0 {
+		return false
+	}
+	this.current = this.events[0]
+	this.events = this.events[1:]
+	return true
+}
+
+func (this *fakeAssistantStream) Current() openai.AssistantStreamEventUnion {
+	return this.current
+}
+
+func (this *fakeAssistantStream) Err() error {
+	return nil
+}
+
+func (this *fakeAssistantStream) Close() error {
+	this.closed = true
+	return nil
+}
+
+type fakeAssistantBackend struct {
+	submitted []*AssistantToolOutput
+	next      *fakeAssistantStream
+}
+
+func (this *fakeAssistantBackend) CreateThread(_ context.Context) (string, error) {
+	return "thread_1", nil
+}
+
+func (this *fakeAssistantBackend) AddUserMessage(_ context.Context, _ string, _ string) error {
+	return nil
+}
+
+func (this *fakeAssistantBackend) StreamRun(_ context.Context, _ string, _ string, _ string) AssistantStream {
+	return this.next
+}
+
+func (this *fakeAssistantBackend) SubmitToolOutputs(_ context.Context, _ string, _ string, outputs []*AssistantToolOutput) AssistantStream {
+	this.submitted = append(this.submitted, outputs...)
+	return this.next
+}
+
+func streamEvent(t *testing.T, name string, data string) openai.AssistantStreamEventUnion {
+	t.Helper()
+	event := openai.AssistantStreamEventUnion{}
+	err := json.Unmarshal([]byte(`{"event": "`+name+`", "data": `+data+`}`), &event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return event
+}
+
+func TestNormalizeAssistantEvent(t *testing.T) {
+	t.Run("message delta text", func(t *testing.T) {
+		event := NormalizeAssistantEvent(streamEvent(t, "thread.message.delta",
+			`{"id": "msg_1", "object": "thread.message.delta", "delta": {"content": [{"index": 0, "type": "text", "text": {"value": "Hel"}}]}}`))
+		if event == nil || event.Type != ASSISTANT_EVENT_MESSAGE_DELTA || event.Text != "Hel" || event.MessageID != "msg_1" {
+			t.Errorf("Expected message delta, got %+v", event)
+		}
+	})
+
+	t.Run("requires action tool calls", func(t *testing.T) {
+		event := NormalizeAssistantEvent(streamEvent(t, "thread.run.requires_action",
+			`{"id": "run_1", "object": "thread.run", "status": "requires_action", "required_action": {"type": "submit_tool_outputs",
+			"submit_tool_outputs": {"tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "lookup", "arguments": "{\"q\":1}"}}]}}}`))
+		if event == nil || event.Type != ASSISTANT_EVENT_TOOL_CALLS || event.RunID != "run_1" {
+			t.Fatalf("Expected tool calls, got %+v", event)
+		}
+		if len(event.ToolCalls) != 1 || event.ToolCalls[0].Name != "lookup" || event.ToolCalls[0].Arguments != `{"q":1}` {
+			t.Errorf("Expected lookup tool call, got %+v", event.ToolCalls)
+		}
+	})
+
+	t.Run("failed run uses last error", func(t *testing.T) {
+		event := NormalizeAssistantEvent(streamEvent(t, "thread.run.failed",
+			`{"id": "run_1", "object": "thread.run", "status": "failed", "last_error": {"code": "server_error", "message": "boom"}}`))
+		if event == nil || event.Type != ASSISTANT_EVENT_RUN_FAILED || event.Error != "boom" {
+			t.Errorf("Expected failed run, got %+v", event)
+		}
+	})
+
+	t.Run("step events are dropped", func(t *testing.T) {
+		event := NormalizeAssistantEvent(streamEvent(t, "thread.run.step.created", `{"id": "step_1", "object": "thread.run.step"}`))
+		if event != nil {
+			t.Errorf("Expected nil, got %+v", event)
+		}
+	})
+}
+
+func TestAssistantRunExecute(t *testing.T) {
+	RegisterAssistantTool("lookup", func(_ context.Context, toolContext *AssistantToolContext, arguments json.RawMessage) (string, error) {
+		return `{"found": true, "account": "` + toolContext.AccountID + `"}`, nil
+	})
+
+	first := &fakeAssistantStream{events: []openai.AssistantStreamEventUnion{
+		streamEvent(t, "thread.run.created", `{"id": "run_1", "object": "thread.run", "status": "queued"}`),
+		streamEvent(t, "thread.run.requires_action",
+			`{"id": "run_1", "object": "thread.run", "status": "requires_action", "required_action": {"type": "submit_tool_outputs",
+			"submit_tool_outputs": {"tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "lookup", "arguments": "{}"}},
+			{"id": "call_2", "type": "function", "function": {"name": "missing", "arguments": "{}"}}]}}}`),
+	}}
+	second := &fakeAssistantStream{events: []openai.AssistantStreamEventUnion{
+		streamEvent(t, "thread.message.delta",
+			`{"id": "msg_1", "object": "thread.message.delta", "delta": {"content": [{"index": 0, "type": "text", "text": {"value": "Found it"}}]}}`),
+		streamEvent(t, "thread.message.completed",
+			`{"id": "msg_1", "object": "thread.message", "run_id": "run_1", "role": "assistant", "content": [{"type": "text", "text": {"value": "Found it", "annotations": []}}]}`),
+		streamEvent(t, "thread.run.completed",
+			`{"id": "run_1", "object": "thread.run", "status": "completed", "usage": {"prompt_tokens": 10, "completion_tokens": 3, "total_tokens": 13}}`),
+	}}
+
+	backend := &fakeAssistantBackend{next: second}
+	emitted := []*AssistantEvent{}
+	mirrored := []*AssistantEvent{}
+	run := &assistantRun{
+		backend:     backend,
+		threadID:    "thread_1",
+		toolContext: &AssistantToolContext{AccountID: "acct"},
+		emit: func(event *AssistantEvent) error {
+			emitted = append(emitted, event)
+			return nil
+		},
+		mirror: func(_ context.Context, event *AssistantEvent) error {
+			mirrored = append(mirrored, event)
+			return nil
+		},
+	}
+
+	err := run.execute(context.Background(), first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !first.closed || !second.closed {
+		t.Errorf("Expected both streams to be closed")
+	}
+
+	if len(backend.submitted) != 2 {
+		t.Fatalf("Expected 2 tool outputs, got %d", len(backend.submitted))
+	}
+	if backend.submitted[0].Output != `{"found": true, "account": "acct"}` {
+		t.Errorf("Expected lookup output, got %s", backend.submitted[0].Output)
+	}
+	if !strings.Contains(backend.submitted[1].Output, "unknown tool missing") {
+		t.Errorf("Expected unknown tool error, got %s", backend.submitted[1].Output)
+	}
+
+	types := []string{}
+	for _, event := range emitted {
+		types = append(types, event.Type)
+	}
+	expected := []string{
+		ASSISTANT_EVENT_RUN_STARTED,
+		ASSISTANT_EVENT_TOOL_CALLS,
+		ASSISTANT_EVENT_MESSAGE_DELTA,
+		ASSISTANT_EVENT_MESSAGE_COMPLETED,
+		ASSISTANT_EVENT_RUN_COMPLETED,
+	}
+	if strings.Join(types, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected events %v, got %v", expected, types)
+	}
+
+	if len(mirrored) != 1 || mirrored[0].Text != "Found it" || mirrored[0].MessageID != "msg_1" {
+		t.Errorf("Expected completed message to be mirrored, got %+v", mirrored)
+	}
+
+	if emitted[4].InputTokens != 10 || emitted[4].OutputTokens != 3 {
+		t.Errorf("Expected usage on run completed, got %+v", emitted[4])
+	}
+}
+
+func TestAssistantRunCitations(t *testing.T) {
+	RegisterAssistantTool("cite", func(_ context.Context, toolContext *AssistantToolContext, _ json.RawMessage) (string, error) {
+		citation := &message.Citation{
+			DocumentID:  "doc_1",
+			ChunkID:     "chunk_1",
+			StartOffset: 10,
+			EndOffset:   40,
+			Quote:       "Refunds take 14 days",
+		}
+		toolContext.AddCitations(citation, citation)
+		return `{"results": []}`, nil
+	})
+
+	first := &fakeAssistantStream{events: []openai.AssistantStreamEventUnion{
+		streamEvent(t, "thread.run.requires_action",
+			`{"id": "run_1", "object": "thread.run", "status": "requires_action", "required_action": {"type": "submit_tool_outputs",
+			"submit_tool_outputs": {"tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "cite", "arguments": "{}"}}]}}}`),
+	}}
+	second := &fakeAssistantStream{events: []openai.AssistantStreamEventUnion{
+		streamEvent(t, "thread.message.completed",
+			`{"id": "msg_1", "object": "thread.message", "run_id": "run_1", "role": "assistant", "content": [{"type": "text", "text": {"value": "14 days", "annotations": []}}]}`),
+	}}
+
+	emitted := []*AssistantEvent{}
+	mirrored := []*AssistantEvent{}
+	run := &assistantRun{
+		backend:     &fakeAssistantBackend{next: second},
+		threadID:    "thread_1",
+		toolContext: &AssistantToolContext{AccountID: "acct"},
+		emit: func(event *AssistantEvent) error {
+			emitted = append(emitted, event)
+			return nil
+		},
+		mirror: func(_ context.Context, event *AssistantEvent) error {
+			mirrored = append(mirrored, event)
+			return nil
+		},
+	}
+
+	err := run.execute(context.Background(), first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(emitted) != 3 || emitted[1].Type != ASSISTANT_EVENT_CITATION || emitted[1].Citation.ChunkID != "chunk_1" {
+		t.Fatalf("Expected one citation event after the tool call, got %+v", emitted)
+	}
+	if len(mirrored) != 1 || len(mirrored[0].Citations) != 1 || mirrored[0].Citations[0].Quote != "Refunds take 14 days" {
+		t.Errorf("Expected the completed message to carry its citation, got %+v", mirrored)
+	}
+}
+
+func TestAssistantEventWriteSSE(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	err := (&AssistantEvent{Type: ASSISTANT_EVENT_MESSAGE_DELTA, Text: "hi"}).WriteSSE(buffer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "event: message.delta\ndata: {\"type\":\"message.delta\",\"text\":\"hi\"}\n\n"
+	if buffer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buffer.String())
+	}
+}
@@ -0,0 +1,108 @@
+package agent_service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/techboss-ai-go/internal/models/message"
+)
+
+// AssistantToolHandler answers a single assistant function call, the returned string is sent back as the tool output
+type AssistantToolHandler func(ctx context.Context, toolContext *AssistantToolContext, arguments json.RawMessage) (string, error)
+
+// AssistantToolContext identifies who a tool call is running on behalf of, and collects the citations of any
+// documents the tools retrieved so they can be attached to the answer
+type AssistantToolContext struct {
+	AccountID      string
+	OrganizationID string
+	AgentID        string
+	ConversationID string
+
+	citationsLock sync.Mutex
+	citations     []*message.Citation
+}
+
+// AddCitations records passages a tool handed to the assistant, returning the ones not already cited
+func (this *AssistantToolContext) AddCitations(citations ...*message.Citation) []*message.Citation {
+	this.citationsLock.Lock()
+	defer this.citationsLock.Unlock()
+
+	added := []*message.Citation{}
+	for _, citation := range citations {
+		duplicate := false
+		for _, existing := range this.citations {
+			if existing.ChunkID == citation.ChunkID {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			this.citations = append(this.citations, citation)
+			added = append(added, citation)
+		}
+	}
+	return added
+}
+
+// Citations returns every citation recorded so far
+func (this *AssistantToolContext) Citations() []*message.Citation {
+	this.citationsLock.Lock()
+	defer this.citationsLock.Unlock()
+	return append([]*message.Citation{}, this.citations...)
+}
+
+// AssistantToolOutput is the answer to one tool call
+type AssistantToolOutput struct {
+	ToolCallID string
+	Output     string
+}
+
+var (
+	assistantToolsLock sync.RWMutex
+	assistantTools     = map[string]AssistantToolHandler{}
+)
+
+// RegisterAssistantTool makes a function callable by assistants under the given name
+func RegisterAssistantTool(name string, handler AssistantToolHandler) {
+	assistantToolsLock.Lock()
+	defer assistantToolsLock.Unlock()
+	assistantTools[name] = handler
+}
+
+func getAssistantTool(name string) (AssistantToolHandler, bool) {
+	assistantToolsLock.RLock()
+	defer assistantToolsLock.RUnlock()
+	handler, ok := assistantTools[name]
+	return handler, ok
+}
+
+// runAssistantTools answers every tool call, unknown tools and handler errors are reported back to the model
+// as an error output so the run can continue rather than stall in requires_action
+func runAssistantTools(ctx context.Context, toolContext *AssistantToolContext, toolCalls []*AssistantToolCall) []*AssistantToolOutput {
+	outputs := []*AssistantToolOutput{}
+	for _, toolCall := range toolCalls {
+		handler, ok := getAssistantTool(toolCall.Name)
+		if !ok {
+			outputs = append(outputs, &AssistantToolOutput{ToolCallID: toolCall.ID, Output: toolErrorOutput("unknown tool " + toolCall.Name)})
+			continue
+		}
+
+		output, err := handler(ctx, toolContext, json.RawMessage(toolCall.Arguments))
+		if err != nil {
+			log.ErrorContext(err, ctx)
+			outputs = append(outputs, &AssistantToolOutput{ToolCallID: toolCall.ID, Output: toolErrorOutput(err.Error())})
+			continue
+		}
+
+		outputs = append(outputs, &AssistantToolOutput{ToolCallID: toolCall.ID, Output: output})
+	}
+
+	return outputs
+}
+
+func toolErrorOutput(message string) string {
+	payload, _ := json.Marshal(map[string]string{"error": message})
+	return string(payload)
+}
@@ -0,0 +1,108 @@
+package agent_service
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/account"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent_attribute"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/organization"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/pkg/errors"
+)
+
+// ErrAgentAccessDenied is returned when the caller's organization or plan may not use an agent
+var ErrAgentAccessDenied = errors.New("agent access denied")
+
+// ChatKitSession is a started ChatKit session and the conversation recorded for it
+type ChatKitSession struct {
+	ClientSecret string
+	Conversation *conversation.Conversation
+}
+
+// StartChatKitSession checks access, builds the agent's state variables and opens a ChatKit session,
+// recording a conversation so usage can be attributed to the account, organization and agent
+func StartChatKitSession(ctx context.Context, agentObj *agent.Agent, accountObj *account.AccountWithFeatures) (*ChatKitSession, error) {
+	if !agentObj.CanBeUsedBy(accountObj.OrganizationID.Get(), int64(accountObj.BillingPlanLevel.Get())) {
+		return nil, ErrAgentAccessDenied
+	}
+
+	settings, err := agentObj.Settings.Get()
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil || settings.WorkflowID == "" {
+		return nil, errors.Errorf("agent %s has no workflow", agentObj.ID())
+	}
+
+	orgObj, err := organization.Get(ctx, accountObj.OrganizationID.Get())
+	if err != nil {
+		return nil, err
+	}
+	if tools.Empty(orgObj) {
+		orgObj = nil
+	}
+
+	var attributes *agent_attribute.Attributes
+	attributeObj, err := agent_attribute.GetByAccountAndAgent(ctx, accountObj.ID(), agentObj.ID())
+	if err != nil {
+		return nil, err
+	}
+	if attributeObj != nil {
+		attributes, err = attributeObj.Attributes.Get()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	variables, err := ResolveStateVariables(settings.StateVariables, StateValues(accountObj, orgObj, attributes))
+	if err != nil {
+		return nil, errors.Wrapf(err, "agent %s state variables", agentObj.ID())
+	}
+
+	stateVariables := map[string]openai.ChatSessionWorkflowParamStateVariableUnion{}
+	for name, value := range variables {
+		stateVariables[name] = openai.ChatSessionWorkflowParamStateVariableUnion{
+			OfString: openai.String(value),
+		}
+	}
+
+	client := openai.NewClient(
+		option.WithAPIKey(environment.GetConfig().AIKeys.OpenAI.APIKey),
+	)
+
+	resp, err := client.Beta.ChatKit.Sessions.New(ctx, openai.BetaChatKitSessionNewParams{
+		User: accountObj.ID().String(),
+		Workflow: openai.ChatSessionWorkflowParam{
+			ID:             settings.WorkflowID,
+			StateVariables: stateVariables,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conversationObj := conversation.New()
+	conversationObj.AccountID.Set(accountObj.ID())
+	conversationObj.OrganizationID.Set(accountObj.OrganizationID.Get())
+	conversationObj.AgentID.Set(agentObj.ID())
+	conversationObj.Source.Set(conversation.SOURCE_CHATKIT)
+	conversationObj.ExternalID.Set(resp.ID)
+	err = conversationObj.Save(accountObj)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook_service.PublishAndLog(ctx, conversationObj.OrganizationID.Get(), webhook_service.EVENT_CONVERSATION_STARTED,
+		webhook_service.NewConversationData(conversationObj))
+
+	return &ChatKitSession{
+		ClientSecret: resp.ClientSecret,
+		Conversation: conversationObj,
+	}, nil
+}
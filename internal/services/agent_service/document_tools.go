@@ -0,0 +1,114 @@
+package agent_service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/retrieval_service"
+	"github.com/pkg/errors"
+)
+
+// SEARCH_DOCUMENTS_TOOL is the function name assistants call to search the organization's documents
+const SEARCH_DOCUMENTS_TOOL = "search_documents"
+
+// SearchDocumentsToolDefinition is the function definition to add to an assistant that should search documents
+var SearchDocumentsToolDefinition = map[string]any{
+	"type": "function",
+	"function": map[string]any{
+		"name":        SEARCH_DOCUMENTS_TOOL,
+		"description": "Search the organization's documents and return the most relevant passages with their source",
+		"parameters": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "What to look for, exact product names and SKUs match as well as meaning",
+				},
+				"limit": map[string]any{
+					"type":        "integer",
+					"description": "How many passages to return, at most 20",
+				},
+			},
+			"required": []string{"query"},
+		},
+	},
+}
+
+type searchDocumentsArguments struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit"`
+}
+
+type searchDocumentsOutput struct {
+	Results []*retrieval_service.Result `json:"results"`
+}
+
+func init() {
+	RegisterAssistantTool(SEARCH_DOCUMENTS_TOOL, searchDocuments)
+}
+
+// searchDocuments runs a retrieval over the documents of the organization the conversation belongs to, limited to
+// the document groups the agent may use
+func searchDocuments(ctx context.Context, toolContext *AssistantToolContext, arguments json.RawMessage) (string, error) {
+	args := &searchDocumentsArguments{}
+	err := json.Unmarshal(arguments, args)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid arguments")
+	}
+	if toolContext.OrganizationID == "" {
+		return "", errors.New("documents can only be searched within an organization")
+	}
+
+	scope := document_chunk.ChunkScope{
+		OrganizationID: types.UUID(toolContext.OrganizationID),
+		AccountID:      types.UUID(toolContext.AccountID),
+		Limit:          args.Limit,
+	}
+
+	if toolContext.AgentID != "" {
+		agentObj, err := agent.Get(ctx, types.UUID(toolContext.AgentID))
+		if err != nil {
+			return "", err
+		}
+		if tools.Empty(agentObj) {
+			return "", errors.Errorf("agent %s not found", toolContext.AgentID)
+		}
+
+		groupIDs, restricted, err := document_service.AgentGroupIDs(ctx, agentObj, scope.OrganizationID)
+		if err != nil {
+			return "", err
+		}
+		if restricted && len(groupIDs) == 0 {
+			// the organization has none of the groups the agent may use
+			return marshalSearchDocumentsOutput([]*retrieval_service.Result{})
+		}
+		scope.DocumentGroupIDs = groupIDs
+	}
+
+	results, err := retrieval_service.Retrieve(ctx, &retrieval_service.Request{
+		ChunkScope: scope,
+		Query:      args.Query,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, result := range results {
+		toolContext.AddCitations(result.Citation())
+	}
+
+	return marshalSearchDocumentsOutput(results)
+}
+
+func marshalSearchDocumentsOutput(results []*retrieval_service.Result) (string, error) {
+	output, err := json.Marshal(&searchDocumentsOutput{Results: results})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(output), nil
+}
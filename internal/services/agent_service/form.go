@@ -0,0 +1,133 @@
+package agent_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/account"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/form_submission"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
+)
+
+// FORM_MAX_ATTEMPTS is the number of model calls made before a submission is marked failed
+const FORM_MAX_ATTEMPTS = 2
+
+// StructuredResponder produces schema constrained JSON, satisfied by *openai.Service
+type StructuredResponder interface {
+	CreateStructuredResponse(ctx context.Context, structuredRequest *openai.StructuredRequest) ([]byte, error)
+}
+
+// GetFormSettings returns the form definition for a single shot form agent
+func GetFormSettings(agentObj *agent.Agent) (*agent.FormSettings, error) {
+	if agentObj.Type.Get() != agent.AGENT_TYPE_SINGLE_SHOT_FORM {
+		return nil, errors.Errorf("agent %s is not a form agent", agentObj.ID())
+	}
+
+	settings, err := agentObj.Settings.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if settings == nil || settings.Form == nil || len(settings.Form.OutputSchema) == 0 {
+		return nil, errors.Errorf("agent %s has no form configured", agentObj.ID())
+	}
+
+	return settings.Form, nil
+}
+
+// RunForm stores a submission for already validated input, runs it through the model and stores the result.
+// The submission is returned even when generation fails so the caller can surface the stored error.
+func RunForm(
+	ctx context.Context,
+	responder StructuredResponder,
+	agentObj *agent.Agent,
+	accountObj *account.AccountWithFeatures,
+	input map[string]any,
+) (*form_submission.FormSubmission, error) {
+	formSettings, err := GetFormSettings(agentObj)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := agentObj.Settings.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	submission := form_submission.New()
+	submission.AgentID.Set(agentObj.ID())
+	submission.AccountID.Set(accountObj.ID())
+	submission.OrganizationID.Set(accountObj.OrganizationID.Get())
+	submission.Input.Set(input)
+	submission.Status.Set(form_submission.STATUS_PENDING)
+	err = submission.Save(accountObj)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := formSettings.RenderPrompt(input)
+	if err == nil {
+		var output map[string]any
+		var attempts int
+		output, attempts, err = generateFormOutput(ctx, responder, settings.Instructions, formSettings, prompt)
+		submission.Attempts.Set(attempts)
+		if err == nil {
+			submission.Output.Set(output)
+			submission.Status.Set(form_submission.STATUS_COMPLETED)
+		}
+	}
+
+	if err != nil {
+		submission.Error.Set(err.Error())
+		submission.Status.Set(form_submission.STATUS_FAILED)
+	}
+
+	saveErr := submission.Save(accountObj)
+	if saveErr != nil {
+		return nil, saveErr
+	}
+
+	return submission, err
+}
+
+// generateFormOutput calls the model, validating the result against the output schema and retrying with the validation error
+func generateFormOutput(
+	ctx context.Context,
+	responder StructuredResponder,
+	instructions string,
+	formSettings *agent.FormSettings,
+	prompt string,
+) (map[string]any, int, error) {
+	input := prompt
+	var lastErr error
+
+	for attempt := 1; attempt <= FORM_MAX_ATTEMPTS; attempt++ {
+		raw, err := responder.CreateStructuredResponse(ctx, &openai.StructuredRequest{
+			Model:        formSettings.Model,
+			Instructions: instructions,
+			Input:        input,
+			SchemaName:   "form_output",
+			Schema:       formSettings.OutputSchema,
+		})
+		if err != nil {
+			return nil, attempt, err
+		}
+
+		output := map[string]any{}
+		err = json.Unmarshal(raw, &output)
+		if err == nil {
+			err = ValidateSchema(formSettings.OutputSchema, output)
+		}
+		if err == nil {
+			return output, attempt, nil
+		}
+
+		lastErr = err
+		input = fmt.Sprintf("%s\n\nYour previous response was invalid (%s). Respond again with JSON that matches the schema exactly.", prompt, err.Error())
+	}
+
+	return nil, FORM_MAX_ATTEMPTS, errors.Wrap(lastErr, "model output did not match schema")
+}
@@ -0,0 +1,73 @@
+package agent_service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+)
+
+type fakeResponder struct {
+	responses []string
+	requests  []*openai.StructuredRequest
+}
+
+func (this *fakeResponder) CreateStructuredResponse(_ context.Context, structuredRequest *openai.StructuredRequest) ([]byte, error) {
+	this.requests = append(this.requests, structuredRequest)
+	response := this.responses[0]
+	this.responses = this.responses[1:]
+	return []byte(response), nil
+}
+
+func TestGenerateFormOutput(t *testing.T) {
+	formSettings := &agent.FormSettings{
+		OutputSchema: map[string]any{
+			"type":     "object",
+			"required": []any{"tagline"},
+			"properties": map[string]any{
+				"tagline": map[string]any{"type": "string"},
+			},
+		},
+	}
+
+	t.Run("valid on first attempt", func(t *testing.T) {
+		responder := &fakeResponder{responses: []string{`{"tagline": "Ship it"}`}}
+
+		output, attempts, err := generateFormOutput(context.Background(), responder, "", formSettings, "prompt")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if attempts != 1 || output["tagline"] != "Ship it" {
+			t.Errorf("unexpected result %v after %d attempts", output, attempts)
+		}
+	})
+
+	t.Run("retries once with the validation error", func(t *testing.T) {
+		responder := &fakeResponder{responses: []string{`{"tagline": 7}`, `{"tagline": "Ship it"}`}}
+
+		output, attempts, err := generateFormOutput(context.Background(), responder, "", formSettings, "prompt")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if attempts != 2 || output["tagline"] != "Ship it" {
+			t.Errorf("unexpected result %v after %d attempts", output, attempts)
+		}
+		if !strings.Contains(responder.requests[1].Input, "previous response was invalid") {
+			t.Errorf("expected retry prompt to include the validation error, got %q", responder.requests[1].Input)
+		}
+	})
+
+	t.Run("fails after max attempts", func(t *testing.T) {
+		responder := &fakeResponder{responses: []string{`{}`, `not json`}}
+
+		_, attempts, err := generateFormOutput(context.Background(), responder, "", formSettings, "prompt")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if attempts != FORM_MAX_ATTEMPTS {
+			t.Errorf("expected %d attempts, got %d", FORM_MAX_ATTEMPTS, attempts)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package agent_service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent_attribute"
+)
+
+// BuildInstructions combines the agent's base instructions with the account's saved attributes for that agent
+func BuildInstructions(ctx context.Context, agentObj *agent.Agent, accountID types.UUID) (string, error) {
+	settings, err := agentObj.Settings.Get()
+	if err != nil {
+		return "", err
+	}
+
+	attributePrompt, err := agent_attribute.GetPrompt(ctx, accountID, agentObj.ID())
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{}
+	if settings != nil && settings.Instructions != "" {
+		parts = append(parts, settings.Instructions)
+	}
+	if attributePrompt != "" {
+		parts = append(parts, attributePrompt)
+	}
+
+	return strings.Join(parts, "\n\n"), nil
+}
@@ -0,0 +1,132 @@
+package agent_service
+
+import (
+	"context"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/message"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+)
+
+// REPLY_HISTORY_LIMIT is how many stored messages are replayed to the model for a reply
+const REPLY_HISTORY_LIMIT = 20
+
+// Responder produces a plain text model response, satisfied by *openai.Service
+type Responder interface {
+	CreateResponse(ctx context.Context, responseRequest *openai.ResponseRequest) (*openai.ResponseResult, error)
+}
+
+// StreamingResponder produces a streamed plain text model response, satisfied by *openai.Service
+type StreamingResponder interface {
+	StreamResponse(ctx context.Context, responseRequest *openai.ResponseRequest, onDelta func(delta string) error) (*openai.ResponseResult, error)
+}
+
+// Reply stores the user's message, answers it with the agent's instructions and the stored history, and stores the reply
+func Reply(
+	ctx context.Context,
+	responder Responder,
+	agentObj *agent.Agent,
+	conversationObj *conversation.Conversation,
+	body string,
+) (*message.Message, error) {
+	responseRequest, err := prepareReply(ctx, agentObj, conversationObj, body)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := responder.CreateResponse(ctx, responseRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return saveReply(ctx, conversationObj, result)
+}
+
+// StreamReply stores the user's message and answers it like Reply, handing the text to onDelta as it
+// is generated. The full reply is stored once the response completes.
+func StreamReply(
+	ctx context.Context,
+	responder StreamingResponder,
+	agentObj *agent.Agent,
+	conversationObj *conversation.Conversation,
+	body string,
+	onDelta func(delta string) error,
+) (*message.Message, error) {
+	responseRequest, err := prepareReply(ctx, agentObj, conversationObj, body)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := responder.StreamResponse(ctx, responseRequest, onDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	return saveReply(ctx, conversationObj, result)
+}
+
+// prepareReply stores the user's message and builds the model request from the agent's settings and the history before it
+func prepareReply(
+	ctx context.Context,
+	agentObj *agent.Agent,
+	conversationObj *conversation.Conversation,
+	body string,
+) (*openai.ResponseRequest, error) {
+	settings, err := agentObj.Settings.Get()
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &agent.Settings{}
+	}
+
+	history, err := message.GetMessagesByConversationID(ctx, conversationObj.ID(), REPLY_HISTORY_LIMIT)
+	if err != nil {
+		return nil, err
+	}
+
+	userMessage := &message.Message{
+		ConversationID: conversationObj.ID(),
+		Body:           body,
+		Role:           message.ROLE_USER,
+	}
+	err = userMessage.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &openai.ResponseRequest{
+		Model:        settings.Model,
+		Instructions: settings.Instructions,
+		Messages:     append(historyToInput(history), &openai.InputMessage{Role: "user", Content: body}),
+	}, nil
+}
+
+// saveReply stores the model's answer in the conversation
+func saveReply(ctx context.Context, conversationObj *conversation.Conversation, result *openai.ResponseResult) (*message.Message, error) {
+	reply := &message.Message{
+		ConversationID: conversationObj.ID(),
+		Body:           result.Text,
+		Role:           message.ROLE_ASSISTANT,
+		Tokens:         result.OutputTokens,
+	}
+	err := reply.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func historyToInput(history []*message.Message) []*openai.InputMessage {
+	messages := []*openai.InputMessage{}
+	for _, historyMessage := range history {
+		role := "user"
+		if historyMessage.Role == message.ROLE_ASSISTANT {
+			role = "assistant"
+		}
+		messages = append(messages, &openai.InputMessage{Role: role, Content: historyMessage.Body})
+	}
+	return messages
+}
@@ -0,0 +1,132 @@
+package agent_service
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ValidateSchema checks a decoded JSON value against the subset of JSON schema used for structured outputs
+// (type, properties, required, additionalProperties, items and enum)
+func ValidateSchema(schema map[string]any, value any) error {
+	problems := validateNode(schema, value, "$")
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validateNode(schema map[string]any, value any, path string) []string {
+	if schema == nil {
+		return nil
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, value) {
+		return []string{fmt.Sprintf("%s must be one of %v", path, enum)}
+	}
+
+	types := schemaTypes(schema["type"])
+	if len(types) == 0 {
+		return nil
+	}
+
+	for _, typ := range types {
+		if matchesType(typ, value) {
+			switch typ {
+			case "object":
+				return validateObject(schema, value.(map[string]any), path)
+			case "array":
+				return validateArray(schema, value.([]any), path)
+			}
+			return nil
+		}
+	}
+
+	return []string{fmt.Sprintf("%s must be of type %s", path, strings.Join(types, " or "))}
+}
+
+func validateObject(schema map[string]any, obj map[string]any, path string) []string {
+	problems := []string{}
+	properties, _ := schema["properties"].(map[string]any)
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			key, _ := name.(string)
+			if _, exists := obj[key]; !exists {
+				problems = append(problems, fmt.Sprintf("%s.%s is required", path, key))
+			}
+		}
+	}
+
+	for key, child := range obj {
+		propertySchema, ok := properties[key].(map[string]any)
+		if !ok {
+			if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				problems = append(problems, fmt.Sprintf("%s.%s is not allowed", path, key))
+			}
+			continue
+		}
+		problems = append(problems, validateNode(propertySchema, child, path+"."+key)...)
+	}
+
+	slices.Sort(problems)
+	return problems
+}
+
+func validateArray(schema map[string]any, arr []any, path string) []string {
+	itemSchema, ok := schema["items"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	problems := []string{}
+	for i, child := range arr {
+		problems = append(problems, validateNode(itemSchema, child, fmt.Sprintf("%s[%d]", path, i))...)
+	}
+	return problems
+}
+
+func schemaTypes(raw any) []string {
+	switch typ := raw.(type) {
+	case string:
+		return []string{typ}
+	case []any:
+		types := []string{}
+		for _, t := range typ {
+			if str, ok := t.(string); ok {
+				types = append(types, str)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesType(typ string, value any) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		num, ok := value.(float64)
+		return ok && num == math.Trunc(num)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package agent_service
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	schema := map[string]any{}
+	err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["title", "score", "tags"],
+		"properties": {
+			"title": {"type": "string"},
+			"score": {"type": "integer"},
+			"tone": {"type": ["string", "null"], "enum": ["formal", "casual", null]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid", `{"title": "Hello", "score": 3, "tone": null, "tags": ["a", "b"]}`, false},
+		{"missing required", `{"title": "Hello", "tags": []}`, true},
+		{"wrong type", `{"title": 1, "score": 3, "tags": []}`, true},
+		{"non integer", `{"title": "Hello", "score": 3.5, "tags": []}`, true},
+		{"bad enum", `{"title": "Hello", "score": 3, "tone": "angry", "tags": []}`, true},
+		{"bad array item", `{"title": "Hello", "score": 3, "tags": [1]}`, true},
+		{"additional property", `{"title": "Hello", "score": 3, "tags": [], "extra": true}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatal(err)
+			}
+
+			err := ValidateSchema(schema, value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
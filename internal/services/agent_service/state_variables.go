@@ -0,0 +1,129 @@
+package agent_service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/account"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent_attribute"
+	"github.com/griffnb/techboss-ai-go/internal/models/organization"
+	"github.com/pkg/errors"
+)
+
+// StateVariableSources are the fixed sources an agent's state variable mapping can reference.
+// Onboarding answers and attribute variables are also available by key as
+// organization.onboard_answers.<key> and attributes.variables.<key>.
+var StateVariableSources = []string{
+	"account.id",
+	"account.first_name",
+	"account.last_name",
+	"account.email",
+	"organization.id",
+	"organization.name",
+	"organization.onboard_answers",
+	"plan.name",
+	"attributes.preferred_tone",
+	"attributes.prompt",
+}
+
+var dynamicStateVariablePrefixes = []string{
+	"organization.onboard_answers.",
+	"attributes.variables.",
+}
+
+// DefaultStateVariables is used for agents without a mapping and matches the variables ChatKit workflows were built against
+var DefaultStateVariables = map[string]string{
+	"organization_id":      "organization.id",
+	"account_id":           "account.id",
+	"business_information": "organization.onboard_answers",
+}
+
+// StateValues flattens the session's account, organization and agent attributes into source keys
+func StateValues(accountObj *account.AccountWithFeatures, orgObj *organization.Organization, attributes *agent_attribute.Attributes) map[string]string {
+	values := map[string]string{
+		"account.id":         accountObj.ID().String(),
+		"account.first_name": accountObj.FirstName.Get(),
+		"account.last_name":  accountObj.LastName.Get(),
+		"account.email":      accountObj.Email.Get(),
+		"plan.name":          accountObj.BillingPlanName.Get(),
+	}
+
+	if orgObj != nil {
+		values["organization.id"] = orgObj.ID().String()
+		values["organization.name"] = orgObj.Name.Get()
+
+		metaData := orgObj.MetaData.GetI()
+		if metaData != nil {
+			values["organization.onboard_answers"] = metaData.GetOnboardAnswersString()
+			for key, answer := range metaData.OnboardAnswers {
+				values["organization.onboard_answers."+key] = stringifyStateValue(answer)
+			}
+		}
+	}
+
+	if attributes != nil {
+		values["attributes.preferred_tone"] = attributes.PreferredTone
+		values["attributes.prompt"] = attributes.ToPrompt()
+		for key, value := range attributes.Variables {
+			values["attributes.variables."+key] = value
+		}
+	}
+
+	return values
+}
+
+// ResolveStateVariables maps each state variable to its source value.
+// Unknown sources are an error so a misconfigured agent fails loudly instead of sending empty context.
+func ResolveStateVariables(mapping map[string]string, values map[string]string) (map[string]string, error) {
+	if len(mapping) == 0 {
+		mapping = DefaultStateVariables
+	}
+
+	resolved := map[string]string{}
+	problems := []string{}
+	for name, source := range mapping {
+		if !isStateVariableSource(source) {
+			problems = append(problems, fmt.Sprintf("%s has unknown source %s", name, source))
+			continue
+		}
+		resolved[name] = values[source]
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+
+	return resolved, nil
+}
+
+func isStateVariableSource(source string) bool {
+	for _, known := range StateVariableSources {
+		if source == known {
+			return true
+		}
+	}
+	for _, prefix := range dynamicStateVariablePrefixes {
+		if strings.HasPrefix(source, prefix) && len(source) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringifyStateValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	default:
+		b, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprintf("%v", typed)
+		}
+		return string(b)
+	}
+}
@@ -0,0 +1,49 @@
+package agent_service
+
+import (
+	"testing"
+)
+
+func TestResolveStateVariables(t *testing.T) {
+	values := map[string]string{
+		"account.id":                            "acct-1",
+		"organization.id":                       "org-1",
+		"organization.onboard_answers":          `{"industry": "retail"}`,
+		"organization.onboard_answers.industry": "retail",
+		"attributes.variables.region":           "EMEA",
+	}
+
+	t.Run("defaults when no mapping", func(t *testing.T) {
+		resolved, err := ResolveStateVariables(nil, values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolved["organization_id"] != "org-1" || resolved["account_id"] != "acct-1" || resolved["business_information"] == "" {
+			t.Errorf("unexpected default variables %v", resolved)
+		}
+	})
+
+	t.Run("custom mapping with dynamic keys", func(t *testing.T) {
+		resolved, err := ResolveStateVariables(map[string]string{
+			"industry": "organization.onboard_answers.industry",
+			"region":   "attributes.variables.region",
+			"tone":     "attributes.preferred_tone",
+		}, values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resolved["industry"] != "retail" || resolved["region"] != "EMEA" {
+			t.Errorf("unexpected variables %v", resolved)
+		}
+		if value, ok := resolved["tone"]; !ok || value != "" {
+			t.Errorf("expected known but unset source to resolve to empty string, got %q", value)
+		}
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		_, err := ResolveStateVariables(map[string]string{"secret": "account.hashed_password"}, values)
+		if err == nil {
+			t.Fatal("expected unknown source error")
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package agent_service
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/organization"
+	"github.com/griffnb/techboss-ai-go/internal/models/widget_lead"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrWidgetNotFound is returned for unknown or disabled widget ids, and for agents their organization may not use
+	ErrWidgetNotFound = errors.New("widget not found")
+	// ErrWidgetOriginDenied is returned when the request origin is not on the widget's allowlist
+	ErrWidgetOriginDenied = errors.New("widget origin not allowed")
+	// ErrWidgetConversationNotFound is returned when a visitor token does not match the conversation
+	ErrWidgetConversationNotFound = errors.New("widget conversation not found")
+)
+
+// WidgetLeadInput is the contact information a visitor leaves in the widget
+type WidgetLeadInput struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+}
+
+// GetWidgetAgent loads the agent publishing the widget, checks its organization may still use it and checks the
+// request origin against its allowlist
+func GetWidgetAgent(ctx context.Context, widgetID string, origin string) (*agent.Agent, *agent.WidgetSettings, error) {
+	if !strings.HasPrefix(widgetID, agent.WIDGET_ID_PREFIX) {
+		return nil, nil, ErrWidgetNotFound
+	}
+
+	agentObj, err := agent.GetByWidgetID(ctx, widgetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if agentObj == nil {
+		return nil, nil, ErrWidgetNotFound
+	}
+
+	settings, err := agentObj.Settings.Get()
+	if err != nil {
+		return nil, nil, err
+	}
+	if settings == nil || settings.Widget == nil || !settings.Widget.Enabled {
+		return nil, nil, ErrWidgetNotFound
+	}
+	if tools.Empty(settings.Widget.OrganizationID) {
+		return nil, nil, ErrWidgetNotFound
+	}
+
+	// the widget answers on behalf of its organization, so it stops once the organization may no longer use the agent
+	planLevel, err := organization.GetPlanLevel(ctx, settings.Widget.OrganizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !agentObj.CanBeUsedBy(settings.Widget.OrganizationID, planLevel) {
+		return nil, nil, ErrWidgetNotFound
+	}
+
+	if !settings.Widget.AllowsOrigin(origin) {
+		return nil, nil, ErrWidgetOriginDenied
+	}
+
+	return agentObj, settings.Widget, nil
+}
+
+// StartWidgetConversation records an anonymous visitor conversation and returns it with the visitor token
+// the widget must present on later calls. Only a hash of the token is stored.
+func StartWidgetConversation(
+	ctx context.Context,
+	agentObj *agent.Agent,
+	widget *agent.WidgetSettings,
+) (*conversation.Conversation, string, error) {
+	visitorToken := tools.SessionKey()
+
+	conversationObj := conversation.New()
+	conversationObj.AgentID.Set(agentObj.ID())
+	conversationObj.OrganizationID.Set(widget.OrganizationID)
+	conversationObj.Source.Set(conversation.SOURCE_WIDGET)
+	conversationObj.ExternalID.Set(tools.Sha1(visitorToken))
+	err := conversationObj.Save(nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	webhook_service.PublishAndLog(ctx, widget.OrganizationID, webhook_service.EVENT_CONVERSATION_STARTED,
+		webhook_service.NewConversationData(conversationObj))
+
+	return conversationObj, visitorToken, nil
+}
+
+// GetWidgetConversation loads a visitor conversation, checking it belongs to the agent and matches the visitor token
+func GetWidgetConversation(
+	ctx context.Context,
+	agentObj *agent.Agent,
+	conversationID types.UUID,
+	visitorToken string,
+) (*conversation.Conversation, error) {
+	if tools.Empty(conversationID) || tools.Empty(visitorToken) {
+		return nil, ErrWidgetConversationNotFound
+	}
+
+	conversationObj, err := conversation.Get(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tools.Empty(conversationObj) ||
+		conversationObj.Source.Get() != conversation.SOURCE_WIDGET ||
+		conversationObj.AgentID.Get() != agentObj.ID() ||
+		conversationObj.ExternalID.Get() != tools.Sha1(visitorToken) {
+		return nil, ErrWidgetConversationNotFound
+	}
+
+	return conversationObj, nil
+}
+
+// CaptureWidgetLead creates or updates the organization's lead for the visitor's email and links it to the conversation
+func CaptureWidgetLead(
+	ctx context.Context,
+	agentObj *agent.Agent,
+	conversationObj *conversation.Conversation,
+	input *WidgetLeadInput,
+) (*widget_lead.WidgetLead, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(input.Email))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid email %s", input.Email)
+	}
+	email := strings.ToLower(address.Address)
+
+	leadObj, err := widget_lead.GetByOrganizationAndEmail(ctx, conversationObj.OrganizationID.Get(), email)
+	if err != nil {
+		return nil, err
+	}
+	if leadObj == nil {
+		leadObj = widget_lead.New()
+		leadObj.OrganizationID.Set(conversationObj.OrganizationID.Get())
+		leadObj.AgentID.Set(agentObj.ID())
+		leadObj.Email.Set(email)
+	}
+	if !tools.Empty(input.Name) {
+		leadObj.Name.Set(input.Name)
+	}
+	if !tools.Empty(input.Phone) {
+		leadObj.Phone.Set(input.Phone)
+	}
+
+	err = leadObj.Save(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conversationObj.WidgetLeadID.Set(leadObj.ID())
+	err = conversationObj.Save(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook_service.PublishAndLog(ctx, conversationObj.OrganizationID.Get(), webhook_service.EVENT_LEAD_CAPTURED,
+		webhook_service.NewLeadData(leadObj, conversationObj))
+
+	return leadObj, nil
+}
@@ -49,6 +49,12 @@ func (c *Client) WithBaseURL(baseURL string) *Client {
 	return c
 }
 
+// WithHTTPClient allows injecting the http client, e.g. one backed by a recording transport in tests
+func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
+	c.httpClient = httpClient
+	return c
+}
+
 // WithTimeout allows setting a custom timeout
 func (c *Client) WithTimeout(timeout time.Duration) *Client {
 	c.httpClient.Timeout = timeout
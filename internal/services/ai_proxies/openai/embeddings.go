@@ -0,0 +1,102 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultEmbeddingModel is used for embedding requests that do not specify a model
+const DefaultEmbeddingModel = "text-embedding-3-small"
+
+// EmbeddingRequest embeds a batch of texts, Dimensions of 0 keeps the model's own size
+type EmbeddingRequest struct {
+	Model      string
+	Input      []string
+	Dimensions int
+}
+
+type embeddingsBody struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateEmbeddings calls the embeddings API and returns one vector per input, in input order
+func (c *Client) CreateEmbeddings(ctx context.Context, embeddingRequest *EmbeddingRequest) (vectors [][]float32, err error) {
+	model := embeddingRequest.Model
+	if model == "" {
+		model = DefaultEmbeddingModel
+	}
+	data := map[string]any{
+		"model":           model,
+		"input":           embeddingRequest.Input,
+		"encoding_format": "float",
+	}
+	if embeddingRequest.Dimensions > 0 {
+		data["dimensions"] = embeddingRequest.Dimensions
+	}
+
+	requestBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/embeddings", bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make request to OpenAI")
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close response body")
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	parsed := &embeddingsBody{}
+	if err := json.Unmarshal(body, parsed); err != nil {
+		return nil, errors.Wrap(err, "failed to parse response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if parsed.Error != nil {
+			return nil, errors.Errorf("openai returned %d: %s", resp.StatusCode, parsed.Error.Message)
+		}
+		return nil, errors.Errorf("openai returned %d", resp.StatusCode)
+	}
+
+	vectors = make([][]float32, len(embeddingRequest.Input))
+	for _, item := range parsed.Data {
+		if item.Index < 0 || item.Index >= len(vectors) {
+			return nil, errors.Errorf("embedding index %d out of range", item.Index)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	for i, vector := range vectors {
+		if len(vector) == 0 {
+			return nil, errors.Errorf("no embedding returned for input %d", i)
+		}
+	}
+
+	return vectors, nil
+}
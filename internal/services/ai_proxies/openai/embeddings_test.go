@@ -0,0 +1,57 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateEmbeddings(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("Expected path /embeddings, got %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		// Returned out of order to check vectors are matched to inputs by index
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0.3,0.4]},{"index":0,"embedding":[0.1,0.2]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key").WithBaseURL(server.URL)
+	vectors, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{
+		Input:      []string{"first", "second"},
+		Dimensions: 2,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(vectors) != 2 || vectors[0][0] != 0.1 || vectors[1][1] != 0.4 {
+		t.Errorf("Unexpected vectors %v", vectors)
+	}
+	if received["model"] != DefaultEmbeddingModel {
+		t.Errorf("Expected default model, got %v", received["model"])
+	}
+	if received["dimensions"] != float64(2) {
+		t.Errorf("Expected dimensions 2, got %v", received["dimensions"])
+	}
+}
+
+func TestCreateEmbeddingsMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"index":0,"embedding":[0.1]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key").WithBaseURL(server.URL)
+	_, err := client.CreateEmbeddings(context.Background(), &EmbeddingRequest{Input: []string{"a", "b"}})
+	if err == nil {
+		t.Fatal("Expected error when an input has no embedding")
+	}
+}
@@ -0,0 +1,160 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/recorder"
+)
+
+// newFixtureClient returns a client backed by a golden file in testdata/fixtures.
+// Run with RECORD_FIXTURES=1 and OPENAI_API_KEY set to re-record against the live API.
+func newFixtureClient(t *testing.T, name string) *Client {
+	t.Helper()
+
+	mode := recorder.ModeFromEnv()
+	transport, err := recorder.New(filepath.Join("testdata", "fixtures", name+".json"), mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := transport.Save(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	apiKey := "test-key"
+	if mode == recorder.MODE_RECORD {
+		apiKey = os.Getenv("OPENAI_API_KEY")
+	}
+
+	return NewClient(apiKey).WithHTTPClient(transport.Client())
+}
+
+func TestFixtureCreateResponseUsage(t *testing.T) {
+	client := newFixtureClient(t, "responses_usage")
+
+	result, err := client.CreateResponse(context.Background(), &ResponseRequest{
+		Instructions: "Be concise.",
+		Messages:     []*InputMessage{{Role: "user", Content: "Say hello in five words."}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Model != "gpt-4.1-mini-2025-04-14" {
+		t.Errorf("Expected dated model, got %s", result.Model)
+	}
+	if result.InputTokens != 21 || result.OutputTokens != 8 {
+		t.Errorf("Expected usage 21/8, got %d/%d", result.InputTokens, result.OutputTokens)
+	}
+	if result.Text == "" {
+		t.Error("Expected output text")
+	}
+}
+
+func TestFixtureCreateResponseError(t *testing.T) {
+	client := newFixtureClient(t, "responses_error")
+
+	_, err := client.CreateResponse(context.Background(), &ResponseRequest{
+		Model:    "gpt-nonexistent",
+		Messages: []*InputMessage{{Role: "user", Content: "hi"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected provider error message, got %v", err)
+	}
+}
+
+func TestFixtureProxyStreamRequest(t *testing.T) {
+	client := newFixtureClient(t, "responses_stream")
+	writer := httptest.NewRecorder()
+
+	err := client.ProxyStreamRequest(context.Background(), []byte(`{"model":"gpt-4.1-mini","input":"Count to three."}`), writer)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if writer.Header().Get("Content-Type") != ContentTypeSSE {
+		t.Errorf("Expected SSE content type, got %s", writer.Header().Get("Content-Type"))
+	}
+
+	body := writer.Body.String()
+	for _, expected := range []string{"event: response.created", `"delta":"1, 2"`, `"delta":", 3"`, "event: response.completed"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected streamed body to contain %q", expected)
+		}
+	}
+
+	if strings.Index(body, `"delta":"1, 2"`) > strings.Index(body, `"delta":", 3"`) {
+		t.Error("Expected deltas to be streamed in order")
+	}
+}
+
+func TestFixtureProxyRequestToolLoop(t *testing.T) {
+	client := newFixtureClient(t, "responses_tool_loop")
+
+	tools := []map[string]any{{
+		"type":        "function",
+		"name":        "get_weather",
+		"description": "Current weather for a city",
+		"strict":      true,
+		"parameters": map[string]any{
+			"type":                 "object",
+			"properties":           map[string]any{"city": map[string]any{"type": "string"}},
+			"required":             []string{"city"},
+			"additionalProperties": false,
+		},
+	}}
+	input := []any{map[string]any{"role": "user", "content": "What is the weather in Paris?"}}
+	calls := []string{}
+
+	answer := ""
+	for round := 0; round < 3 && answer == ""; round++ {
+		requestBody, _ := json.Marshal(map[string]any{"model": "gpt-4.1-mini", "input": input, "tools": tools})
+		writer := httptest.NewRecorder()
+		if err := client.ProxyRequest(context.Background(), requestBody, writer); err != nil {
+			t.Fatalf("Unexpected error in round %d: %v", round, err)
+		}
+
+		parsed := struct {
+			Output []struct {
+				Type      string `json:"type"`
+				CallID    string `json:"call_id"`
+				Name      string `json:"name"`
+				Arguments string `json:"arguments"`
+				Content   []struct {
+					Text string `json:"text"`
+				} `json:"content"`
+			} `json:"output"`
+		}{}
+		if err := json.Unmarshal(writer.Body.Bytes(), &parsed); err != nil {
+			t.Fatalf("Unexpected body in round %d: %s", round, writer.Body.String())
+		}
+
+		for _, output := range parsed.Output {
+			switch output.Type {
+			case "function_call":
+				calls = append(calls, output.Name+" "+output.Arguments)
+				input = append(input,
+					map[string]any{"type": "function_call", "call_id": output.CallID, "name": output.Name, "arguments": output.Arguments},
+					map[string]any{"type": "function_call_output", "call_id": output.CallID, "output": `{"conditions":"light rain","temperature_c":18}`},
+				)
+			case "message":
+				answer = output.Content[0].Text
+			}
+		}
+	}
+
+	if len(calls) != 1 || calls[0] != `get_weather {"city":"Paris"}` {
+		t.Errorf("Expected one get_weather call for Paris, got %v", calls)
+	}
+	if !strings.Contains(answer, "light rain") {
+		t.Errorf("Expected the answer to use the tool output, got %q", answer)
+	}
+}
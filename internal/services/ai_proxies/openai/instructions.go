@@ -0,0 +1,31 @@
+package openai
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// MergeInstructions prepends server side instructions to the instructions already present in a responses request body
+func MergeInstructions(requestBody []byte, instructions string) ([]byte, error) {
+	if instructions == "" {
+		return requestBody, nil
+	}
+
+	var requestData map[string]any
+	if err := json.Unmarshal(requestBody, &requestData); err != nil {
+		return nil, errors.Wrap(err, "failed to parse request body")
+	}
+
+	if existing, ok := requestData["instructions"].(string); ok && existing != "" {
+		instructions = instructions + "\n\n" + existing
+	}
+	requestData["instructions"] = instructions
+
+	modifiedRequestBody, err := json.Marshal(requestData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal modified request body")
+	}
+
+	return modifiedRequestBody, nil
+}
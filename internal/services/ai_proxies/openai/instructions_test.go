@@ -0,0 +1,41 @@
+package openai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMergeInstructions(t *testing.T) {
+	t.Run("empty instructions leave the body untouched", func(t *testing.T) {
+		body := []byte(`{"model":"gpt-4o"}`)
+		merged, err := MergeInstructions(body, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(merged) != string(body) {
+			t.Errorf("Expected %s, got %s", body, merged)
+		}
+	})
+
+	t.Run("instructions are prepended to existing ones", func(t *testing.T) {
+		merged, err := MergeInstructions([]byte(`{"model":"gpt-4o","instructions":"client"}`), "server")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var data map[string]any
+		if err := json.Unmarshal(merged, &data); err != nil {
+			t.Fatal(err)
+		}
+		if data["instructions"] != "server\n\nclient" {
+			t.Errorf("Expected merged instructions, got %v", data["instructions"])
+		}
+	})
+
+	t.Run("invalid json returns an error", func(t *testing.T) {
+		_, err := MergeInstructions([]byte(`{`), "server")
+		if err == nil {
+			t.Error("Expected error for invalid json")
+		}
+	})
+}
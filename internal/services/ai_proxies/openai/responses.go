@@ -0,0 +1,246 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultModel is used for server side requests that do not specify a model
+const DefaultModel = "gpt-4.1-mini"
+
+// InputMessage is a single conversation message sent to the responses API
+type InputMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ResponseRequest is a non streaming responses API request made by the server
+type ResponseRequest struct {
+	Model        string
+	Instructions string
+	Messages     []*InputMessage
+	Format       map[string]any // optional text.format, e.g. a json_schema definition
+}
+
+// ResponseResult is the text and usage returned by the responses API
+type ResponseResult struct {
+	Model        string
+	Text         string
+	InputTokens  int
+	OutputTokens int
+}
+
+type responsesBody struct {
+	Model  string `json:"model"`
+	Output []struct {
+		Type    string `json:"type"`
+		Content []struct {
+			Type    string `json:"type"`
+			Text    string `json:"text"`
+			Refusal string `json:"refusal"`
+		} `json:"content"`
+	} `json:"output"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateResponse calls the responses API and returns the first output text along with token usage
+func (c *Client) CreateResponse(ctx context.Context, responseRequest *ResponseRequest) (result *ResponseResult, err error) {
+	requestBody, err := json.Marshal(responseRequestData(responseRequest))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/responses", bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make request to OpenAI")
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close response body")
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	parsed := &responsesBody{}
+	if err := json.Unmarshal(body, parsed); err != nil {
+		return nil, errors.Wrap(err, "failed to parse response body")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if parsed.Error != nil {
+			return nil, errors.Errorf("openai returned %d: %s", resp.StatusCode, parsed.Error.Message)
+		}
+		return nil, errors.Errorf("openai returned %d", resp.StatusCode)
+	}
+
+	for _, output := range parsed.Output {
+		if output.Type != "message" {
+			continue
+		}
+		for _, content := range output.Content {
+			switch content.Type {
+			case "output_text":
+				return &ResponseResult{
+					Model:        parsed.Model,
+					Text:         content.Text,
+					InputTokens:  parsed.Usage.InputTokens,
+					OutputTokens: parsed.Usage.OutputTokens,
+				}, nil
+			case "refusal":
+				return nil, errors.Errorf("model refused: %s", content.Refusal)
+			}
+		}
+	}
+
+	return nil, errors.New("no output text in response")
+}
+
+// streamEvent is the subset of responses API stream events the server reads
+type streamEvent struct {
+	Type     string `json:"type"`
+	Delta    string `json:"delta"`
+	Message  string `json:"message"`
+	Response *struct {
+		Model string `json:"model"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"response"`
+}
+
+// StreamResponse calls the responses API with streaming and hands each text delta to onDelta as it arrives.
+// The returned result carries the full text and the usage from the completed event.
+func (c *Client) StreamResponse(
+	ctx context.Context,
+	responseRequest *ResponseRequest,
+	onDelta func(delta string) error,
+) (result *ResponseResult, err error) {
+	requestData := responseRequestData(responseRequest)
+	requestData["stream"] = true
+
+	requestBody, err := json.Marshal(requestData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/responses", bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	req.Header.Set("Content-Type", ContentTypeJSON)
+	req.Header.Set("Accept", ContentTypeSSE)
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make request to OpenAI")
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			err = errors.Wrap(closeErr, "failed to close response body")
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		parsed := &responsesBody{}
+		if json.Unmarshal(body, parsed) == nil && parsed.Error != nil {
+			return nil, errors.Errorf("openai returned %d: %s", resp.StatusCode, parsed.Error.Message)
+		}
+		return nil, errors.Errorf("openai returned %d", resp.StatusCode)
+	}
+
+	text := &strings.Builder{}
+	result = &ResponseResult{Model: responseRequest.Model}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok || data == "[DONE]" {
+			continue
+		}
+
+		event := &streamEvent{}
+		if err := json.Unmarshal([]byte(data), event); err != nil {
+			return nil, errors.Wrap(err, "failed to parse stream event")
+		}
+
+		switch event.Type {
+		case "response.output_text.delta":
+			text.WriteString(event.Delta)
+			if err := onDelta(event.Delta); err != nil {
+				return nil, err
+			}
+		case "response.completed":
+			if event.Response != nil {
+				result.Model = event.Response.Model
+				result.InputTokens = event.Response.Usage.InputTokens
+				result.OutputTokens = event.Response.Usage.OutputTokens
+			}
+		case "response.failed":
+			if event.Response != nil && event.Response.Error != nil {
+				return nil, errors.Errorf("openai response failed: %s", event.Response.Error.Message)
+			}
+			return nil, errors.New("openai response failed")
+		case "error":
+			return nil, errors.Errorf("openai stream error: %s", event.Message)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read stream")
+	}
+
+	result.Text = text.String()
+	return result, nil
+}
+
+// responseRequestData builds the responses API request body shared by the streaming and non streaming calls
+func responseRequestData(responseRequest *ResponseRequest) map[string]any {
+	model := responseRequest.Model
+	if model == "" {
+		model = DefaultModel
+	}
+
+	requestData := map[string]any{
+		"model": model,
+		"input": responseRequest.Messages,
+	}
+	if responseRequest.Instructions != "" {
+		requestData["instructions"] = responseRequest.Instructions
+	}
+	if responseRequest.Format != nil {
+		requestData["text"] = map[string]any{"format": responseRequest.Format}
+	}
+	return requestData
+}
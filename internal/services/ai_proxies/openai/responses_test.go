@@ -0,0 +1,106 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateResponse(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		_, _ = w.Write([]byte(`{"model":"gpt-4.1-mini-2025","output":[{"type":"reasoning"},{"type":"message","content":[{"type":"output_text","text":"Hello"}]}],"usage":{"input_tokens":12,"output_tokens":3}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key").WithBaseURL(server.URL)
+	result, err := client.CreateResponse(context.Background(), &ResponseRequest{
+		Instructions: "be brief",
+		Messages:     []*InputMessage{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Text != "Hello" || result.InputTokens != 12 || result.OutputTokens != 3 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	if received["instructions"] != "be brief" {
+		t.Errorf("Expected instructions to be sent, got %v", received["instructions"])
+	}
+
+	if _, ok := received["text"]; ok {
+		t.Errorf("Expected no text format when none is requested")
+	}
+}
+
+func TestStreamResponse(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", ContentTypeSSE)
+		_, _ = w.Write([]byte(strings.Join([]string{
+			"event: response.created",
+			`data: {"type":"response.created","response":{"model":"gpt-4.1-mini-2025"}}`,
+			"",
+			"event: response.output_text.delta",
+			`data: {"type":"response.output_text.delta","delta":"Hel"}`,
+			"",
+			"event: response.output_text.delta",
+			`data: {"type":"response.output_text.delta","delta":"lo"}`,
+			"",
+			"event: response.completed",
+			`data: {"type":"response.completed","response":{"model":"gpt-4.1-mini-2025","usage":{"input_tokens":12,"output_tokens":3}}}`,
+			"",
+		}, "\n")))
+	}))
+	defer server.Close()
+
+	deltas := []string{}
+	client := NewClient("test-key").WithBaseURL(server.URL)
+	result, err := client.StreamResponse(context.Background(), &ResponseRequest{
+		Messages: []*InputMessage{{Role: "user", Content: "hi"}},
+	}, func(delta string) error {
+		deltas = append(deltas, delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if received["stream"] != true {
+		t.Errorf("Expected stream to be requested, got %v", received["stream"])
+	}
+
+	if strings.Join(deltas, "|") != "Hel|lo" {
+		t.Errorf("Unexpected deltas %v", deltas)
+	}
+
+	if result.Text != "Hello" || result.Model != "gpt-4.1-mini-2025" || result.InputTokens != 12 || result.OutputTokens != 3 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestStreamResponseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentTypeSSE)
+		_, _ = w.Write([]byte(`data: {"type":"response.failed","response":{"error":{"message":"overloaded"}}}` + "\n\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key").WithBaseURL(server.URL)
+	_, err := client.StreamResponse(context.Background(), &ResponseRequest{}, func(string) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "overloaded") {
+		t.Errorf("Expected failed response error, got %v", err)
+	}
+}
@@ -69,6 +69,60 @@ func (s *Service) ProxyStreaming(ctx context.Context, request *http.Request, res
 	return s.client.ProxyStreamRequest(ctx, requestBody, responseWriter)
 }
 
+// ProxyNonStreamingWithInstructions proxies a non-streaming request to OpenAI after merging in server side instructions
+func (s *Service) ProxyNonStreamingWithInstructions(ctx context.Context, request *http.Request, responseWriter http.ResponseWriter, instructions string) error {
+	requestBody, err := readInstructedBody(ctx, request, instructions)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ProxyRequest(ctx, requestBody, responseWriter)
+}
+
+// ProxyStreamingWithInstructions proxies a streaming request to OpenAI after merging in server side instructions
+func (s *Service) ProxyStreamingWithInstructions(ctx context.Context, request *http.Request, responseWriter http.ResponseWriter, instructions string) error {
+	requestBody, err := readInstructedBody(ctx, request, instructions)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ProxyStreamRequest(ctx, requestBody, responseWriter)
+}
+
+func readInstructedBody(ctx context.Context, request *http.Request, instructions string) ([]byte, error) {
+	requestBody, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request body")
+	}
+	defer func() {
+		if closeErr := request.Body.Close(); closeErr != nil {
+			log.ErrorContext(closeErr, ctx)
+		}
+	}()
+
+	return MergeInstructions(requestBody, instructions)
+}
+
+// CreateResponse runs a single non streaming request and returns the output text with usage
+func (s *Service) CreateResponse(ctx context.Context, responseRequest *ResponseRequest) (*ResponseResult, error) {
+	return s.client.CreateResponse(ctx, responseRequest)
+}
+
+// StreamResponse runs a single streaming request, passing text deltas to onDelta as they arrive
+func (s *Service) StreamResponse(ctx context.Context, responseRequest *ResponseRequest, onDelta func(delta string) error) (*ResponseResult, error) {
+	return s.client.StreamResponse(ctx, responseRequest, onDelta)
+}
+
+// CreateStructuredResponse runs a single shot request constrained to a JSON schema
+func (s *Service) CreateStructuredResponse(ctx context.Context, structuredRequest *StructuredRequest) ([]byte, error) {
+	return s.client.CreateStructuredResponse(ctx, structuredRequest)
+}
+
+// CreateEmbeddings embeds a batch of texts
+func (s *Service) CreateEmbeddings(ctx context.Context, embeddingRequest *EmbeddingRequest) ([][]float32, error) {
+	return s.client.CreateEmbeddings(ctx, embeddingRequest)
+}
+
 // GetClient returns the underlying client for advanced use cases
 func (s *Service) GetClient() *Client {
 	return s.client
@@ -0,0 +1,42 @@
+package openai
+
+import (
+	"context"
+)
+
+// DefaultStructuredModel is used for structured requests that do not specify a model
+const DefaultStructuredModel = DefaultModel
+
+// StructuredRequest is a single shot responses request constrained to a JSON schema
+type StructuredRequest struct {
+	Model        string
+	Instructions string
+	Input        string
+	SchemaName   string
+	Schema       map[string]any
+}
+
+// CreateStructuredResponse calls the responses API in structured output mode and returns the raw JSON text the model produced
+func (c *Client) CreateStructuredResponse(ctx context.Context, structuredRequest *StructuredRequest) ([]byte, error) {
+	schemaName := structuredRequest.SchemaName
+	if schemaName == "" {
+		schemaName = "output"
+	}
+
+	result, err := c.CreateResponse(ctx, &ResponseRequest{
+		Model:        structuredRequest.Model,
+		Instructions: structuredRequest.Instructions,
+		Messages:     []*InputMessage{{Role: "user", Content: structuredRequest.Input}},
+		Format: map[string]any{
+			"type":   "json_schema",
+			"name":   schemaName,
+			"schema": structuredRequest.Schema,
+			"strict": true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(result.Text), nil
+}
@@ -0,0 +1,60 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateStructuredResponse(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/responses" {
+			t.Errorf("Expected path /responses, got %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", ContentTypeJSON)
+		_, _ = w.Write([]byte(`{"output":[{"type":"message","content":[{"type":"output_text","text":"{\"title\":\"Hi\"}"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key").WithBaseURL(server.URL)
+	output, err := client.CreateStructuredResponse(context.Background(), &StructuredRequest{
+		Input:  "say hi",
+		Schema: map[string]any{"type": "object"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if string(output) != `{"title":"Hi"}` {
+		t.Errorf("Unexpected output %s", output)
+	}
+
+	if received["model"] != DefaultStructuredModel {
+		t.Errorf("Expected default model, got %v", received["model"])
+	}
+
+	format := received["text"].(map[string]any)["format"].(map[string]any)
+	if format["type"] != "json_schema" || format["strict"] != true {
+		t.Errorf("Expected strict json_schema format, got %v", format)
+	}
+}
+
+func TestCreateStructuredResponseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"invalid schema"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key").WithBaseURL(server.URL)
+	_, err := client.CreateStructuredResponse(context.Background(), &StructuredRequest{Input: "x"})
+	if err == nil {
+		t.Fatal("Expected error for bad request")
+	}
+}
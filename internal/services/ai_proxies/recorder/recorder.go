@@ -0,0 +1,305 @@
+// Package recorder provides an http.RoundTripper that records provider traffic to golden files
+// and replays it in tests, so provider clients can be exercised without network access.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type Mode int
+
+const (
+	MODE_REPLAY Mode = iota
+	MODE_RECORD
+)
+
+// RECORD_ENV switches tests from replaying fixtures to recording them against the real provider
+const RECORD_ENV = "RECORD_FIXTURES"
+
+// Interaction is a single recorded request and response
+type Interaction struct {
+	Request  *RecordedRequest  `json:"request"`
+	Response *RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+	Chunks     []string            `json:"chunks,omitempty"` // SSE events, one entry per event including its trailing blank line
+}
+
+// Transport records or replays interactions for a single fixture file
+type Transport struct {
+	mode         Mode
+	path         string
+	real         http.RoundTripper
+	scrubber     *Scrubber
+	interactions []*Interaction
+	used         []bool
+	mx           sync.Mutex
+}
+
+// ModeFromEnv returns MODE_RECORD when RECORD_FIXTURES is set
+func ModeFromEnv() Mode {
+	if os.Getenv(RECORD_ENV) != "" {
+		return MODE_RECORD
+	}
+	return MODE_REPLAY
+}
+
+// New creates a transport for the fixture at path. In replay mode the fixture must already exist.
+func New(path string, mode Mode) (*Transport, error) {
+	transport := &Transport{
+		mode:     mode,
+		path:     path,
+		real:     http.DefaultTransport,
+		scrubber: NewScrubber(),
+	}
+
+	if mode == MODE_RECORD {
+		return transport, nil
+	}
+
+	// nolint:gosec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read fixture %s, record it with %s=1", path, RECORD_ENV)
+	}
+
+	err = json.Unmarshal(data, &transport.interactions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse fixture %s", path)
+	}
+	transport.used = make([]bool, len(transport.interactions))
+
+	return transport, nil
+}
+
+// WithRealTransport sets the transport used to reach the provider while recording
+func (this *Transport) WithRealTransport(real http.RoundTripper) *Transport {
+	this.real = real
+	return this
+}
+
+// WithScrubber replaces the default secret scrubber
+func (this *Transport) WithScrubber(scrubber *Scrubber) *Transport {
+	this.scrubber = scrubber
+	return this
+}
+
+// Client returns an http.Client that uses this transport
+func (this *Transport) Client() *http.Client {
+	return &http.Client{Transport: this}
+}
+
+// Interactions returns the recorded or loaded interactions
+func (this *Transport) Interactions() []*Interaction {
+	this.mx.Lock()
+	defer this.mx.Unlock()
+	return this.interactions
+}
+
+func (this *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if this.mode == MODE_RECORD {
+		return this.record(req)
+	}
+	return this.replay(req)
+}
+
+func (this *Transport) record(req *http.Request) (*http.Response, error) {
+	requestBody, err := readAndRestore(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := this.real.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := readAndRestore(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	recordedResponse := &RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    this.scrubber.Headers(resp.Header),
+	}
+	if isSSE(resp.Header) {
+		recordedResponse.Chunks = splitEvents(this.scrubber.Body(string(responseBody)))
+	} else {
+		recordedResponse.Body = this.scrubber.Body(string(responseBody))
+	}
+
+	this.mx.Lock()
+	defer this.mx.Unlock()
+	this.interactions = append(this.interactions, &Interaction{
+		Request: &RecordedRequest{
+			Method:  req.Method,
+			URL:     this.scrubber.Body(req.URL.String()),
+			Headers: this.scrubber.Headers(req.Header),
+			Body:    this.scrubber.Body(string(requestBody)),
+		},
+		Response: recordedResponse,
+	})
+
+	return resp, nil
+}
+
+func (this *Transport) replay(req *http.Request) (*http.Response, error) {
+	requestBody, err := readAndRestore(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	this.mx.Lock()
+	defer this.mx.Unlock()
+
+	for i, interaction := range this.interactions {
+		if this.used[i] || !matches(interaction.Request, req, requestBody) {
+			continue
+		}
+		this.used[i] = true
+
+		body := interaction.Response.Body
+		if len(interaction.Response.Chunks) > 0 {
+			body = strings.Join(interaction.Response.Chunks, "")
+		}
+
+		return &http.Response{
+			StatusCode:    interaction.Response.StatusCode,
+			Status:        http.StatusText(interaction.Response.StatusCode),
+			Header:        http.Header(interaction.Response.Headers).Clone(),
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+		}, nil
+	}
+
+	return nil, errors.Errorf("no recorded interaction for %s %s with body %s in %s", req.Method, req.URL.Path, requestBody, this.path)
+}
+
+// Save writes recorded interactions to the fixture file. It does nothing in replay mode.
+func (this *Transport) Save() error {
+	if this.mode != MODE_RECORD {
+		return nil
+	}
+
+	this.mx.Lock()
+	defer this.mx.Unlock()
+
+	data, err := json.MarshalIndent(this.interactions, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal fixture")
+	}
+
+	// nolint:gosec
+	err = os.MkdirAll(filepath.Dir(this.path), 0o755)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// nolint:gosec
+	err = os.WriteFile(this.path, append(data, '\n'), 0o644)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// matches compares method, path and body. Base URLs can differ between recording and replay, and JSON bodies
+// match when they hold the same values whatever their key order.
+func matches(recorded *RecordedRequest, req *http.Request, body []byte) bool {
+	if recorded.Method != req.Method || !sameBody(recorded.Body, string(body)) {
+		return false
+	}
+
+	recordedPath := recorded.URL
+	if idx := strings.Index(recordedPath, "://"); idx >= 0 {
+		recordedPath = recordedPath[idx+3:]
+		if slash := strings.Index(recordedPath, "/"); slash >= 0 {
+			recordedPath = recordedPath[slash:]
+		} else {
+			recordedPath = "/"
+		}
+	}
+	if query := strings.Index(recordedPath, "?"); query >= 0 {
+		recordedPath = recordedPath[:query]
+	}
+
+	return strings.HasSuffix(req.URL.Path, recordedPath) || strings.HasSuffix(recordedPath, req.URL.Path)
+}
+
+// sameBody compares bodies as JSON when both parse, otherwise as text
+func sameBody(recorded, sent string) bool {
+	if recorded == sent {
+		return true
+	}
+
+	var recordedValue, sentValue any
+	if json.Unmarshal([]byte(recorded), &recordedValue) != nil || json.Unmarshal([]byte(sent), &sentValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(recordedValue, sentValue)
+}
+
+func readAndRestore(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read body")
+	}
+	err = (*body).Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to close body")
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func isSSE(header http.Header) bool {
+	return strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+}
+
+// splitEvents splits an SSE body after each blank line so every event is one chunk
+func splitEvents(body string) []string {
+	normalized := strings.ReplaceAll(body, "\r\n", "\n")
+	chunks := []string{}
+	for normalized != "" {
+		idx := strings.Index(normalized, "\n\n")
+		if idx < 0 {
+			chunks = append(chunks, normalized)
+			break
+		}
+		chunks = append(chunks, normalized[:idx+2])
+		normalized = normalized[idx+2:]
+	}
+	return chunks
+}
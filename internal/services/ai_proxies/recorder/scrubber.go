@@ -0,0 +1,62 @@
+package recorder
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// REDACTED replaces scrubbed secrets in fixtures
+const REDACTED = "[REDACTED]"
+
+// Scrubber removes secrets from headers and bodies before they are written to a fixture
+type Scrubber struct {
+	HeaderNames []string
+	Patterns    []*regexp.Regexp
+}
+
+// NewScrubber returns a scrubber for auth headers and common provider key formats
+func NewScrubber() *Scrubber {
+	return &Scrubber{
+		HeaderNames: []string{
+			"Authorization",
+			"Cookie",
+			"Set-Cookie",
+			"Openai-Organization",
+			"Openai-Project",
+			"X-Api-Key",
+			"Api-Key",
+		},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`sk-[A-Za-z0-9_\-]{8,}`),
+			regexp.MustCompile(`Bearer [A-Za-z0-9_\-\.]+`),
+			regexp.MustCompile(`(?i)("(?:api_key|apikey|client_secret|secret|token|password)"\s*:\s*")[^"]*(")`),
+		},
+	}
+}
+
+// Headers returns a copy of the headers with secret values redacted
+func (this *Scrubber) Headers(header http.Header) map[string][]string {
+	if len(header) == 0 {
+		return nil
+	}
+
+	scrubbed := header.Clone()
+	for _, name := range this.HeaderNames {
+		if _, ok := scrubbed[http.CanonicalHeaderKey(name)]; ok {
+			scrubbed.Set(name, REDACTED)
+		}
+	}
+	return scrubbed
+}
+
+// Body redacts secrets in a request or response body
+func (this *Scrubber) Body(body string) string {
+	for _, pattern := range this.Patterns {
+		if pattern.NumSubexp() == 2 {
+			body = pattern.ReplaceAllString(body, "${1}"+REDACTED+"${2}")
+			continue
+		}
+		body = pattern.ReplaceAllString(body, REDACTED)
+	}
+	return body
+}
@@ -133,6 +133,7 @@ func SuccessfulStripeCheckout(
 		return nil, err
 	}
 
+	publishSubscriptionUpdated(ctx, subObj)
 	return subObj, nil
 }
 
@@ -150,7 +151,13 @@ func ProcessStripeCancel(ctx context.Context, sub *subscription.Subscription, sa
 	if !tools.Empty(stripeSubscription.CancelAt) {
 		sub.EndTS.Set(stripeSubscription.CancelAt)
 	}
-	return sub.Save(savingUser)
+	err = sub.Save(savingUser)
+	if err != nil {
+		return err
+	}
+
+	publishSubscriptionUpdated(ctx, sub)
+	return nil
 }
 
 // TODO
@@ -162,7 +169,13 @@ func ProcessStripeResume(ctx context.Context, sub *subscription.Subscription, sa
 
 	sub.Status.Set(subscription.STATUS_ACTIVE)
 	sub.EndTS.Set(0)
-	return sub.Save(savingUser)
+	err = sub.Save(savingUser)
+	if err != nil {
+		return err
+	}
+
+	publishSubscriptionUpdated(ctx, sub)
+	return nil
 }
 
 func ProcessStripePlanChange(
@@ -215,5 +228,6 @@ func ProcessStripePlanChange(
 		return err
 	}
 
+	publishSubscriptionUpdated(ctx, newSub)
 	return nil
 }
@@ -106,7 +106,13 @@ func ProcessStripeEvent(ctx context.Context, event stripe.Event) error {
 		}
 
 		// Need to save it here
-		return subObj.Save(nil)
+		err = subObj.Save(nil)
+		if err != nil {
+			return err
+		}
+
+		publishSubscriptionUpdated(ctx, subObj)
+		return nil
 
 	}
 	return nil
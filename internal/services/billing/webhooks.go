@@ -0,0 +1,14 @@
+package billing
+
+import (
+	"context"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/subscription"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
+)
+
+// publishSubscriptionUpdated notifies the organization's webhook endpoints of a saved subscription change
+func publishSubscriptionUpdated(ctx context.Context, subObj *subscription.Subscription) {
+	webhook_service.PublishAndLog(ctx, subObj.OrganizationID.Get(), webhook_service.EVENT_SUBSCRIPTION_UPDATED,
+		webhook_service.NewSubscriptionData(subObj))
+}
@@ -0,0 +1,55 @@
+package document_service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+// Artifact is the processed form of a document, the extracted text and the chunks it is indexed by
+type Artifact struct {
+	DocumentID  types.UUID `json:"document_id"`
+	ContentType string     `json:"content_type"`
+	Text        string     `json:"text"`
+	Chunks      []*Chunk   `json:"chunks"`
+}
+
+// WriteArtifact stores the processed artifact and points the document at it
+func WriteArtifact(ctx context.Context, documentObj *document.Document, artifact *Artifact) error {
+	body, err := json.Marshal(artifact)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	key := documentObj.ProcessedS3Key()
+	err = upload(ctx, key, "application/json", body)
+	if err != nil {
+		return err
+	}
+
+	documentObj.ProcessedS3URL.Set(document.ObjectURL(key))
+	documentObj.ChunkCount.Set(int64(len(artifact.Chunks)))
+	return nil
+}
+
+// ReadArtifact loads the processed artifact of a document
+func ReadArtifact(ctx context.Context, documentObj *document.Document) (*Artifact, error) {
+	if documentObj.ProcessedS3URL.Get() == "" {
+		return nil, errors.Errorf("document %s has not been processed", documentObj.ID())
+	}
+
+	body, err := download(ctx, documentObj.GetFilePath("processed_s3_url"))
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := &Artifact{}
+	err = json.Unmarshal(body, artifact)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return artifact, nil
+}
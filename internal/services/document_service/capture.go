@@ -0,0 +1,215 @@
+package document_service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
+)
+
+// MAX_REFETCH_HOURS caps how rarely a capture is re-fetched, a month
+const MAX_REFETCH_HOURS = 24 * 30
+
+// newResponder builds the model client page summaries are made with
+var newResponder = func() (StructuredResponder, error) {
+	return openai.NewServiceFromEnv()
+}
+
+// CreateWebpage saves a capture of a URL and queues its first fetch. refetchHours of 0 captures the page once.
+func CreateWebpage(documentObj *document.Document, rawURL string, refetchHours int, savingUser coremodel.Model) error {
+	err := ValidateWebpageURL(rawURL)
+	if err != nil {
+		return err
+	}
+	if refetchHours < 0 || refetchHours > MAX_REFETCH_HOURS {
+		return errors.Errorf("refetch_hours must be between 0 and %d", MAX_REFETCH_HOURS)
+	}
+
+	metaData, err := documentObj.MetaData.Get()
+	if err != nil {
+		return err
+	}
+	if metaData == nil {
+		metaData = &document.MetaData{}
+	}
+	metaData.WebpageURL = rawURL
+	metaData.RefetchHours = refetchHours
+	documentObj.MetaData.Set(metaData)
+
+	documentObj.DocumentType.Set(document.DOCUMENT_TYPE_WEBPAGE_SUMMARY)
+	documentObj.ContentType.Set("text/html")
+	if documentObj.Name.Get() == "" {
+		documentObj.Name.Set(rawURL)
+	}
+	documentObj.Status.Set(document.STATUS_PENDING)
+
+	err = documentObj.Save(savingUser)
+	if err != nil {
+		return err
+	}
+
+	return advance(documentObj, document.STATUS_RAW, savingUser)
+}
+
+// captureWebpage fetches a capture for the first time, or again on retry
+func captureWebpage(ctx context.Context, documentObj *document.Document) error {
+	metaData, err := documentObj.MetaData.Get()
+	if err != nil {
+		return err
+	}
+	if metaData == nil || metaData.WebpageURL == "" {
+		return fail(ctx, documentObj, errors.New("webpage document has no url"))
+	}
+
+	page, err := FetchWebpage(ctx, metaData.WebpageURL)
+	if err != nil {
+		return fail(ctx, documentObj, errors.Wrap(err, "page could not be fetched"))
+	}
+
+	applyWebpage(ctx, documentObj, metaData, page)
+	return StoreText(ctx, documentObj, page.Text)
+}
+
+// Refetch fetches a capture again, replacing and re-indexing its content only when the page changed, or crawls
+// a sitemap again. A failed fetch keeps the indexed content and is tried again at the next scheduled time.
+func Refetch(ctx context.Context, documentID types.UUID) error {
+	documentObj, err := document.Get(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	// a job queued before the document was deleted must not bring its content or a sitemap's pages back
+	if tools.Empty(documentObj) || documentObj.Deleted.Get() == 1 || documentObj.Status.Get() != document.STATUS_INDEXED {
+		return nil
+	}
+	if documentObj.DocumentType.Get() == document.DOCUMENT_TYPE_SITEMAP {
+		return crawlSitemap(ctx, documentObj)
+	}
+	if documentObj.DocumentType.Get() != document.DOCUMENT_TYPE_WEBPAGE_SUMMARY {
+		return nil
+	}
+
+	metaData, err := documentObj.MetaData.Get()
+	if err != nil {
+		return err
+	}
+	if metaData == nil || metaData.WebpageURL == "" {
+		return nil
+	}
+
+	page, err := FetchWebpage(ctx, metaData.WebpageURL)
+	if err != nil {
+		log.ErrorContext(err, ctx)
+		metaData.FetchError = err.Error()
+		documentObj.MetaData.Set(metaData)
+		scheduleRefetch(documentObj, metaData)
+		return documentObj.Save(nil)
+	}
+
+	if contentHash(page.Text) == metaData.ContentHash {
+		metaData.FetchedAtTS = time.Now().Unix()
+		metaData.FetchError = ""
+		documentObj.MetaData.Set(metaData)
+		scheduleRefetch(documentObj, metaData)
+		return documentObj.Save(nil)
+	}
+
+	applyWebpage(ctx, documentObj, metaData, page)
+	return StoreText(ctx, documentObj, page.Text)
+}
+
+// QueueDueRefetches queues a re-fetch of every capture whose time has come, moving its next time on
+// first so a slow worker does not get the same capture queued twice
+func QueueDueRefetches(ctx context.Context) error {
+	documentObjs, err := document.FindDueRefetches(ctx, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	for _, documentObj := range documentObjs {
+		metaData, err := documentObj.MetaData.Get()
+		if err != nil {
+			log.ErrorContext(err, ctx)
+			continue
+		}
+
+		scheduleRefetch(documentObj, metaData)
+		err = documentObj.Save(nil)
+		if err != nil {
+			log.ErrorContext(err, ctx)
+			continue
+		}
+
+		err = worker_jobs.QueueDocumentRefetchJob(documentObj.ID())
+		if err != nil {
+			log.ErrorContext(err, ctx)
+		}
+	}
+
+	return nil
+}
+
+// applyWebpage records a fetched page on the document, summarizing captures made directly. A failed summary is
+// logged and left empty rather than failing the capture, it is made again when the page next changes or on retry.
+func applyWebpage(ctx context.Context, documentObj *document.Document, metaData *document.MetaData, page *Webpage) {
+	now := time.Now().Unix()
+	metaData.Title = page.Title
+	metaData.ContentHash = contentHash(page.Text)
+	documentObj.ContentHash.Set(metaData.ContentHash)
+	metaData.FetchedAtTS = now
+	metaData.ChangedAtTS = now
+	metaData.FetchError = ""
+	metaData.Summary = ""
+	metaData.KeyFacts = nil
+
+	if documentObj.Name.Get() == "" || documentObj.Name.Get() == metaData.WebpageURL {
+		if page.Title != "" {
+			documentObj.Name.Set(page.Title)
+		}
+	}
+
+	// Pages found by a sitemap crawl are indexed without a summary, so a crawl fits in one job
+	if !tools.Empty(documentObj.ParentID.Get()) {
+		documentObj.MetaData.Set(metaData)
+		scheduleRefetch(documentObj, metaData)
+		return
+	}
+
+	responder, err := newResponder()
+	if err == nil {
+		var summary *Summary
+		summary, err = Summarize(ctx, responder, page.Title, page.Text)
+		if err == nil {
+			metaData.Summary = summary.Summary
+			metaData.KeyFacts = summary.KeyFacts
+		}
+	}
+	if err != nil {
+		log.ErrorContext(errors.Wrapf(err, "summary of document %s", documentObj.ID()), ctx)
+	}
+
+	documentObj.MetaData.Set(metaData)
+	scheduleRefetch(documentObj, metaData)
+}
+
+// scheduleRefetch sets when the capture is next fetched, or clears it for one off captures
+func scheduleRefetch(documentObj *document.Document, metaData *document.MetaData) {
+	if metaData == nil || metaData.RefetchHours <= 0 {
+		documentObj.RefetchAtTS.Set(0)
+		return
+	}
+	documentObj.RefetchAtTS.Set(time.Now().Add(time.Duration(metaData.RefetchHours) * time.Hour).Unix())
+}
+
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
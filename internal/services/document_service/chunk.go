@@ -0,0 +1,127 @@
+package document_service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// CHUNK_SIZE is the target size of a chunk in bytes
+	CHUNK_SIZE = 1500
+	// CHUNK_OVERLAP is how much of the end of a chunk is repeated at the start of the next
+	CHUNK_OVERLAP = 200
+)
+
+// chunkBreaks are the boundaries a chunk prefers to end on, strongest first
+var chunkBreaks = []string{"\n\n", "\n", ". ", " "}
+
+// Chunk is a piece of a document's text, Start and End are byte offsets into the extracted text. Page and
+// Heading are where the chunk starts, when the format has them.
+type Chunk struct {
+	Index   int    `json:"index"`
+	Text    string `json:"text"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	Page    int    `json:"page,omitempty"`
+	Heading string `json:"heading,omitempty"`
+}
+
+// ChunkText splits text into overlapping chunks of about size bytes, ending each chunk on a paragraph, line,
+// sentence or word boundary when one falls in its second half
+func ChunkText(text string, size int, overlap int) []*Chunk {
+	if size <= 0 {
+		size = CHUNK_SIZE
+	}
+	if overlap < 0 || overlap >= size/2 {
+		overlap = size / 4
+	}
+
+	chunks := []*Chunk{}
+	start := 0
+	for start < len(text) {
+		end := len(text)
+		if start+size < len(text) {
+			end = runeBoundary(text, start+size)
+			end = breakBefore(text, start+size/2, end)
+		}
+
+		chunkStart, chunkEnd := trimOffsets(text, start, end)
+		if chunkStart < chunkEnd {
+			chunks = append(chunks, &Chunk{
+				Index: len(chunks),
+				Text:  text[chunkStart:chunkEnd],
+				Start: chunkStart,
+				End:   chunkEnd,
+			})
+		}
+
+		if end >= len(text) {
+			break
+		}
+
+		next := wordStart(text, runeBoundary(text, end-overlap), end)
+		if next <= start {
+			next = end
+		}
+		start = next
+	}
+
+	return chunks
+}
+
+// runeBoundary moves an offset back to the start of the rune it falls in
+func runeBoundary(text string, offset int) int {
+	for offset > 0 && offset < len(text) && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+	return offset
+}
+
+// breakBefore returns the end of the last preferred boundary in text[from:end], or end when there is none
+func breakBefore(text string, from int, end int) int {
+	from = runeBoundary(text, from)
+	for _, boundary := range chunkBreaks {
+		index := strings.LastIndex(text[from:end], boundary)
+		if index >= 0 {
+			return from + index + len(boundary)
+		}
+	}
+	return end
+}
+
+// wordStart moves an offset forward past the word it falls in, so an overlap does not begin mid word
+func wordStart(text string, offset int, end int) int {
+	if offset <= 0 {
+		return 0
+	}
+	index := strings.IndexFunc(text[offset:end], unicode.IsSpace)
+	if index < 0 {
+		return offset
+	}
+	return offset + index + 1
+}
+
+// trimOffsets narrows text[start:end] to exclude surrounding whitespace
+func trimOffsets(text string, start int, end int) (int, int) {
+	segment := text[start:end]
+	trimmedLeft := strings.TrimLeftFunc(segment, unicode.IsSpace)
+	start += len(segment) - len(trimmedLeft)
+	end = start + len(strings.TrimRightFunc(trimmedLeft, unicode.IsSpace))
+	return start, end
+}
+
+// LocateChunks sets the page and heading of each chunk from the section it starts in
+func LocateChunks(chunks []*Chunk, sections []*Section) {
+	for _, chunk := range chunks {
+		index := sort.Search(len(sections), func(i int) bool {
+			return sections[i].Start > chunk.Start
+		}) - 1
+		if index < 0 {
+			continue
+		}
+		chunk.Page = sections[index].Page
+		chunk.Heading = sections[index].Heading
+	}
+}
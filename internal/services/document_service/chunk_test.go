@@ -0,0 +1,75 @@
+package document_service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChunkText_Short(t *testing.T) {
+	chunks := ChunkText("  one short paragraph  ", 100, 10)
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(chunks))
+	}
+	if chunks[0].Text != "one short paragraph" || chunks[0].Start != 2 || chunks[0].End != 21 {
+		t.Errorf("unexpected chunk %+v", chunks[0])
+	}
+}
+
+func TestChunkText_Offsets(t *testing.T) {
+	text := strings.Repeat("Sentence number one is here. ", 40) + "\n\n" + strings.Repeat("Другой абзац текста. ", 40)
+
+	chunks := ChunkText(text, 300, 60)
+	if len(chunks) < 2 {
+		t.Fatalf("expected several chunks, got %d", len(chunks))
+	}
+
+	for i, chunk := range chunks {
+		if chunk.Index != i {
+			t.Errorf("chunk %d has index %d", i, chunk.Index)
+		}
+		if text[chunk.Start:chunk.End] != chunk.Text {
+			t.Errorf("chunk %d offsets do not match its text", i)
+		}
+		if len(chunk.Text) > 300 {
+			t.Errorf("chunk %d is %d bytes", i, len(chunk.Text))
+		}
+		if i > 0 && chunk.Start >= chunks[i-1].End {
+			t.Errorf("chunk %d does not overlap the previous chunk", i)
+		}
+	}
+
+	if chunks[len(chunks)-1].End != len(strings.TrimSpace(text)) {
+		t.Errorf("last chunk does not reach the end of the text")
+	}
+}
+
+func TestChunkText_BreaksOnSentences(t *testing.T) {
+	text := strings.Repeat("Alpha beta gamma delta. ", 30)
+
+	for _, chunk := range ChunkText(text, 200, 40)[:2] {
+		if !strings.HasSuffix(chunk.Text, ".") {
+			t.Errorf("expected chunk to end on a sentence, got %q", chunk.Text)
+		}
+	}
+}
+
+func TestLocateChunks(t *testing.T) {
+	chunks := []*Chunk{{Start: 0}, {Start: 40}, {Start: 90}}
+	sections := []*Section{
+		{Start: 10, Page: 1, Heading: "Intro"},
+		{Start: 40, Page: 1, Heading: "Setup"},
+		{Start: 60, Page: 2, Heading: "Setup"},
+	}
+
+	LocateChunks(chunks, sections)
+
+	if chunks[0].Page != 0 || chunks[0].Heading != "" {
+		t.Errorf("chunk before the first section should have no location, got %+v", chunks[0])
+	}
+	if chunks[1].Page != 1 || chunks[1].Heading != "Setup" {
+		t.Errorf("chunk starting on a section should take it, got %+v", chunks[1])
+	}
+	if chunks[2].Page != 2 || chunks[2].Heading != "Setup" {
+		t.Errorf("chunk should take the last section before it, got %+v", chunks[2])
+	}
+}
@@ -0,0 +1,299 @@
+package document_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/task_update"
+	"github.com/pkg/errors"
+)
+
+const (
+	CRAWL_STAGE_DISCOVERING = "discovering"
+	CRAWL_STAGE_FETCHING    = "fetching"
+	CRAWL_STAGE_DONE        = "done"
+	CRAWL_STAGE_FAILED      = "failed"
+
+	// CRAWL_PROGRESS_EVERY is how many pages pass between progress saves on the sitemap document
+	CRAWL_PROGRESS_EVERY = 10
+)
+
+// CreateSitemap saves a sitemap document and queues its first crawl. refetchHours of 0 crawls it once.
+func CreateSitemap(
+	documentObj *document.Document,
+	rawURL string,
+	settings *document.CrawlSettings,
+	refetchHours int,
+	savingUser coremodel.Model,
+) error {
+	err := ValidateWebpageURL(rawURL)
+	if err != nil {
+		return err
+	}
+	if refetchHours < 0 || refetchHours > MAX_REFETCH_HOURS {
+		return errors.Errorf("refetch_hours must be between 0 and %d", MAX_REFETCH_HOURS)
+	}
+	if settings == nil {
+		settings = &document.CrawlSettings{}
+	}
+	if settings.MaxPages <= 0 {
+		settings.MaxPages = CRAWL_DEFAULT_MAX_PAGES
+	}
+	if settings.MaxPages > CRAWL_MAX_PAGES {
+		return errors.Errorf("max_pages must be at most %d", CRAWL_MAX_PAGES)
+	}
+
+	documentObj.MetaData.Set(&document.MetaData{
+		WebpageURL:   rawURL,
+		RefetchHours: refetchHours,
+		Crawl:        settings,
+	})
+	documentObj.DocumentType.Set(document.DOCUMENT_TYPE_SITEMAP)
+	documentObj.ContentType.Set("application/xml")
+	if documentObj.Name.Get() == "" {
+		documentObj.Name.Set(rawURL)
+	}
+	documentObj.Status.Set(document.STATUS_PENDING)
+
+	err = documentObj.Save(savingUser)
+	if err != nil {
+		return err
+	}
+
+	return advance(documentObj, document.STATUS_RAW, savingUser)
+}
+
+// crawlSitemap discovers a sitemap's pages and captures each as a child webpage document, reporting progress
+// through the context's task updater. Pages seen on an earlier crawl are only re-indexed when they changed.
+func crawlSitemap(ctx context.Context, parentObj *document.Document) error {
+	metaData, err := parentObj.MetaData.Get()
+	if err != nil {
+		return err
+	}
+	if metaData == nil || metaData.WebpageURL == "" {
+		return fail(ctx, parentObj, errors.New("sitemap document has no url"))
+	}
+	settings := metaData.Crawl
+	if settings == nil {
+		settings = &document.CrawlSettings{}
+	}
+
+	updater := task_update.NewTaskUpdate[*document.CrawlProgress]()
+	updater.Listen(recordCrawlProgress(ctx, parentObj.ID()))
+	ctx = task_update.WithTaskUpdater(ctx, updater)
+
+	progress, crawlErr := runCrawl(ctx, parentObj, metaData.WebpageURL, settings)
+	updater.Finish()
+
+	// Reloaded as progress was saved on it while crawling
+	parentObj, err = document.Get(ctx, parentObj.ID())
+	if err != nil {
+		return err
+	}
+	if tools.Empty(parentObj) {
+		return nil
+	}
+	metaData, err = parentObj.MetaData.Get()
+	if err != nil {
+		return err
+	}
+	if metaData == nil {
+		metaData = &document.MetaData{}
+	}
+
+	progress.UpdatedAtTS = time.Now().Unix()
+	metaData.CrawlProgress = progress
+	metaData.FetchedAtTS = progress.UpdatedAtTS
+	parentObj.MetaData.Set(metaData)
+	scheduleRefetch(parentObj, metaData)
+
+	if crawlErr != nil {
+		// A failed re-crawl keeps the pages it found before
+		if parentObj.Status.Get() == document.STATUS_INDEXED {
+			log.ErrorContext(crawlErr, ctx)
+			return parentObj.Save(nil)
+		}
+		return fail(ctx, parentObj, crawlErr)
+	}
+
+	if parentObj.Status.Get() == document.STATUS_INDEXED {
+		return parentObj.Save(nil)
+	}
+	return advance(parentObj, document.STATUS_INDEXED, nil)
+}
+
+// runCrawl does the crawl, the returned progress is complete even when the crawl stopped with an error
+func runCrawl(
+	ctx context.Context,
+	parentObj *document.Document,
+	startURL string,
+	settings *document.CrawlSettings,
+) (*document.CrawlProgress, error) {
+	progress := &document.CrawlProgress{
+		Stage:       CRAWL_STAGE_DISCOVERING,
+		StartedAtTS: time.Now().Unix(),
+	}
+	sendCrawlProgress(ctx, progress)
+
+	crawler := NewCrawler(settings.Include, settings.Exclude, settings.MaxPages, time.Duration(settings.DelayMS)*time.Millisecond)
+	result, err := crawler.Discover(ctx, startURL)
+	if err != nil {
+		progress.Stage = CRAWL_STAGE_FAILED
+		progress.Error = err.Error()
+		return progress, errors.Wrap(err, "sitemap could not be read")
+	}
+
+	children, err := document.FindChildren(ctx, parentObj.ID())
+	if err != nil {
+		return progress, err
+	}
+	existing := map[string]*document.Document{}
+	for _, child := range children {
+		childMetaData, err := child.MetaData.Get()
+		if err == nil && childMetaData != nil {
+			existing[childMetaData.WebpageURL] = child
+		}
+	}
+
+	progress.Stage = CRAWL_STAGE_FETCHING
+	progress.Found = len(result.Pages)
+	progress.Skipped = result.Skipped
+	sendCrawlProgress(ctx, progress)
+
+	for _, pageURL := range result.Pages {
+		if ctx.Err() != nil {
+			progress.Stage = CRAWL_STAGE_FAILED
+			progress.Error = ctx.Err().Error()
+			return progress, errors.WithStack(ctx.Err())
+		}
+
+		err = capturePage(ctx, crawler, parentObj, existing[pageURL], pageURL, progress)
+		if err != nil {
+			log.ErrorContext(err, ctx)
+			progress.Failed++
+		}
+		progress.Processed++
+		sendCrawlProgress(ctx, progress)
+	}
+
+	progress.Stage = CRAWL_STAGE_DONE
+	sendCrawlProgress(ctx, progress)
+	return progress, nil
+}
+
+// capturePage fetches one page of the sitemap into its child document
+func capturePage(
+	ctx context.Context,
+	crawler *Crawler,
+	parentObj *document.Document,
+	childObj *document.Document,
+	pageURL string,
+	progress *document.CrawlProgress,
+) error {
+	page, err := crawler.FetchPage(ctx, pageURL)
+	if err != nil {
+		return errors.Wrapf(err, "page %s", pageURL)
+	}
+
+	if childObj == nil {
+		childObj = document.New()
+		childObj.OrganizationID.Set(parentObj.OrganizationID.Get())
+		childObj.AccountID.Set(parentObj.AccountID.Get())
+		childObj.Sharing.Set(parentObj.Sharing.Get())
+		childObj.ParentID.Set(parentObj.ID())
+		childObj.DocumentGroupID.Set(parentObj.DocumentGroupID.Get())
+		childObj.DocumentType.Set(document.DOCUMENT_TYPE_WEBPAGE_SUMMARY)
+		childObj.ContentType.Set("text/html")
+		childObj.Name.Set(pageURL)
+		childObj.MetaData.Set(&document.MetaData{WebpageURL: pageURL})
+		childObj.Status.Set(document.STATUS_RAW)
+		err = childObj.Save(nil)
+		if err != nil {
+			return err
+		}
+		progress.Created++
+	} else {
+		// Still being processed from the last crawl
+		if childObj.Status.Get() != document.STATUS_INDEXED && childObj.Status.Get() != document.STATUS_ERROR {
+			progress.Unchanged++
+			return nil
+		}
+
+		childMetaData, err := childObj.MetaData.Get()
+		if err != nil {
+			return err
+		}
+		if childMetaData == nil {
+			childMetaData = &document.MetaData{WebpageURL: pageURL}
+		}
+		if childObj.Status.Get() == document.STATUS_INDEXED && childMetaData.ContentHash == contentHash(page.Text) {
+			childMetaData.FetchedAtTS = time.Now().Unix()
+			childObj.MetaData.Set(childMetaData)
+			progress.Unchanged++
+			return childObj.Save(nil)
+		}
+		progress.Updated++
+	}
+
+	childMetaData, err := childObj.MetaData.Get()
+	if err != nil {
+		return err
+	}
+	if childMetaData == nil {
+		childMetaData = &document.MetaData{WebpageURL: pageURL}
+	}
+	applyWebpage(ctx, childObj, childMetaData, page)
+	return StoreText(ctx, childObj, page.Text)
+}
+
+// sendCrawlProgress reports progress to the context's task updater when there is one
+func sendCrawlProgress(ctx context.Context, progress *document.CrawlProgress) {
+	taskUpdater, updatesActive := task_update.GetTaskUpdater[*document.CrawlProgress](ctx)
+	if !updatesActive {
+		return
+	}
+	snapshot := *progress
+	taskUpdater.Send(&snapshot)
+}
+
+// recordCrawlProgress saves crawl progress on the sitemap document when the stage changes and every
+// CRAWL_PROGRESS_EVERY pages, so it can be followed through the document API
+func recordCrawlProgress(ctx context.Context, documentID types.UUID) func(progress *document.CrawlProgress) {
+	lastStage := ""
+	return func(progress *document.CrawlProgress) {
+		if progress.Stage == lastStage && progress.Processed%CRAWL_PROGRESS_EVERY != 0 {
+			return
+		}
+		lastStage = progress.Stage
+
+		documentObj, err := document.Get(ctx, documentID)
+		if err != nil {
+			log.ErrorContext(err, ctx)
+			return
+		}
+		if tools.Empty(documentObj) {
+			return
+		}
+		metaData, err := documentObj.MetaData.Get()
+		if err != nil {
+			log.ErrorContext(err, ctx)
+			return
+		}
+		if metaData == nil {
+			metaData = &document.MetaData{}
+		}
+
+		progress.UpdatedAtTS = time.Now().Unix()
+		metaData.CrawlProgress = progress
+		documentObj.MetaData.Set(metaData)
+		err = documentObj.Save(nil)
+		if err != nil {
+			log.ErrorContext(err, ctx)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package document_service
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/models/object_tag"
+)
+
+// Delete removes a document from the library. It is marked deleted so change logs keep their history, its chunks
+// and tags are removed, and its stored files are deleted from S3 and the provider vector store. Deleting the
+// current version makes the one it replaced current again, and a sitemap takes its crawled pages with it.
+func Delete(ctx context.Context, documentObj *document.Document, savingUser coremodel.Model) error {
+	documentObj.Deleted.Set(1)
+	documentObj.RefetchAtTS.Set(0)
+	err := documentObj.Save(savingUser)
+	if err != nil {
+		return err
+	}
+
+	err = document_chunk.DeleteByDocument(ctx, documentObj.ID())
+	if err != nil {
+		return err
+	}
+
+	err = restorePreviousVersion(ctx, documentObj, savingUser)
+	if err != nil {
+		return err
+	}
+
+	objectTags, err := object_tag.GetByObject(ctx, documentObj.URN.Get())
+	if err != nil {
+		return err
+	}
+	for _, objectTagObj := range objectTags {
+		err = objectTagObj.Delete()
+		if err != nil {
+			return err
+		}
+	}
+
+	// a file left behind only costs storage, it should not keep the document in the library
+	for _, field := range []string{"raw_s3_url", "processed_s3_url"} {
+		if documentObj.GetString(field) == "" {
+			continue
+		}
+		err = remove(ctx, documentObj.GetFilePath(field))
+		if err != nil {
+			log.ErrorContext(err, ctx)
+		}
+	}
+
+	removeFromVectorStore(ctx, documentObj)
+
+	if documentObj.DocumentType.Get() != document.DOCUMENT_TYPE_SITEMAP {
+		return nil
+	}
+
+	children, err := document.FindChildren(ctx, documentObj.ID())
+	if err != nil {
+		return err
+	}
+	for _, childObj := range children {
+		if childObj.Deleted.Get() == 1 {
+			continue
+		}
+		err = Delete(ctx, childObj, savingUser)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package document_service
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/services/embedding_service"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EMBEDDINGS_INDEXER is the name the local vector index registers under
+	EMBEDDINGS_INDEXER = "embeddings"
+
+	DEFAULT_SEARCH_LIMIT = 8
+	MAX_SEARCH_LIMIT     = 50
+)
+
+// ErrNotEmbeddable is returned when embedding a document that has not finished processing
+var ErrNotEmbeddable = errors.New("only indexed documents can be embedded again")
+
+func init() {
+	RegisterIndexer(EMBEDDINGS_INDEXER, embedDocument)
+}
+
+// Embed embeds an indexed document's chunks again, used after the embedding provider changes
+func Embed(ctx context.Context, documentID types.UUID) error {
+	documentObj, err := document.Get(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(documentObj) {
+		return nil
+	}
+	if documentObj.Status.Get() != document.STATUS_INDEXED {
+		return ErrNotEmbeddable
+	}
+
+	artifact, err := ReadArtifact(ctx, documentObj)
+	if err != nil {
+		return err
+	}
+	return embedDocument(ctx, documentObj, artifact)
+}
+
+// embedDocument replaces the document's chunks in the vector index with freshly embedded ones, unless the stored
+// chunks already match the artifact and the current embedding model
+func embedDocument(ctx context.Context, documentObj *document.Document, artifact *Artifact) error {
+	provider, err := embedding_service.GetProvider()
+	if err != nil {
+		return err
+	}
+
+	organizationID := documentObj.OrganizationID.Get()
+	if tools.Empty(organizationID) {
+		return errors.Errorf("document %s has no organization", documentObj.ID())
+	}
+
+	existing, err := document_chunk.FindByDocument(ctx, documentObj.ID())
+	if err != nil {
+		return err
+	}
+	if chunksCurrent(existing, artifact, provider.Model()) {
+		return nil
+	}
+
+	texts := make([]string, len(artifact.Chunks))
+	for i, chunk := range artifact.Chunks {
+		texts[i] = chunk.Text
+	}
+	vectors, err := embedding_service.EmbedAll(ctx, provider, texts)
+	if err != nil {
+		return err
+	}
+
+	err = document_chunk.DeleteByDocument(ctx, documentObj.ID())
+	if err != nil {
+		return err
+	}
+
+	for i, chunk := range artifact.Chunks {
+		chunkObj := document_chunk.New()
+		chunkObj.OrganizationID.Set(organizationID)
+		chunkObj.DocumentID.Set(documentObj.ID())
+		chunkObj.DocumentGroupID.Set(documentObj.DocumentGroupID.Get())
+		chunkObj.ChunkIndex.Set(int64(chunk.Index))
+		chunkObj.Content.Set(chunk.Text)
+		chunkObj.StartOffset.Set(int64(chunk.Start))
+		chunkObj.EndOffset.Set(int64(chunk.End))
+		chunkObj.Page.Set(int64(chunk.Page))
+		chunkObj.Heading.Set(chunk.Heading)
+		chunkObj.EmbeddingModel.Set(provider.Model())
+		chunkObj.SetEmbedding(vectors[i])
+		err = chunkObj.Save(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChunkSearch finds the chunks of an organization's documents that best match a query
+type ChunkSearch struct {
+	document_chunk.ChunkScope
+	Query string
+}
+
+// SearchChunks embeds the query with the current provider and returns the closest chunks, best first
+func SearchChunks(ctx context.Context, search *ChunkSearch) ([]*document_chunk.ChunkMatch, error) {
+	if tools.Empty(search.OrganizationID) {
+		return nil, errors.New("organization is required")
+	}
+	if search.Query == "" {
+		return []*document_chunk.ChunkMatch{}, nil
+	}
+
+	limit := search.Limit
+	if limit <= 0 {
+		limit = DEFAULT_SEARCH_LIMIT
+	}
+	limit = min(limit, MAX_SEARCH_LIMIT)
+
+	provider, err := embedding_service.GetProvider()
+	if err != nil {
+		return nil, err
+	}
+	vectors, err := provider.Embed(ctx, []string{search.Query})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != 1 {
+		return nil, errors.Errorf("%s returned %d vectors for the query", provider.Model(), len(vectors))
+	}
+
+	scope := search.ChunkScope
+	scope.Limit = limit
+	return document_chunk.FindSimilar(ctx, &document_chunk.SimilarityQuery{
+		ChunkScope:     scope,
+		EmbeddingModel: provider.Model(),
+		Embedding:      vectors[0],
+	})
+}
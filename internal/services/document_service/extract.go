@@ -0,0 +1,213 @@
+package document_service
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MAX_EXTRACT_ERRORS is the most partial failures kept for one file, the rest are counted
+	MAX_EXTRACT_ERRORS = 20
+	// MAX_DECODED_BYTES caps what one compressed part of a file may inflate to
+	MAX_DECODED_BYTES = 100 << 20
+	// MAX_EXTRACT_DURATION bounds how long one file may take to read, hostile files can be slow without being large
+	MAX_EXTRACT_DURATION = 2 * time.Minute
+)
+
+var (
+	// ErrUnsupportedContentType is returned for files no extractor can read
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	// ErrNoText is returned when a file has no text to index
+	ErrNoText = errors.New("no text could be extracted")
+
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+	spacesPattern     = regexp.MustCompile(`[ \t]+`)
+)
+
+// Extractor reads the text out of a raw file. Parts of the file it cannot read are reported in the extraction's
+// Errors, an error is only returned when nothing could be read. Extractors that can run long stop when ctx is done.
+type Extractor func(ctx context.Context, data []byte) (*Extraction, error)
+
+type registeredExtractor struct {
+	documentType document.DocumentType
+	extract      Extractor
+}
+
+var (
+	extractorsLock sync.RWMutex
+	extractors     = map[string]*registeredExtractor{}
+)
+
+// RegisterExtractor adds the extractor for a media type and the document type its files are stored as
+func RegisterExtractor(mediaType string, documentType document.DocumentType, extractor Extractor) {
+	extractorsLock.Lock()
+	defer extractorsLock.Unlock()
+	extractors[mediaType] = &registeredExtractor{documentType: documentType, extract: extractor}
+}
+
+// getExtractor returns the extractor for a media type, nil when there is none
+func getExtractor(mediaType string) *registeredExtractor {
+	extractorsLock.RLock()
+	defer extractorsLock.RUnlock()
+	return extractors[mediaType]
+}
+
+// SupportedContentTypes lists the media types that can be extracted, sorted
+func SupportedContentTypes() []string {
+	extractorsLock.RLock()
+	defer extractorsLock.RUnlock()
+
+	mediaTypes := make([]string, 0, len(extractors))
+	for mediaType := range extractors {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	return mediaTypes
+}
+
+// Extraction is the text read from a raw file
+type Extraction struct {
+	Text string `json:"text"`
+	// Sections mark where pages and headings start in Text, in order
+	Sections []*Section `json:"sections,omitempty"`
+	// Errors are the parts of the file that could not be read, the rest was extracted
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Section is a point in the extracted text where a page or heading starts, Start is a byte offset into the text.
+// Page is 0 for formats without pages and Heading is the nearest heading at or before Start.
+type Section struct {
+	Start   int    `json:"start"`
+	Page    int    `json:"page,omitempty"`
+	Heading string `json:"heading,omitempty"`
+}
+
+// NormalizeContentType strips parameters from a content type, returning "" when it is not valid
+func NormalizeContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// Supported reports whether files of the content type can be extracted
+func Supported(contentType string) bool {
+	return getExtractor(NormalizeContentType(contentType)) != nil
+}
+
+// DocumentTypeFor returns the document type files of the content type are stored as, 0 when it is not supported
+func DocumentTypeFor(contentType string) document.DocumentType {
+	extractor := getExtractor(NormalizeContentType(contentType))
+	if extractor == nil {
+		return 0
+	}
+	return extractor.documentType
+}
+
+// Extract reads the text out of a raw file with the extractor registered for its content type, giving up after
+// MAX_EXTRACT_DURATION
+func Extract(ctx context.Context, contentType string, data []byte) (*Extraction, error) {
+	extractor := getExtractor(NormalizeContentType(contentType))
+	if extractor == nil {
+		return nil, errors.Wrapf(ErrUnsupportedContentType, "%s", contentType)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, MAX_EXTRACT_DURATION)
+	defer cancel()
+	return extractor.extract(ctx, data)
+}
+
+// textBuilder assembles extracted text block by block, recording the page and heading each block starts under.
+// Blocks are trimmed and joined with separator, so section offsets stay valid in the final text.
+type textBuilder struct {
+	separator    string
+	text         strings.Builder
+	sections     []*Section
+	page         int
+	heading      string
+	marked       bool
+	errors       []string
+	skippedCount int
+}
+
+func newTextBuilder(separator string) *textBuilder {
+	return &textBuilder{separator: separator}
+}
+
+// Page starts a new page, numbered from 1
+func (this *textBuilder) Page(page int) {
+	this.page = page
+	this.marked = true
+}
+
+// Heading starts a new heading, the blocks after it belong to it until the next one
+func (this *textBuilder) Heading(heading string) {
+	heading = strings.Join(strings.Fields(heading), " ")
+	if heading == "" {
+		return
+	}
+	this.heading = heading
+	this.marked = true
+}
+
+// Block adds a block of text, empty blocks are dropped
+func (this *textBuilder) Block(block string) {
+	block = strings.ReplaceAll(block, "\r\n", "\n")
+	block = strings.TrimSpace(blankLinesPattern.ReplaceAllString(block, "\n\n"))
+	if block == "" {
+		return
+	}
+
+	if this.text.Len() > 0 {
+		this.text.WriteString(this.separator)
+	}
+	// A page or heading opened since the last block starts with this one
+	if this.marked {
+		this.sections = append(this.sections, &Section{
+			Start:   this.text.Len(),
+			Page:    this.page,
+			Heading: this.heading,
+		})
+		this.marked = false
+	}
+	this.text.WriteString(block)
+}
+
+// Errorf records a part of the file that could not be read
+func (this *textBuilder) Errorf(format string, args ...any) {
+	if len(this.errors) >= MAX_EXTRACT_ERRORS {
+		this.skippedCount++
+		return
+	}
+	this.errors = append(this.errors, fmt.Sprintf(format, args...))
+}
+
+// Extraction returns the text built, failing with ErrNoText when there is none
+func (this *textBuilder) Extraction() (*Extraction, error) {
+	if this.text.Len() == 0 {
+		if len(this.errors) > 0 {
+			return nil, errors.Wrap(ErrNoText, this.errors[0])
+		}
+		return nil, ErrNoText
+	}
+
+	extraction := &Extraction{
+		Text:     this.text.String(),
+		Sections: this.sections,
+		Errors:   this.errors,
+	}
+	if this.skippedCount > 0 {
+		extraction.Errors = append(extraction.Errors, fmt.Sprintf("%d more errors", this.skippedCount))
+	}
+	return extraction, nil
+}
@@ -0,0 +1,155 @@
+package document_service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+const (
+	DOCX_CONTENT_TYPE = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	// docxBodyPath is the part of the package holding the document body
+	docxBodyPath = "word/document.xml"
+)
+
+func init() {
+	RegisterExtractor(DOCX_CONTENT_TYPE, document.DOCUMENT_TYPE_DOCX, extractDOCX)
+}
+
+// extractDOCX reads the paragraphs of a Word document's body. Paragraphs in a heading or title style, or with an
+// outline level, start a heading. DOCX has no fixed pages, so pages follow the breaks Word recorded when it last
+// laid the document out, or the explicit page breaks when it recorded none.
+func extractDOCX(_ context.Context, data []byte) (*Extraction, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.Wrap(err, "file is not a valid DOCX")
+	}
+
+	var bodyFile *zip.File
+	for _, file := range reader.File {
+		if file.Name == docxBodyPath {
+			bodyFile = file
+			break
+		}
+	}
+	if bodyFile == nil {
+		return nil, errors.Errorf("DOCX has no %s", docxBodyPath)
+	}
+
+	body, err := readZipFile(bodyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := newTextBuilder("\n")
+	renderedBreaks := bytes.Contains(body, []byte("lastRenderedPageBreak"))
+	page := 1
+	builder.Page(page)
+
+	paragraph := &strings.Builder{}
+	style := ""
+	outlined := false
+	inText := false
+	flush := func() {
+		text := paragraph.String()
+		if strings.HasPrefix(strings.ToLower(style), "heading") || strings.EqualFold(style, "title") || outlined {
+			builder.Heading(text)
+		}
+		builder.Block(text)
+		paragraph.Reset()
+		style = ""
+		outlined = false
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// the paragraphs read so far are kept
+			builder.Errorf("%s: %v", docxBodyPath, err)
+			break
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "p":
+				// text boxes nest paragraphs, the outer text read so far is its own block
+				if paragraph.Len() > 0 {
+					flush()
+				}
+			case "pStyle":
+				style = docxAttr(element, "val")
+			case "outlineLvl":
+				outlined = true
+			case "t":
+				inText = true
+			case "tab":
+				paragraph.WriteString("\t")
+			case "br", "cr":
+				if docxAttr(element, "type") == "page" {
+					if !renderedBreaks {
+						page++
+						builder.Page(page)
+					}
+					continue
+				}
+				paragraph.WriteString("\n")
+			case "lastRenderedPageBreak":
+				page++
+				builder.Page(page)
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				flush()
+			}
+		case xml.CharData:
+			if inText {
+				paragraph.Write(element)
+			}
+		}
+	}
+	flush()
+
+	return builder.Extraction()
+}
+
+// readZipFile reads a file out of a zip archive, refusing ones that inflate past MAX_DECODED_BYTES
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s could not be opened", file.Name)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, MAX_DECODED_BYTES+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s could not be read", file.Name)
+	}
+	if len(data) > MAX_DECODED_BYTES {
+		return nil, errors.Errorf("%s is larger than %d MB uncompressed", file.Name, MAX_DECODED_BYTES>>20)
+	}
+	return data, nil
+}
+
+// docxAttr returns an attribute of an element by its local name, ignoring the namespace
+func docxAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
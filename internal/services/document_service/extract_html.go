@@ -0,0 +1,58 @@
+package document_service
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+)
+
+var (
+	htmlHiddenPattern  = regexp.MustCompile(`(?is)<(script|style|noscript|template)\b.*?</(script|style|noscript|template)>`)
+	htmlHeadingPattern = regexp.MustCompile(`(?is)<h[1-6]\b[^>]*>(.*?)</h[1-6]\s*>`)
+	htmlBlockPattern   = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6]|section|article|blockquote)>`)
+	htmlTagPattern     = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+func init() {
+	RegisterExtractor("text/html", document.DOCUMENT_TYPE_HTML, extractHTML)
+}
+
+// extractHTML converts a page to text, each h1 to h6 starts a heading
+func extractHTML(_ context.Context, data []byte) (*Extraction, error) {
+	body, err := readUTF8(data)
+	if err != nil {
+		return nil, err
+	}
+	body = htmlHiddenPattern.ReplaceAllString(body, "")
+
+	builder := newTextBuilder("\n")
+	last := 0
+	for _, match := range htmlHeadingPattern.FindAllStringSubmatchIndex(body, -1) {
+		builder.Block(HTMLToText(body[last:match[0]]))
+
+		heading := HTMLToText(body[match[2]:match[3]])
+		builder.Heading(heading)
+		builder.Block(heading)
+		last = match[1]
+	}
+	builder.Block(HTMLToText(body[last:]))
+
+	return builder.Extraction()
+}
+
+// HTMLToText drops scripts, styles and markup, keeping block elements on their own lines
+func HTMLToText(body string) string {
+	body = htmlHiddenPattern.ReplaceAllString(body, "")
+	body = htmlBlockPattern.ReplaceAllString(body, "\n")
+	body = htmlTagPattern.ReplaceAllString(body, "")
+	body = html.UnescapeString(body)
+
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacesPattern.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,566 @@
+package document_service
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+const (
+	PDF_CONTENT_TYPE = "application/pdf"
+	// pdfHeadingScale is how much larger than the body text a line must be to be taken as a heading
+	pdfHeadingScale = 1.2
+	// pdfMaxHeadingLength keeps large print paragraphs, like a cover page blurb, from becoming headings
+	pdfMaxHeadingLength = 120
+	// pdfMaxRangeSize bounds one bfrange of a ToUnicode map
+	pdfMaxRangeSize = 1 << 16
+	// pdfWordGap is how far back, in thousandths of the font size, a TJ adjustment must move to count as a space
+	pdfWordGap = 200
+)
+
+// pdfWinAnsi maps the bytes of WinAnsiEncoding that differ from Latin-1
+var pdfWinAnsi = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ', 0x89: '‰', 0x8A: 'Š',
+	0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“', 0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—',
+	0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›', 0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+func init() {
+	RegisterExtractor(PDF_CONTENT_TYPE, document.DOCUMENT_TYPE_PDF, extractPDF)
+}
+
+// extractPDF reads the text layer of each page. PDFs rarely mark their headings, so lines set noticeably larger
+// than the body text are taken as headings. Pages that cannot be read are reported and skipped, scanned pages
+// have no text layer and extract nothing.
+func extractPDF(ctx context.Context, data []byte) (*Extraction, error) {
+	file, err := parsePDF(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if file.encrypted() {
+		return nil, errors.New("encrypted PDFs are not supported")
+	}
+	pages := file.pages()
+	if len(pages) == 0 {
+		return nil, errors.New("the PDF has no pages")
+	}
+
+	builder := newTextBuilder("\n\n")
+	fonts := map[pdfRef]*pdfFont{}
+	pageLines := make([][]*pdfLine, len(pages))
+	sizes := map[float64]int{}
+	for i, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, "the PDF took too long to read")
+		}
+
+		content, err := file.pageContent(page.dict)
+		if err != nil {
+			builder.Errorf("page %d: %v", i+1, err)
+		}
+
+		state := &pdfTextState{file: file, fonts: fonts, scale: 1}
+		state.run(content, page.resources, 0)
+		if state.undecodable {
+			builder.Errorf("page %d: text in a font without a Unicode map was skipped", i+1)
+		}
+
+		pageLines[i] = state.finish()
+		for _, line := range pageLines[i] {
+			sizes[line.size] += len(line.text)
+		}
+	}
+
+	bodySize, bodyCount := 0.0, 0
+	for size, count := range sizes {
+		if count > bodyCount || (count == bodyCount && size < bodySize) {
+			bodySize, bodyCount = size, count
+		}
+	}
+
+	for i, lines := range pageLines {
+		builder.Page(i + 1)
+		paragraph := []string{}
+		for _, line := range lines {
+			if bodySize > 0 && line.size >= bodySize*pdfHeadingScale && len(line.text) <= pdfMaxHeadingLength &&
+				strings.IndexFunc(line.text, unicode.IsLetter) >= 0 {
+				builder.Block(strings.Join(paragraph, "\n"))
+				paragraph = paragraph[:0]
+				builder.Heading(line.text)
+				builder.Block(line.text)
+				continue
+			}
+			paragraph = append(paragraph, line.text)
+		}
+		builder.Block(strings.Join(paragraph, "\n"))
+	}
+
+	extraction, err := builder.Extraction()
+	if errors.Is(err, ErrNoText) {
+		return nil, errors.Wrap(err, "the PDF has no text layer, it may be scanned")
+	}
+	return extraction, err
+}
+
+// pdfPage is a page with the resources it uses, which may be inherited from the page tree
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// pages returns the pages in reading order from the page tree, or every page object in file order when the tree
+// is broken
+func (this *pdfFile) pages() []*pdfPage {
+	pages := []*pdfPage{}
+	visited := map[pdfRef]bool{}
+
+	var walk func(node any, resources pdfDict, depth int)
+	walk = func(node any, resources pdfDict, depth int) {
+		if depth > pdfMaxDepth {
+			return
+		}
+		if ref, ok := node.(pdfRef); ok {
+			if visited[ref] {
+				return
+			}
+			visited[ref] = true
+		}
+
+		dict := this.dict(node)
+		if dict == nil {
+			return
+		}
+		if own := this.dict(dict["Resources"]); own != nil {
+			resources = own
+		}
+
+		kids := this.array(dict["Kids"])
+		if dict["Type"] == pdfName("Pages") || (kids != nil && dict["Type"] != pdfName("Page")) {
+			for _, kid := range kids {
+				walk(kid, resources, depth+1)
+			}
+			return
+		}
+		pages = append(pages, &pdfPage{dict: dict, resources: resources})
+	}
+
+	if catalog := this.catalog(); catalog != nil {
+		walk(catalog["Pages"], nil, 0)
+	}
+	if len(pages) > 0 {
+		return pages
+	}
+
+	for _, number := range this.numbers() {
+		if dict := this.dict(this.objects[number]); dict != nil && dict["Type"] == pdfName("Page") {
+			pages = append(pages, &pdfPage{dict: dict, resources: this.dict(dict["Resources"])})
+		}
+	}
+	return pages
+}
+
+// pageContent returns a page's content streams decoded and joined. When one cannot be decoded the content before
+// it is returned with the error.
+func (this *pdfFile) pageContent(page pdfDict) ([]byte, error) {
+	var parts []any
+	switch contents := this.resolve(page["Contents"]).(type) {
+	case *pdfStream:
+		parts = []any{contents}
+	case []any:
+		parts = contents
+	}
+
+	content := &bytes.Buffer{}
+	for _, part := range parts {
+		stream, ok := this.resolve(part).(*pdfStream)
+		if !ok {
+			continue
+		}
+		data, err := this.decode(stream)
+		if err != nil {
+			return content.Bytes(), err
+		}
+		content.Write(data)
+		content.WriteByte('\n')
+	}
+	return content.Bytes(), nil
+}
+
+// pdfFont decodes the strings shown in a font
+type pdfFont struct {
+	cmap *pdfCMap
+	// composite fonts use multi byte codes that mean nothing without a ToUnicode map
+	composite bool
+}
+
+// font loads a font from the resources by the name the content stream uses for it
+func (this *pdfFile) font(resources pdfDict, name pdfName, cache map[pdfRef]*pdfFont) *pdfFont {
+	fontRef := this.dict(resources["Font"])[name]
+	if ref, ok := fontRef.(pdfRef); ok && cache[ref] != nil {
+		return cache[ref]
+	}
+
+	dict := this.dict(fontRef)
+	font := &pdfFont{composite: dict["Subtype"] == pdfName("Type0")}
+	if stream, ok := this.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		data, err := this.decode(stream)
+		if err == nil {
+			font.cmap = parsePDFCMap(data)
+		}
+	}
+
+	if ref, ok := fontRef.(pdfRef); ok {
+		cache[ref] = font
+	}
+	return font
+}
+
+// text decodes a shown string, ok is false when the font gives no way to read it
+func (this *pdfFont) text(raw []byte) (string, bool) {
+	if this != nil && this.cmap != nil {
+		return this.cmap.decode(raw), true
+	}
+	if this != nil && this.composite {
+		return "", false
+	}
+
+	runes := make([]rune, 0, len(raw))
+	for _, b := range raw {
+		if r, ok := pdfWinAnsi[b]; ok {
+			runes = append(runes, r)
+			continue
+		}
+		runes = append(runes, rune(b))
+	}
+	return string(runes), true
+}
+
+// pdfCMap is a ToUnicode map from character codes to the text they stand for
+type pdfCMap struct {
+	lengths []int // code lengths in bytes, shortest first
+	chars   map[string]string
+}
+
+func parsePDFCMap(data []byte) *pdfCMap {
+	cmap := &pdfCMap{chars: map[string]string{}}
+	lengths := map[int]bool{}
+
+	lexer := &pdfLexer{data: data}
+	operands := []any{}
+	for {
+		obj, err := lexer.object()
+		if err != nil {
+			break
+		}
+		keyword, ok := obj.(pdfKeyword)
+		if !ok {
+			operands = append(operands, obj)
+			continue
+		}
+
+		switch keyword {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				if low, ok := operands[i].([]byte); ok && len(low) > 0 {
+					lengths[len(low)] = true
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				code, codeOK := operands[i].([]byte)
+				text, textOK := operands[i+1].([]byte)
+				if codeOK && textOK {
+					cmap.chars[string(code)] = utf16Text(text)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				cmap.addRange(operands[i], operands[i+1], operands[i+2])
+			}
+		}
+		operands = operands[:0]
+	}
+
+	if len(lengths) == 0 {
+		for code := range cmap.chars {
+			lengths[len(code)] = true
+		}
+	}
+	for length := range lengths {
+		cmap.lengths = append(cmap.lengths, length)
+	}
+	sort.Ints(cmap.lengths)
+	return cmap
+}
+
+// addRange maps the codes from low to high either to consecutive characters from the start text, or to the
+// texts of an array in turn
+func (this *pdfCMap) addRange(lowObj any, highObj any, destination any) {
+	low, lowOK := lowObj.([]byte)
+	high, highOK := highObj.([]byte)
+	if !lowOK || !highOK || len(low) == 0 || len(low) != len(high) || len(low) > 4 {
+		return
+	}
+
+	start, end := codeValue(low), codeValue(high)
+	if end < start || end-start >= pdfMaxRangeSize {
+		return
+	}
+
+	for value := start; value <= end; value++ {
+		code := string(codeBytes(value, len(low)))
+		switch texts := destination.(type) {
+		case []byte:
+			runes := []rune(utf16Text(texts))
+			if len(runes) == 0 {
+				return
+			}
+			runes[len(runes)-1] += rune(value - start)
+			this.chars[code] = string(runes)
+		case []any:
+			if int(value-start) >= len(texts) {
+				return
+			}
+			if text, ok := texts[value-start].([]byte); ok {
+				this.chars[code] = utf16Text(text)
+			}
+		}
+	}
+}
+
+// decode reads a string code by code, trying the shortest code length first. Codes with no mapping are dropped.
+func (this *pdfCMap) decode(raw []byte) string {
+	lengths := this.lengths
+	if len(lengths) == 0 {
+		lengths = []int{1}
+	}
+
+	out := &strings.Builder{}
+	for i := 0; i < len(raw); {
+		matched := false
+		for _, length := range lengths {
+			if i+length > len(raw) {
+				break
+			}
+			if text, ok := this.chars[string(raw[i:i+length])]; ok {
+				out.WriteString(text)
+				i += length
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			i += lengths[len(lengths)-1]
+		}
+	}
+	return out.String()
+}
+
+func codeValue(code []byte) uint32 {
+	value := uint32(0)
+	for _, b := range code {
+		value = value<<8 | uint32(b)
+	}
+	return value
+}
+
+func codeBytes(value uint32, length int) []byte {
+	code := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		code[i] = byte(value)
+		value >>= 8
+	}
+	return code
+}
+
+// utf16Text decodes the big endian UTF-16 a ToUnicode map writes its text in
+func utf16Text(data []byte) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// pdfLine is a line of a page's text, size is the largest font size on it
+type pdfLine struct {
+	text string
+	size float64
+}
+
+// pdfTextState follows the text operators of a content stream. Positions are only tracked far enough to tell
+// when the text moves to a new line.
+type pdfTextState struct {
+	file        *pdfFile
+	fonts       map[pdfRef]*pdfFont
+	lines       []*pdfLine
+	line        strings.Builder
+	lineSize    float64
+	lineY       float64
+	font        *pdfFont
+	fontSize    float64
+	scale       float64
+	y           float64
+	space       bool
+	undecodable bool
+}
+
+// run interprets a content stream, forms drawn with Do are run with their own resources
+func (this *pdfTextState) run(content []byte, resources pdfDict, depth int) {
+	lexer := &pdfLexer{data: content}
+	operands := []any{}
+	for {
+		obj, err := lexer.object()
+		if err != nil {
+			return
+		}
+		operator, ok := obj.(pdfKeyword)
+		if !ok {
+			operands = append(operands, obj)
+			continue
+		}
+
+		switch operator {
+		case "BT":
+			this.y = 0
+			this.scale = 1
+			this.space = true
+		case "Tf":
+			if len(operands) >= 2 {
+				name, _ := operands[0].(pdfName)
+				this.font = this.file.font(resources, name, this.fonts)
+				this.fontSize = math.Abs(pdfNumber(operands[1]))
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				this.y += pdfNumber(operands[1]) * this.scale
+				this.space = true
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				this.scale = math.Abs(pdfNumber(operands[3]))
+				if this.scale == 0 {
+					this.scale = math.Abs(pdfNumber(operands[1]))
+				}
+				this.y = pdfNumber(operands[5])
+				this.space = true
+			}
+		case "T*":
+			this.newLine()
+		case "Tj":
+			if len(operands) >= 1 {
+				this.show(operands[0])
+			}
+		case "'", "\"":
+			this.newLine()
+			if len(operands) >= 1 {
+				this.show(operands[len(operands)-1])
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				items, _ := operands[0].([]any)
+				for _, item := range items {
+					if _, ok := item.([]byte); ok {
+						this.show(item)
+					} else if pdfNumber(item) <= -pdfWordGap {
+						this.space = true
+					}
+				}
+			}
+		case "Do":
+			if len(operands) >= 1 && depth < pdfMaxDepth {
+				this.runForm(operands[0], resources, depth)
+			}
+		case "ID":
+			skipInlineImage(lexer)
+		}
+		operands = operands[:0]
+	}
+}
+
+// runForm runs the content of a form XObject
+func (this *pdfTextState) runForm(nameObj any, resources pdfDict, depth int) {
+	name, _ := nameObj.(pdfName)
+	stream, ok := this.file.resolve(this.file.dict(resources["XObject"])[name]).(*pdfStream)
+	if !ok || stream.Dict["Subtype"] != pdfName("Form") {
+		return
+	}
+	content, err := this.file.decode(stream)
+	if err != nil {
+		return
+	}
+	if own := this.file.dict(stream.Dict["Resources"]); own != nil {
+		resources = own
+	}
+	this.run(content, resources, depth+1)
+}
+
+// skipInlineImage moves past the binary data of an inline image, which ends at an EI on its own
+func skipInlineImage(lexer *pdfLexer) {
+	for i := lexer.pos; i+2 <= len(lexer.data); i++ {
+		if lexer.data[i] == 'E' && lexer.data[i+1] == 'I' && i > 0 && isPDFSpace(lexer.data[i-1]) &&
+			(i+2 == len(lexer.data) || isPDFSpace(lexer.data[i+2])) {
+			lexer.pos = i + 2
+			return
+		}
+	}
+	lexer.pos = len(lexer.data)
+}
+
+// show writes a shown string, starting a new line when the text has moved up or down
+func (this *pdfTextState) show(obj any) {
+	raw, _ := obj.([]byte)
+	text, ok := this.font.text(raw)
+	if !ok {
+		this.undecodable = true
+		return
+	}
+	text = strings.Map(func(r rune) rune {
+		if r == 0 {
+			return -1
+		}
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text)
+	if text == "" {
+		return
+	}
+
+	if this.line.Len() > 0 && math.Abs(this.y-this.lineY) > 1 {
+		this.newLine()
+	}
+	if this.line.Len() > 0 && this.space && !strings.HasSuffix(this.line.String(), " ") &&
+		!strings.HasPrefix(text, " ") {
+		this.line.WriteString(" ")
+	}
+	this.space = false
+	this.lineY = this.y
+	this.lineSize = max(this.lineSize, math.Round(this.fontSize*this.scale*2)/2)
+	this.line.WriteString(text)
+}
+
+// newLine ends the line being written
+func (this *pdfTextState) newLine() {
+	text := strings.TrimSpace(spacesPattern.ReplaceAllString(this.line.String(), " "))
+	if text != "" {
+		this.lines = append(this.lines, &pdfLine{text: text, size: this.lineSize})
+	}
+	this.line.Reset()
+	this.lineSize = 0
+	this.space = false
+}
+
+// finish ends the page and returns its lines
+func (this *pdfTextState) finish() []*pdfLine {
+	this.newLine()
+	return this.lines
+}
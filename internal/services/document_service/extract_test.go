@@ -0,0 +1,260 @@
+package document_service
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestExtract_HTML(t *testing.T) {
+	html := `<html><head><style>p { color: red }</style><script>var x = 1;</script></head>
+<body><h1>Title</h1><p>First &amp; second</p><div>Third</div></body></html>`
+
+	extraction, err := Extract(context.Background(), "text/html; charset=utf-8", []byte(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extraction.Text != "Title\nFirst & second\nThird" {
+		t.Errorf("unexpected text %q", extraction.Text)
+	}
+	if len(extraction.Sections) != 1 || extraction.Sections[0].Heading != "Title" || extraction.Sections[0].Start != 0 {
+		t.Errorf("unexpected sections %+v", extraction.Sections)
+	}
+}
+
+func TestExtract_Errors(t *testing.T) {
+	_, err := Extract(context.Background(), "application/zip", []byte("PK"))
+	if !errors.Is(err, ErrUnsupportedContentType) {
+		t.Errorf("expected unsupported content type, got %v", err)
+	}
+
+	_, err = Extract(context.Background(), "text/plain", []byte(" \n\n "))
+	if !errors.Is(err, ErrNoText) {
+		t.Errorf("expected no text, got %v", err)
+	}
+
+	_, err = Extract(context.Background(), "text/plain", []byte{0xff, 0xfe, 0x00})
+	if err == nil {
+		t.Errorf("expected invalid UTF-8 to fail")
+	}
+}
+
+func TestExtract_Markdown(t *testing.T) {
+	markdown := "# Guide\n\nIntro text.\n\n```\n# not a heading\n```\n\n## Setup ##\nRun it.\n"
+
+	extraction, err := Extract(context.Background(), "text/markdown", []byte(markdown))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extraction.Text != "# Guide\n\nIntro text.\n\n```\n# not a heading\n```\n\n## Setup ##\n\nRun it." {
+		t.Errorf("unexpected text %q", extraction.Text)
+	}
+	want := []*Section{
+		{Start: 0, Heading: "Guide"},
+		{Start: strings.Index(extraction.Text, "## Setup"), Heading: "Setup"},
+	}
+	if !reflect.DeepEqual(extraction.Sections, want) {
+		t.Errorf("unexpected sections %+v", extraction.Sections)
+	}
+}
+
+func TestExtract_CSV(t *testing.T) {
+	extraction, err := Extract(context.Background(), "text/csv", []byte("\xef\xbb\xbfname,price\nWidget,10\nGadget,,blue\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extraction.Text != "name: Widget\nprice: 10\n\nname: Gadget\nColumn 3: blue" {
+		t.Errorf("unexpected text %q", extraction.Text)
+	}
+
+	_, err = Extract(context.Background(), "text/csv", []byte("name,price\n"))
+	if !errors.Is(err, ErrNoText) {
+		t.Errorf("expected a header only file to have no text, got %v", err)
+	}
+}
+
+func TestExtract_DOCX(t *testing.T) {
+	body := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Overview</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">First </w:t></w:r><w:r><w:t>paragraph</w:t><w:tab/><w:t>tabbed</w:t></w:r></w:p>
+<w:p><w:r><w:br w:type="page"/><w:t>Second page</w:t></w:r></w:p>
+</w:body></w:document>`
+
+	extraction, err := Extract(context.Background(), DOCX_CONTENT_TYPE, testDOCX(t, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extraction.Text != "Overview\nFirst paragraph\ttabbed\nSecond page" {
+		t.Errorf("unexpected text %q", extraction.Text)
+	}
+	want := []*Section{
+		{Start: 0, Page: 1, Heading: "Overview"},
+		{Start: strings.Index(extraction.Text, "Second page"), Page: 2, Heading: "Overview"},
+	}
+	if !reflect.DeepEqual(extraction.Sections, want) {
+		t.Errorf("unexpected sections %+v", extraction.Sections)
+	}
+	if len(extraction.Errors) != 0 {
+		t.Errorf("unexpected errors %v", extraction.Errors)
+	}
+}
+
+func TestExtract_DOCXPartial(t *testing.T) {
+	body := `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Kept</w:t></w:r></w:p><w:p><w:r><w:t>Cut off`
+
+	extraction, err := Extract(context.Background(), DOCX_CONTENT_TYPE, testDOCX(t, body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(extraction.Text, "Kept") {
+		t.Errorf("unexpected text %q", extraction.Text)
+	}
+	if len(extraction.Errors) != 1 {
+		t.Errorf("expected the broken XML to be reported, got %v", extraction.Errors)
+	}
+
+	_, err = Extract(context.Background(), DOCX_CONTENT_TYPE, []byte("not a zip"))
+	if err == nil {
+		t.Errorf("expected an invalid DOCX to fail")
+	}
+}
+
+func TestExtract_PDF(t *testing.T) {
+	cmap := "/CIDInit /ProcSet findresource begin 12 dict begin begincmap\n" +
+		"1 begincodespacerange <0000> <FFFF> endcodespacerange\n" +
+		"1 beginbfchar <0001> <0048> endbfchar\n" +
+		"1 beginbfrange <0002> <0003> <0069> endbfrange\n" +
+		"endcmap CMapName currentdict /CMap defineresource pop end end"
+	pages := []string{
+		"BT /F1 24 Tf 72 720 Td (Introduction) Tj ET\n" +
+			"BT /F1 12 Tf 72 700 Td (First line of the body.) Tj 0 -14 Td [(Second) -250 (line)] TJ ET",
+		"BT /F1 12 Tf 72 720 Td (Page two \\(continued\\) text.) Tj ET\n" +
+			"BT /F2 12 Tf 72 700 Td <00010002> Tj ET",
+	}
+
+	extraction, err := Extract(context.Background(), "application/pdf", testPDF(pages, cmap))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extraction.Text != "Introduction\n\nFirst line of the body.\nSecond line\n\nPage two (continued) text.\nHi" {
+		t.Errorf("unexpected text %q", extraction.Text)
+	}
+	want := []*Section{
+		{Start: 0, Page: 1, Heading: "Introduction"},
+		{Start: strings.Index(extraction.Text, "Page two"), Page: 2, Heading: "Introduction"},
+	}
+	if !reflect.DeepEqual(extraction.Sections, want) {
+		t.Errorf("unexpected sections %+v", extraction.Sections)
+	}
+}
+
+func TestExtract_PDFErrors(t *testing.T) {
+	_, err := Extract(context.Background(), "application/pdf", []byte("not a pdf"))
+	if err == nil {
+		t.Errorf("expected a file without a PDF header to fail")
+	}
+
+	_, err = Extract(context.Background(), "application/pdf", testPDF([]string{"0 0 m 100 100 l S"}, ""))
+	if !errors.Is(err, ErrNoText) {
+		t.Errorf("expected a PDF without a text layer to have no text, got %v", err)
+	}
+}
+
+func TestExtract_PDFHostile(t *testing.T) {
+	// each of these read the rest of the file once per object header before unclosed objects were skipped
+	files := map[string]string{
+		"unclosed":     strings.Repeat("1 0 obj <<\n", 100000),
+		"endobj":       strings.Repeat("1 0 obj << /A [\nendobj\n", 100000),
+		"nested":       "1 0 obj\n" + strings.Repeat("[", 1000000),
+		"nested_dicts": "1 0 obj\n" + strings.Repeat("<< /A ", 1000000),
+	}
+	for name, body := range files {
+		start := time.Now()
+		_, err := Extract(context.Background(), "application/pdf", []byte("%PDF-1.4\n"+body))
+		if err == nil {
+			t.Errorf("%s: expected a PDF without pages to fail", name)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("%s: took %s", name, elapsed)
+		}
+	}
+
+	// a broken object does not hide the objects after it
+	valid := testPDF([]string{"BT /F1 12 Tf 72 720 Td (Still read.) Tj ET"}, "")
+	broken := bytes.Replace(valid, []byte("%PDF-1.4\n"), []byte("%PDF-1.4\n99 0 obj\n<< /A [ 1 2\nendobj\n"), 1)
+	extraction, err := Extract(context.Background(), "application/pdf", broken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extraction.Text != "Still read." {
+		t.Errorf("unexpected text %q", extraction.Text)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Extract(ctx, "application/pdf", valid)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled extraction to stop, got %v", err)
+	}
+}
+
+// testDOCX packs a document body into a minimal DOCX
+func testDOCX(t *testing.T, body string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	file, err := writer.Create(docxBodyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = file.Write([]byte(body))
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+// testPDF builds a PDF with one compressed content stream per page. Font F1 is a standard font and F2 a composite
+// font using the ToUnicode map, resources are inherited from the page tree.
+func testPDF(pages []string, toUnicode string) []byte {
+	objects := [][]byte{
+		[]byte("<< /Type /Catalog /Pages 2 0 R >>"),
+		nil,
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>"),
+		[]byte("<< /Type /Font /Subtype /Type0 /BaseFont /Test /Encoding /Identity-H /ToUnicode 5 0 R >>"),
+		[]byte(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(toUnicode), toUnicode)),
+	}
+
+	kids := []string{}
+	for _, content := range pages {
+		compressed := &bytes.Buffer{}
+		writer := zlib.NewWriter(compressed)
+		_, _ = writer.Write([]byte(content))
+		_ = writer.Close()
+
+		stream := fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+		objects = append(objects, append(append([]byte(stream), compressed.Bytes()...), []byte("\nendstream")...))
+		objects = append(objects, []byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /Contents %d 0 R >>", len(objects))))
+		kids = append(kids, fmt.Sprintf("%d 0 R", len(objects)))
+	}
+	objects[1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> >>",
+		strings.Join(kids, " "), len(pages)))
+
+	out := &bytes.Buffer{}
+	out.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		fmt.Fprintf(out, "%d 0 obj\n", i+1)
+		out.Write(obj)
+		out.WriteString("\nendobj\n")
+	}
+	out.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return out.Bytes()
+}
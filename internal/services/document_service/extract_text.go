@@ -0,0 +1,147 @@
+package document_service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+var (
+	markdownHeadingPattern = regexp.MustCompile(`^ {0,3}#{1,6}[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	markdownFencePattern   = regexp.MustCompile("^ {0,3}(```|~~~)")
+
+	utf8BOM = []byte("\xef\xbb\xbf")
+)
+
+func init() {
+	RegisterExtractor("text/plain", document.DOCUMENT_TYPE_TEXT, extractPlainText)
+	RegisterExtractor("text/markdown", document.DOCUMENT_TYPE_MARKDOWN, extractMarkdown)
+	RegisterExtractor("text/x-markdown", document.DOCUMENT_TYPE_MARKDOWN, extractMarkdown)
+	RegisterExtractor("text/csv", document.DOCUMENT_TYPE_CSV, extractCSV(','))
+	RegisterExtractor("text/tab-separated-values", document.DOCUMENT_TYPE_CSV, extractCSV('\t'))
+}
+
+// readUTF8 returns a text file's content without a byte order mark
+func readUTF8(data []byte) (string, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	if !utf8.Valid(data) {
+		return "", errors.New("file is not valid UTF-8")
+	}
+	return string(data), nil
+}
+
+// extractPlainText keeps the text as it is
+func extractPlainText(_ context.Context, data []byte) (*Extraction, error) {
+	text, err := readUTF8(data)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := newTextBuilder("\n\n")
+	builder.Block(text)
+	return builder.Extraction()
+}
+
+// extractMarkdown keeps the markdown source, tracking ATX headings. Lines in fenced code blocks are never
+// headings.
+func extractMarkdown(_ context.Context, data []byte) (*Extraction, error) {
+	text, err := readUTF8(data)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := newTextBuilder("\n\n")
+	block := []string{}
+	inFence := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if markdownFencePattern.MatchString(line) {
+			inFence = !inFence
+		}
+		match := markdownHeadingPattern.FindStringSubmatch(line)
+		if inFence || match == nil {
+			block = append(block, line)
+			continue
+		}
+
+		builder.Block(strings.Join(block, "\n"))
+		block = block[:0]
+		builder.Heading(match[1])
+		builder.Block(line)
+	}
+	builder.Block(strings.Join(block, "\n"))
+
+	return builder.Extraction()
+}
+
+// extractCSV turns each row after the header row into a record of "column: value" lines, so a chunk holding a
+// row still says what its values mean. Rows that cannot be parsed are reported and skipped.
+func extractCSV(separator rune) Extractor {
+	return func(_ context.Context, data []byte) (*Extraction, error) {
+		text, err := readUTF8(data)
+		if err != nil {
+			return nil, err
+		}
+
+		reader := csv.NewReader(strings.NewReader(text))
+		reader.Comma = separator
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+
+		builder := newTextBuilder("\n\n")
+		var header []string
+		for row := 1; ; row++ {
+			fields, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				builder.Errorf("row %d: %v", row, err)
+				// a reader error that is not about one row will not go away
+				if _, ok := err.(*csv.ParseError); !ok {
+					break
+				}
+				continue
+			}
+
+			if header == nil {
+				header = fields
+				continue
+			}
+			builder.Block(csvRecord(header, fields))
+		}
+
+		if header == nil {
+			return nil, ErrNoText
+		}
+		return builder.Extraction()
+	}
+}
+
+// csvRecord writes a row as one "column: value" line per non empty field
+func csvRecord(header []string, fields []string) string {
+	lines := make([]string, 0, len(fields))
+	for i, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		column := ""
+		if i < len(header) {
+			column = strings.TrimSpace(header[i])
+		}
+		if column == "" {
+			column = fmt.Sprintf("Column %d", i+1)
+		}
+		lines = append(lines, column+": "+field)
+	}
+	return strings.Join(lines, "\n")
+}
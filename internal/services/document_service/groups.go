@@ -0,0 +1,112 @@
+package document_service
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_group"
+	"github.com/griffnb/techboss-ai-go/internal/services/vector_store_service"
+	"github.com/pkg/errors"
+)
+
+// SetGroup moves a document into a group, or out of its group when groupID is empty. The indexed chunks move
+// with it so retrieval scoped to the group sees the change straight away, its provider file follows in the
+// background, and a sitemap takes its pages along.
+func SetGroup(ctx context.Context, documentObj *document.Document, groupID types.UUID, savingUser coremodel.Model) error {
+	documentObj.DocumentGroupID.Set(groupID)
+	err := documentObj.Save(savingUser)
+	if err != nil {
+		return err
+	}
+
+	err = document_chunk.SetGroupByDocument(ctx, documentObj.ID(), groupID)
+	if err != nil {
+		return err
+	}
+
+	err = worker_jobs.QueueDocumentVectorSyncJob(documentObj.ID())
+	if err != nil {
+		return err
+	}
+
+	if documentObj.DocumentType.Get() != document.DOCUMENT_TYPE_SITEMAP {
+		return nil
+	}
+
+	children, err := document.FindChildren(ctx, documentObj.ID())
+	if err != nil {
+		return err
+	}
+	for _, childObj := range children {
+		err = SetGroup(ctx, childObj, groupID, savingUser)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteGroup deletes a group, its documents are kept and no longer belong to any group. Its provider vector
+// store is deleted and the documents are synced into the organization's store instead.
+func DeleteGroup(ctx context.Context, groupObj *document_group.DocumentGroup, savingUser coremodel.Model) error {
+	groupObj.Deleted.Set(1)
+	err := groupObj.Save(savingUser)
+	if err != nil {
+		return err
+	}
+
+	documents, err := document.FindByGroup(ctx, groupObj.ID())
+	if err != nil {
+		return err
+	}
+
+	err = document.ClearGroup(ctx, groupObj.ID())
+	if err != nil {
+		return err
+	}
+	err = document_chunk.ClearGroup(ctx, groupObj.ID())
+	if err != nil {
+		return err
+	}
+
+	err = vector_store_service.DeleteGroupStore(ctx, groupObj.OrganizationID.Get(), groupObj.ID())
+	if err != nil && !errors.Is(err, vector_store_service.ErrNotConfigured) {
+		return err
+	}
+	for _, documentObj := range documents {
+		err = worker_jobs.QueueDocumentVectorSyncJob(documentObj.ID())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AgentGroupIDs returns the groups of the organization an agent may retrieve from. restricted is false when the
+// agent has no knowledge settings and may search every document, when it is true an empty list allows nothing.
+func AgentGroupIDs(ctx context.Context, agentObj *agent.Agent, organizationID types.UUID) ([]types.UUID, bool, error) {
+	settings, err := agentObj.Settings.Get()
+	if err != nil {
+		return nil, true, errors.WithStack(err)
+	}
+	if settings == nil || settings.Knowledge == nil || !settings.Knowledge.Restricted() {
+		return nil, false, nil
+	}
+
+	groupIDs := append([]types.UUID{}, settings.Knowledge.DocumentGroupIDs...)
+	if len(settings.Knowledge.DocumentGroupTypes) > 0 {
+		groups, err := document_group.FindByTypes(ctx, organizationID, settings.Knowledge.DocumentGroupTypes)
+		if err != nil {
+			return nil, true, err
+		}
+		for _, groupObj := range groups {
+			groupIDs = append(groupIDs, groupObj.ID())
+		}
+	}
+	return groupIDs, true, nil
+}
@@ -0,0 +1,42 @@
+package document_service
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+)
+
+// Indexer makes a processed document searchable, it must be safe to run again for the same document
+type Indexer func(ctx context.Context, documentObj *document.Document, artifact *Artifact) error
+
+var (
+	indexersLock sync.RWMutex
+	indexers     = map[string]Indexer{}
+)
+
+// RegisterIndexer adds a search backend every processed document is indexed into
+func RegisterIndexer(name string, indexer Indexer) {
+	indexersLock.Lock()
+	defer indexersLock.Unlock()
+	indexers[name] = indexer
+}
+
+// getIndexers returns the registered indexers in name order so runs are repeatable
+func getIndexers() []Indexer {
+	indexersLock.RLock()
+	defer indexersLock.RUnlock()
+
+	names := make([]string, 0, len(indexers))
+	for name := range indexers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]Indexer, 0, len(names))
+	for _, name := range names {
+		list = append(list, indexers[name])
+	}
+	return list
+}
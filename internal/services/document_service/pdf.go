@@ -0,0 +1,618 @@
+package document_service
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"context"
+	"encoding/ascii85"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// The PDF reader only goes as far as text extraction needs. Objects are found by scanning for their "obj"
+// headers instead of trusting the cross reference table, which also reads files whose table is damaged.
+
+const (
+	// pdfMaxDepth bounds reference chains, page trees, nested forms and arrays and dictionaries nested in an object
+	pdfMaxDepth = 32
+	// pdfMaxObjects bounds the objects read from one file, real files stay well under it
+	pdfMaxObjects = 1 << 20
+)
+
+var (
+	pdfObjectPattern  = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	pdfTrailerPattern = regexp.MustCompile(`trailer\s*<<`)
+
+	errPDFNotClosed      = errors.New("object is not closed before endobj")
+	errPDFTooDeep        = errors.Errorf("object is nested more than %d levels deep", pdfMaxDepth)
+	errPDFTooManyObjects = errors.Errorf("the PDF has more than %d objects", pdfMaxObjects)
+)
+
+type (
+	pdfName    string
+	pdfKeyword string // an operator, or one of the delimiters [ ] << >>
+	pdfRef     int
+	pdfDict    map[pdfName]any
+)
+
+// pdfStream is a stream object, Raw is still encoded with the stream's filters
+type pdfStream struct {
+	Dict pdfDict
+	Raw  []byte
+}
+
+// pdfLexer reads PDF tokens and objects. Numbers are int or float64, strings are []byte.
+type pdfLexer struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func isPDFSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (this *pdfLexer) peek(offset int) byte {
+	if this.pos+offset >= len(this.data) {
+		return 0
+	}
+	return this.data[this.pos+offset]
+}
+
+func (this *pdfLexer) skipSpace() {
+	for this.pos < len(this.data) {
+		c := this.data[this.pos]
+		if c == '%' {
+			for this.pos < len(this.data) && this.data[this.pos] != '\n' && this.data[this.pos] != '\r' {
+				this.pos++
+			}
+			continue
+		}
+		if !isPDFSpace(c) {
+			return
+		}
+		this.pos++
+	}
+}
+
+// token reads the next token, io.EOF at the end of the data
+func (this *pdfLexer) token() (any, error) {
+	this.skipSpace()
+	if this.pos >= len(this.data) {
+		return nil, io.EOF
+	}
+
+	c := this.data[this.pos]
+	switch c {
+	case '/':
+		return this.name(), nil
+	case '(':
+		return this.literalString(), nil
+	case '<':
+		if this.peek(1) == '<' {
+			this.pos += 2
+			return pdfKeyword("<<"), nil
+		}
+		return this.hexString(), nil
+	case '>':
+		if this.peek(1) == '>' {
+			this.pos += 2
+			return pdfKeyword(">>"), nil
+		}
+		this.pos++
+		return pdfKeyword(">"), nil
+	case '[', ']', '{', '}', ')':
+		this.pos++
+		return pdfKeyword(string(c)), nil
+	}
+
+	start := this.pos
+	for this.pos < len(this.data) && !isPDFSpace(this.data[this.pos]) && !isPDFDelimiter(this.data[this.pos]) {
+		this.pos++
+	}
+	word := string(this.data[start:this.pos])
+	if number, ok := parsePDFNumber(word); ok {
+		return number, nil
+	}
+	return pdfKeyword(word), nil
+}
+
+func parsePDFNumber(word string) (any, bool) {
+	if word == "" || !bytes.ContainsRune([]byte("+-.0123456789"), rune(word[0])) {
+		return nil, false
+	}
+	if integer, err := strconv.Atoi(word); err == nil {
+		return integer, true
+	}
+	if real, err := strconv.ParseFloat(word, 64); err == nil {
+		return real, true
+	}
+	return nil, false
+}
+
+func (this *pdfLexer) name() pdfName {
+	this.pos++
+	name := []byte{}
+	for this.pos < len(this.data) && !isPDFSpace(this.data[this.pos]) && !isPDFDelimiter(this.data[this.pos]) {
+		c := this.data[this.pos]
+		if c == '#' && this.pos+2 < len(this.data) {
+			decoded, err := hex.DecodeString(string(this.data[this.pos+1 : this.pos+3]))
+			if err == nil {
+				name = append(name, decoded[0])
+				this.pos += 3
+				continue
+			}
+		}
+		name = append(name, c)
+		this.pos++
+	}
+	return pdfName(name)
+}
+
+func (this *pdfLexer) literalString() []byte {
+	this.pos++
+	out := []byte{}
+	depth := 1
+	for this.pos < len(this.data) {
+		c := this.data[this.pos]
+		this.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if this.pos >= len(this.data) {
+				return out
+			}
+			escaped := this.data[this.pos]
+			this.pos++
+			switch escaped {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				// a backslash before a line break continues the string on the next line
+				if this.peek(0) == '\n' {
+					this.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				c = escaped
+				if escaped >= '0' && escaped <= '7' {
+					value := int(escaped - '0')
+					for i := 0; i < 2 && this.peek(0) >= '0' && this.peek(0) <= '7'; i++ {
+						value = value*8 + int(this.data[this.pos]-'0')
+						this.pos++
+					}
+					c = byte(value)
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func (this *pdfLexer) hexString() []byte {
+	this.pos++
+	digits := []byte{}
+	for this.pos < len(this.data) && this.data[this.pos] != '>' {
+		c := this.data[this.pos]
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+		this.pos++
+	}
+	this.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	decoded, _ := hex.DecodeString(string(digits))
+	return decoded
+}
+
+// object reads a whole object, arrays and dictionaries included
+func (this *pdfLexer) object() (any, error) {
+	token, err := this.token()
+	if err != nil {
+		return nil, err
+	}
+	return this.objectFrom(token)
+}
+
+func (this *pdfLexer) objectFrom(token any) (any, error) {
+	switch value := token.(type) {
+	case pdfKeyword:
+		if value == "[" || value == "<<" {
+			if this.depth >= pdfMaxDepth {
+				return nil, errPDFTooDeep
+			}
+			this.depth++
+			defer func() { this.depth-- }()
+		}
+
+		// endobj never appears inside an object, so an unclosed one stops there instead of reading on to the end
+		switch value {
+		case "[":
+			array := []any{}
+			for {
+				item, err := this.token()
+				if err != nil {
+					return array, err
+				}
+				if item == pdfKeyword("endobj") {
+					return array, errPDFNotClosed
+				}
+				if item == pdfKeyword("]") {
+					return array, nil
+				}
+				obj, err := this.objectFrom(item)
+				if err != nil {
+					return array, err
+				}
+				array = append(array, obj)
+			}
+		case "<<":
+			dict := pdfDict{}
+			for {
+				key, err := this.token()
+				if err != nil {
+					return dict, err
+				}
+				if key == pdfKeyword("endobj") {
+					return dict, errPDFNotClosed
+				}
+				if key == pdfKeyword(">>") {
+					return dict, nil
+				}
+				name, ok := key.(pdfName)
+				if !ok {
+					continue
+				}
+				obj, err := this.object()
+				if err != nil {
+					return dict, err
+				}
+				dict[name] = obj
+			}
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return value, nil
+	case int:
+		// "12 0 R" is a reference to object 12
+		save := this.pos
+		generation, err := this.token()
+		if _, ok := generation.(int); ok && err == nil {
+			keyword, err := this.token()
+			if err == nil && keyword == pdfKeyword("R") {
+				return pdfRef(value), nil
+			}
+		}
+		this.pos = save
+		return value, nil
+	}
+	return token, nil
+}
+
+// stream reads the data of a stream whose dictionary was just read and whose "stream" keyword was consumed
+func (this *pdfLexer) stream(dict pdfDict) *pdfStream {
+	if this.peek(0) == '\r' {
+		this.pos++
+	}
+	if this.peek(0) == '\n' {
+		this.pos++
+	}
+	start := this.pos
+
+	// Length may be a reference or wrong, it is only trusted when endstream follows it
+	if length, ok := dict["Length"].(int); ok && length >= 0 && start+length <= len(this.data) {
+		rest := bytes.TrimLeft(this.data[start+length:], "\r\n \t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			this.pos = start + length
+			return &pdfStream{Dict: dict, Raw: this.data[start : start+length]}
+		}
+	}
+
+	end := bytes.Index(this.data[start:], []byte("endstream"))
+	if end < 0 {
+		end = len(this.data) - start
+	}
+	this.pos = start + end
+	return &pdfStream{Dict: dict, Raw: bytes.TrimRight(this.data[start:start+end], "\r\n")}
+}
+
+// pdfFile is every object found in a file by number, with the trailer dictionaries that point at the catalog
+type pdfFile struct {
+	objects  map[int]any
+	trailers []pdfDict
+}
+
+// parsePDF reads every object of a file. Each part of the file is lexed at most once, an object that cannot be
+// read is skipped up to where reading it stopped, so damaged or hostile files take time in proportion to their size.
+func parsePDF(ctx context.Context, data []byte) (*pdfFile, error) {
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-")) {
+		return nil, errors.New("file is not a PDF")
+	}
+
+	file := &pdfFile{objects: map[int]any{}}
+	end := 0
+	count := 0
+	for _, match := range pdfObjectPattern.FindAllSubmatchIndex(data, -1) {
+		// skips headers matched inside the object before, or in the middle of a number
+		if match[0] < end || (match[0] > 0 && !isPDFSpace(data[match[0]-1]) && !isPDFDelimiter(data[match[0]-1])) {
+			continue
+		}
+		number, err := strconv.Atoi(string(data[match[2]:match[3]]))
+		if err != nil {
+			continue
+		}
+
+		count++
+		if count > pdfMaxObjects {
+			return nil, errPDFTooManyObjects
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, "the PDF took too long to read")
+		}
+
+		lexer := &pdfLexer{data: data, pos: match[1]}
+		obj, err := lexer.object()
+		if err != nil {
+			end = lexer.pos
+			continue
+		}
+		if dict, ok := obj.(pdfDict); ok {
+			save := lexer.pos
+			keyword, _ := lexer.token()
+			if keyword == pdfKeyword("stream") {
+				obj = lexer.stream(dict)
+			} else {
+				lexer.pos = save
+			}
+		}
+		// a later object with the same number is an incremental update replacing it
+		file.objects[number] = obj
+		end = lexer.pos
+	}
+
+	for _, match := range pdfTrailerPattern.FindAllIndex(data, -1) {
+		lexer := &pdfLexer{data: data, pos: match[1] - 2}
+		obj, _ := lexer.object()
+		if dict, ok := obj.(pdfDict); ok {
+			file.trailers = append(file.trailers, dict)
+		}
+	}
+
+	if err := file.expandObjectStreams(ctx); err != nil {
+		return nil, err
+	}
+	for _, number := range file.numbers() {
+		// cross reference streams carry the trailer entries in newer files
+		if stream, ok := file.objects[number].(*pdfStream); ok && stream.Dict["Type"] == pdfName("XRef") {
+			file.trailers = append(file.trailers, stream.Dict)
+		}
+	}
+	return file, nil
+}
+
+// numbers returns the object numbers in order
+func (this *pdfFile) numbers() []int {
+	numbers := make([]int, 0, len(this.objects))
+	for number := range this.objects {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
+// expandObjectStreams adds the objects packed into object streams, which hold the page tree in most newer files
+func (this *pdfFile) expandObjectStreams(ctx context.Context) error {
+	for _, number := range this.numbers() {
+		stream, ok := this.objects[number].(*pdfStream)
+		if !ok || stream.Dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		data, err := this.decode(stream)
+		if err != nil {
+			continue
+		}
+
+		count := int(this.number(stream.Dict["N"]))
+		first := int(this.number(stream.Dict["First"]))
+		header := &pdfLexer{data: data}
+		for i := 0; i < count; i++ {
+			numberToken, numberErr := header.token()
+			offsetToken, offsetErr := header.token()
+			objectNumber, numberOK := numberToken.(int)
+			offset, offsetOK := offsetToken.(int)
+			if numberErr != nil || offsetErr != nil || !numberOK || !offsetOK {
+				break
+			}
+			if _, exists := this.objects[objectNumber]; exists || first+offset < 0 || first+offset >= len(data) {
+				continue
+			}
+			if len(this.objects) >= pdfMaxObjects {
+				return errPDFTooManyObjects
+			}
+			if err := ctx.Err(); err != nil {
+				return errors.Wrap(err, "the PDF took too long to read")
+			}
+
+			lexer := &pdfLexer{data: data, pos: first + offset}
+			obj, err := lexer.object()
+			if err == nil {
+				this.objects[objectNumber] = obj
+			}
+		}
+	}
+	return nil
+}
+
+// encrypted reports whether the file is encrypted, its strings and streams can not be read without the key
+func (this *pdfFile) encrypted() bool {
+	for _, trailer := range this.trailers {
+		if _, ok := trailer["Encrypt"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// catalog returns the document catalog, from the newest trailer that has one
+func (this *pdfFile) catalog() pdfDict {
+	for i := len(this.trailers) - 1; i >= 0; i-- {
+		if root := this.dict(this.trailers[i]["Root"]); root != nil {
+			return root
+		}
+	}
+	for _, number := range this.numbers() {
+		if dict, ok := this.objects[number].(pdfDict); ok && dict["Type"] == pdfName("Catalog") {
+			return dict
+		}
+	}
+	return nil
+}
+
+// resolve follows references to the object they point at, nil when it does not exist
+func (this *pdfFile) resolve(obj any) any {
+	for i := 0; i < pdfMaxDepth; i++ {
+		ref, ok := obj.(pdfRef)
+		if !ok {
+			return obj
+		}
+		obj = this.objects[int(ref)]
+	}
+	return nil
+}
+
+// dict resolves a dictionary, or the dictionary of a stream
+func (this *pdfFile) dict(obj any) pdfDict {
+	switch value := this.resolve(obj).(type) {
+	case pdfDict:
+		return value
+	case *pdfStream:
+		return value.Dict
+	}
+	return nil
+}
+
+func (this *pdfFile) array(obj any) []any {
+	array, _ := this.resolve(obj).([]any)
+	return array
+}
+
+func (this *pdfFile) number(obj any) float64 {
+	return pdfNumber(this.resolve(obj))
+}
+
+func pdfNumber(obj any) float64 {
+	switch value := obj.(type) {
+	case int:
+		return float64(value)
+	case float64:
+		return value
+	}
+	return 0
+}
+
+// decode applies a stream's filters to its data
+func (this *pdfFile) decode(stream *pdfStream) ([]byte, error) {
+	filters := []any{}
+	switch filter := this.resolve(stream.Dict["Filter"]).(type) {
+	case pdfName:
+		filters = append(filters, filter)
+	case []any:
+		filters = filter
+	}
+
+	data := stream.Raw
+	for _, filter := range filters {
+		name, _ := this.resolve(filter).(pdfName)
+		var err error
+		switch name {
+		case "FlateDecode", "Fl":
+			data, err = inflatePDF(data)
+		case "ASCIIHexDecode", "AHx":
+			lexer := &pdfLexer{data: append([]byte{'<'}, data...)}
+			data = lexer.hexString()
+		case "ASCII85Decode", "A85":
+			data, err = ascii85DecodePDF(data)
+		default:
+			return nil, errors.Errorf("unsupported stream filter %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// inflatePDF decompresses flate data. Truncated streams and bad checksums are common, whatever inflated before
+// the problem is kept.
+func inflatePDF(data []byte) ([]byte, error) {
+	var reader io.ReadCloser
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		// some writers leave out the zlib header
+		reader = flate.NewReader(bytes.NewReader(data))
+	}
+	defer reader.Close()
+
+	out, err := io.ReadAll(io.LimitReader(reader, MAX_DECODED_BYTES+1))
+	if len(out) > MAX_DECODED_BYTES {
+		return nil, errors.Errorf("stream is larger than %d MB uncompressed", MAX_DECODED_BYTES>>20)
+	}
+	if err != nil && len(out) == 0 {
+		return nil, errors.Wrap(err, "stream could not be decompressed")
+	}
+	return out, nil
+}
+
+func ascii85DecodePDF(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	data = bytes.TrimPrefix(data, []byte("<~"))
+	if end := bytes.Index(data, []byte("~>")); end >= 0 {
+		data = data[:end]
+	}
+	out, err := io.ReadAll(ascii85.NewDecoder(bytes.NewReader(data)))
+	if err != nil {
+		return nil, errors.Wrap(err, "stream could not be decoded")
+	}
+	return out, nil
+}
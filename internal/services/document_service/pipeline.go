@@ -0,0 +1,229 @@
+package document_service
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+// MAX_RAW_BYTES is the largest uploaded file that will be processed
+const MAX_RAW_BYTES = 50 << 20
+
+var (
+	// ErrNotUploadable is returned when an upload is confirmed for a document that is not waiting for one
+	ErrNotUploadable = errors.New("document is not waiting for an upload")
+	// ErrNotRetryable is returned when retrying a document that has not failed
+	ErrNotRetryable = errors.New("only failed documents can be retried")
+)
+
+// CreateUpload saves a pending document for a file the client is about to upload and returns the presigned
+// URL to PUT it to
+func CreateUpload(documentObj *document.Document, savingUser coremodel.Model) (string, error) {
+	if !Supported(documentObj.ContentType.Get()) {
+		return "", errors.Wrapf(ErrUnsupportedContentType, "%s", documentObj.ContentType.Get())
+	}
+	if documentObj.Name.Get() == "" {
+		documentObj.Name.Set(documentObj.FileName.Get())
+	}
+	documentObj.DocumentType.Set(DocumentTypeFor(documentObj.ContentType.Get()))
+	documentObj.Status.Set(document.STATUS_PENDING)
+
+	// Saved first so the raw key can be built from the document's id
+	err := documentObj.Save(savingUser)
+	if err != nil {
+		return "", err
+	}
+
+	key := documentObj.RawS3Key()
+	documentObj.RawS3URL.Set(document.ObjectURL(key))
+	err = documentObj.Save(savingUser)
+	if err != nil {
+		return "", err
+	}
+
+	return UploadURL(key, documentObj.ContentType.Get())
+}
+
+// ConfirmUpload marks a pending document's file as uploaded and queues it for processing
+func ConfirmUpload(documentObj *document.Document, savingUser coremodel.Model) error {
+	if documentObj.Status.Get() != document.STATUS_PENDING {
+		return ErrNotUploadable
+	}
+	return advance(documentObj, document.STATUS_RAW, savingUser)
+}
+
+// Retry restarts a failed document from the last step it completed
+func Retry(documentObj *document.Document, savingUser coremodel.Model) error {
+	if documentObj.Status.Get() != document.STATUS_ERROR {
+		return ErrNotRetryable
+	}
+	return advance(documentObj, documentObj.RetryStatus(), savingUser)
+}
+
+// Process extracts and chunks an uploaded document, fetches a webpage capture or crawls a sitemap, then writes
+// the processed artifact and queues indexing. An upload identical to a document already processed reuses its
+// work instead. Problems with the file itself are recorded on the document rather than failing the job.
+func Process(ctx context.Context, documentID types.UUID) error {
+	documentObj, err := document.Get(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	// Already moved on, the job was queued twice, or deleted while it waited
+	if tools.Empty(documentObj) || documentObj.Deleted.Get() == 1 || documentObj.Status.Get() != document.STATUS_RAW {
+		return nil
+	}
+
+	switch documentObj.DocumentType.Get() {
+	case document.DOCUMENT_TYPE_WEBPAGE_SUMMARY:
+		return captureWebpage(ctx, documentObj)
+	case document.DOCUMENT_TYPE_SITEMAP:
+		return crawlSitemap(ctx, documentObj)
+	}
+
+	// presigned uploads cannot limit their size, so it is checked before the file is read into memory
+	size, err := objectSize(ctx, documentObj.GetFilePath("raw_s3_url"))
+	if err != nil {
+		return fail(ctx, documentObj, errors.Wrap(err, "uploaded file could not be read"))
+	}
+	if size > MAX_RAW_BYTES {
+		return fail(ctx, documentObj, errors.Errorf("file is larger than %d MB", MAX_RAW_BYTES>>20))
+	}
+
+	raw, err := download(ctx, documentObj.GetFilePath("raw_s3_url"))
+	if err != nil {
+		return fail(ctx, documentObj, errors.Wrap(err, "uploaded file could not be read"))
+	}
+	// the object may have been replaced between the two reads
+	if len(raw) > MAX_RAW_BYTES {
+		return fail(ctx, documentObj, errors.Errorf("file is larger than %d MB", MAX_RAW_BYTES>>20))
+	}
+
+	documentObj.ContentHash.Set(hashContent(raw))
+	reused, err := reuseIdentical(ctx, documentObj)
+	if err != nil || reused {
+		return err
+	}
+
+	extraction, err := Extract(ctx, documentObj.ContentType.Get(), raw)
+	if err != nil {
+		return fail(ctx, documentObj, err)
+	}
+
+	return StoreExtraction(ctx, documentObj, extraction)
+}
+
+// StoreText chunks already extracted text into the document's processed artifact and queues indexing
+func StoreText(ctx context.Context, documentObj *document.Document, text string) error {
+	return StoreExtraction(ctx, documentObj, &Extraction{Text: text})
+}
+
+// StoreExtraction chunks an extraction into the document's processed artifact and queues indexing. Parts of the
+// file the extractor could not read are recorded on the document, the rest is still indexed.
+func StoreExtraction(ctx context.Context, documentObj *document.Document, extraction *Extraction) error {
+	artifact := &Artifact{
+		DocumentID:  documentObj.ID(),
+		ContentType: documentObj.ContentType.Get(),
+		Text:        extraction.Text,
+		Chunks:      ChunkText(extraction.Text, CHUNK_SIZE, CHUNK_OVERLAP),
+	}
+	LocateChunks(artifact.Chunks, extraction.Sections)
+	if len(artifact.Chunks) == 0 {
+		return fail(ctx, documentObj, ErrNoText)
+	}
+
+	err := WriteArtifact(ctx, documentObj, artifact)
+	if err != nil {
+		return fail(ctx, documentObj, errors.Wrap(err, "processed text could not be stored"))
+	}
+
+	err = setExtractionErrors(documentObj, extraction.Errors)
+	if err != nil {
+		return err
+	}
+
+	return advance(documentObj, document.STATUS_PROCESSED, nil)
+}
+
+// setExtractionErrors records the parts of the file that could not be read, clearing those of an earlier run
+func setExtractionErrors(documentObj *document.Document, extractionErrors []string) error {
+	metaData, err := documentObj.MetaData.Get()
+	if err != nil {
+		return err
+	}
+	if metaData == nil {
+		if len(extractionErrors) == 0 {
+			return nil
+		}
+		metaData = &document.MetaData{}
+	}
+	metaData.ExtractionErrors = extractionErrors
+	documentObj.MetaData.Set(metaData)
+	return nil
+}
+
+// Index runs every registered indexer over a processed document, then retires the versions it replaces
+func Index(ctx context.Context, documentID types.UUID) error {
+	documentObj, err := document.Get(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	// a deleted document's chunks are already gone and must not be recreated
+	if tools.Empty(documentObj) || documentObj.Deleted.Get() == 1 || documentObj.Status.Get() != document.STATUS_PROCESSED {
+		return nil
+	}
+
+	artifact, err := ReadArtifact(ctx, documentObj)
+	if err != nil {
+		return fail(ctx, documentObj, errors.Wrap(err, "processed text could not be read"))
+	}
+
+	for _, indexer := range getIndexers() {
+		err = indexer(ctx, documentObj, artifact)
+		if err != nil {
+			return fail(ctx, documentObj, errors.Wrap(err, "indexing failed"))
+		}
+	}
+
+	err = advance(documentObj, document.STATUS_INDEXED, nil)
+	if err != nil {
+		return err
+	}
+	return retireOlderVersions(ctx, documentObj)
+}
+
+// advance moves the document to the next status and queues the job that works on it
+func advance(documentObj *document.Document, status constants.Status, savingUser coremodel.Model) error {
+	err := documentObj.Transition(status)
+	if err != nil {
+		return err
+	}
+
+	err = documentObj.Save(savingUser)
+	if err != nil {
+		return err
+	}
+
+	switch status {
+	case document.STATUS_RAW:
+		return worker_jobs.QueueDocumentProcessJob(documentObj.ID())
+	case document.STATUS_PROCESSED:
+		return worker_jobs.QueueDocumentIndexJob(documentObj.ID())
+	case document.STATUS_INDEXED:
+		return worker_jobs.QueueDocumentVectorSyncJob(documentObj.ID())
+	}
+	return nil
+}
+
+// fail records why a document could not move on, the job itself succeeds so it is not retried blindly
+func fail(ctx context.Context, documentObj *document.Document, reason error) error {
+	log.ErrorContext(reason, ctx)
+	documentObj.Fail(reason.Error())
+	return documentObj.Save(nil)
+}
@@ -0,0 +1,207 @@
+package document_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/account"
+	"github.com/griffnb/techboss-ai-go/internal/models/change_log"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/organization"
+	"github.com/griffnb/techboss-ai-go/internal/models/retention_policy"
+	"github.com/griffnb/techboss-ai-go/internal/services/email_sender"
+	"github.com/pkg/errors"
+)
+
+// RETENTION_USER_URN is recorded as the user of the audit logs written by retention runs
+const RETENTION_USER_URN = "atl:system"
+
+// QueueRetention queues a retention run for every organization with an enabled policy
+func QueueRetention(ctx context.Context) error {
+	organizationIDs, err := retention_policy.FindOrganizationIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, organizationID := range organizationIDs {
+		err = worker_jobs.QueueDocumentRetentionJob(organizationID)
+		if err != nil {
+			log.ErrorContext(err, ctx)
+		}
+	}
+	return nil
+}
+
+// EnforceRetention runs an organization's policies, purging what they have expired, and emails its owners a summary
+// of what was removed. One failing policy does not stop the rest. A run purges at most constants.SYSTEM_LIMIT
+// documents per policy and queues itself again when there may be more.
+func EnforceRetention(ctx context.Context, organizationID types.UUID) error {
+	policies, err := retention_policy.FindActive(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	summary := &email_sender.RetentionSummaryEmailTemplate{}
+	more := false
+	for _, policyObj := range policies {
+		policySummary, full, err := enforcePolicy(ctx, policyObj)
+		if err != nil {
+			log.ErrorContext(errors.Wrapf(err, "enforcing retention policy %s", policyObj.ID()), ctx)
+			continue
+		}
+		if policySummary.Count == 0 {
+			continue
+		}
+		summary.Total += policySummary.Count
+		summary.Policies = append(summary.Policies, policySummary)
+		more = more || full
+	}
+
+	if more {
+		err = worker_jobs.QueueDocumentRetentionJob(organizationID)
+		if err != nil {
+			log.ErrorContext(err, ctx)
+		}
+	}
+
+	if summary.Total == 0 {
+		return nil
+	}
+	return emailRetentionSummary(ctx, organizationID, summary)
+}
+
+// enforcePolicy purges the documents a policy has expired and records what it removed on the policy and in an
+// audit log. full reports whether the batch was full, meaning more documents may be waiting.
+func enforcePolicy(
+	ctx context.Context,
+	policyObj *retention_policy.RetentionPolicy,
+) (*email_sender.RetentionSummaryPolicy, bool, error) {
+	action := policyObj.Action.Get()
+	policySummary := &email_sender.RetentionSummaryPolicy{
+		Name:   policyObj.Name.Get(),
+		Action: action.String(),
+		Days:   policyObj.Days.Get(),
+	}
+
+	documentObjs, err := document.FindExpired(
+		ctx,
+		policyObj.OrganizationID.Get(),
+		policyObj.Days.Get(),
+		policyObj.TagName.Get(),
+		action == retention_policy.ACTION_DELETE_RAW_FILES,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	purged := []map[string]any{}
+	for _, documentObj := range documentObjs {
+		// an earlier purge in this run may have already taken it, e.g. with the sitemap it was crawled from
+		documentObj, err := document.Get(ctx, documentObj.ID())
+		if err != nil {
+			return nil, false, err
+		}
+		if tools.Empty(documentObj) || documentObj.Deleted.Get() == 1 {
+			continue
+		}
+
+		switch action {
+		case retention_policy.ACTION_DELETE_RAW_FILES:
+			err = purgeRawFile(ctx, documentObj)
+		case retention_policy.ACTION_DELETE_DOCUMENTS:
+			err = Delete(ctx, documentObj, nil)
+		default:
+			return nil, false, errors.Errorf("unknown retention action %d", action)
+		}
+		if err != nil {
+			log.ErrorContext(errors.Wrapf(err, "purging document %s", documentObj.ID()), ctx)
+			continue
+		}
+
+		policySummary.AddDocument(documentObj.Name.Get())
+		purged = append(purged, map[string]any{
+			"id":   documentObj.ID(),
+			"name": documentObj.Name.Get(),
+		})
+	}
+
+	policyObj.LastRunAtTS.Set(time.Now().Unix())
+	policyObj.LastPurgedCount.Set(int64(len(purged)))
+	err = policyObj.Save(nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(purged) > 0 {
+		auditRetention(ctx, policyObj, purged)
+	}
+
+	return policySummary, len(documentObjs) >= constants.SYSTEM_LIMIT && len(purged) > 0, nil
+}
+
+// purgeRawFile deletes a document's uploaded file, its processed text and embeddings keep it searchable
+func purgeRawFile(ctx context.Context, documentObj *document.Document) error {
+	err := remove(ctx, documentObj.GetFilePath("raw_s3_url"))
+	if err != nil {
+		return err
+	}
+	documentObj.RawS3URL.Set("")
+	return documentObj.Save(nil)
+}
+
+// auditRetention records what a policy purged in the change log of the policy
+func auditRetention(ctx context.Context, policyObj *retention_policy.RetentionPolicy, purged []map[string]any) {
+	changeLog := change_log.NewDynamo()
+	changeLog.Type = retention_policy.TABLE
+	changeLog.UserURN = RETENTION_USER_URN
+	changeLog.ObjectID = policyObj.ID()
+	changeLog.ObjectURN = policyObj.URN.Get()
+	changeLog.BeforeValues = map[string]any{
+		"documents": purged,
+	}
+	changeLog.AfterValues = map[string]any{
+		"action":       policyObj.Action.Get().String(),
+		"days":         policyObj.Days.Get(),
+		"tag_name":     policyObj.TagName.Get(),
+		"purged_count": len(purged),
+	}
+	err := changeLog.Save(ctx)
+	if err != nil {
+		log.ErrorContext(errors.WithMessage(err, "saving retention audit log"), ctx)
+		_ = worker_jobs.QueueDynamoThrottleRetryJob(change_log.TABLE, changeLog)
+	}
+}
+
+// emailRetentionSummary sends the summary of a run to the organization's owners
+func emailRetentionSummary(
+	ctx context.Context,
+	organizationID types.UUID,
+	summary *email_sender.RetentionSummaryEmailTemplate,
+) error {
+	organizationObj, err := organization.Get(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(organizationObj) {
+		return nil
+	}
+	summary.OrganizationName = organizationObj.Name.Get()
+
+	owners, err := account.FindOrganizationOwners(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	to := []string{}
+	for _, ownerObj := range owners {
+		if !tools.Empty(ownerObj.Email.Get()) {
+			to = append(to, ownerObj.Email.Get())
+		}
+	}
+
+	return email_sender.SendRetentionSummaryEmail(ctx, to, summary)
+}
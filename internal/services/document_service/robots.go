@@ -0,0 +1,137 @@
+package document_service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ROBOTS_AGENT is the product token robots.txt groups are matched against
+const ROBOTS_AGENT = "techbossbot"
+
+// Robots holds the robots.txt rules that apply to the crawler
+type Robots struct {
+	rules      []*robotsRule
+	CrawlDelay time.Duration
+	Sitemaps   []string
+}
+
+type robotsRule struct {
+	allow   bool
+	length  int
+	pattern *regexp.Regexp
+}
+
+// robotsGroup is one User-agent block
+type robotsGroup struct {
+	agents     []string
+	rules      []*robotsRule
+	crawlDelay time.Duration
+}
+
+// ParseRobots reads a robots.txt, keeping the group for our agent or, when there is none, the * group
+func ParseRobots(body string) *Robots {
+	robots := &Robots{}
+	groups := []*robotsGroup{}
+	var current *robotsGroup
+	lastWasAgent := false
+
+	for _, line := range strings.Split(body, "\n") {
+		if index := strings.Index(line, "#"); index >= 0 {
+			line = line[:index]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if current == nil || !lastWasAgent {
+				current = &robotsGroup{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			lastWasAgent = true
+			continue
+		case "allow", "disallow":
+			if current != nil && value != "" {
+				current.rules = append(current.rules, newRobotsRule(key == "allow", value))
+			}
+		case "crawl-delay":
+			seconds, err := strconv.ParseFloat(value, 64)
+			if current != nil && err == nil && seconds > 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		case "sitemap":
+			if value != "" {
+				robots.Sitemaps = append(robots.Sitemaps, value)
+			}
+		}
+		lastWasAgent = false
+	}
+
+	var wildcard, specific *robotsGroup
+	for _, group := range groups {
+		for _, agent := range group.agents {
+			if agent == "*" && wildcard == nil {
+				wildcard = group
+			}
+			if agent != "*" && strings.Contains(ROBOTS_AGENT, agent) && specific == nil {
+				specific = group
+			}
+		}
+	}
+
+	group := specific
+	if group == nil {
+		group = wildcard
+	}
+	if group != nil {
+		robots.rules = group.rules
+		robots.CrawlDelay = group.crawlDelay
+	}
+
+	return robots
+}
+
+// newRobotsRule compiles a path rule, * matches anything and a trailing $ anchors the end
+func newRobotsRule(allow bool, path string) *robotsRule {
+	anchored := strings.HasSuffix(path, "$")
+	path = strings.TrimSuffix(path, "$")
+
+	expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(path), `\*`, ".*")
+	if anchored {
+		expression += "$"
+	}
+
+	return &robotsRule{
+		allow:   allow,
+		length:  len(path),
+		pattern: regexp.MustCompile(expression),
+	}
+}
+
+// Allowed reports whether a path and query may be fetched, the longest matching rule wins and allow wins ties
+func (this *Robots) Allowed(path string) bool {
+	if this == nil {
+		return true
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	var match *robotsRule
+	for _, rule := range this.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if match == nil || rule.length > match.length || (rule.length == match.length && rule.allow) {
+			match = rule
+		}
+	}
+	return match == nil || match.allow
+}
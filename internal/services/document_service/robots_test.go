@@ -0,0 +1,74 @@
+package document_service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRobots(t *testing.T) {
+	robots := ParseRobots(`# comment
+User-agent: *
+Disallow: /private
+Crawl-delay: 2
+
+User-agent: otherbot
+Disallow: /
+
+Sitemap: https://example.com/sitemap_index.xml
+`)
+
+	if robots.CrawlDelay != 2*time.Second {
+		t.Errorf("unexpected crawl delay %s", robots.CrawlDelay)
+	}
+	if len(robots.Sitemaps) != 1 || robots.Sitemaps[0] != "https://example.com/sitemap_index.xml" {
+		t.Errorf("unexpected sitemaps %v", robots.Sitemaps)
+	}
+	if !robots.Allowed("/blog/post") {
+		t.Error("expected /blog/post to be allowed")
+	}
+	if robots.Allowed("/private/page") {
+		t.Error("expected /private/page to be disallowed")
+	}
+}
+
+func TestParseRobots_SpecificAgent(t *testing.T) {
+	robots := ParseRobots(`User-agent: *
+Disallow: /
+
+User-agent: TechBossBot
+Disallow: /admin
+Allow: /admin/help$
+`)
+
+	if !robots.Allowed("/docs") {
+		t.Error("expected our group to replace the * group")
+	}
+	if robots.Allowed("/admin/users") {
+		t.Error("expected /admin/users to be disallowed")
+	}
+	if !robots.Allowed("/admin/help") {
+		t.Error("expected the longer allow rule to win")
+	}
+	if robots.Allowed("/admin/help/more") {
+		t.Error("expected $ to anchor the allow rule")
+	}
+}
+
+func TestRobotsAllowed_Wildcard(t *testing.T) {
+	robots := ParseRobots("User-agent: *\nDisallow: /*.pdf$\nDisallow: /search?\n")
+
+	if robots.Allowed("/files/report.pdf") {
+		t.Error("expected pdf to be disallowed")
+	}
+	if !robots.Allowed("/files/report.pdf.html") {
+		t.Error("expected html page to be allowed")
+	}
+	if robots.Allowed("/search?q=ai") {
+		t.Error("expected search to be disallowed")
+	}
+
+	var missing *Robots
+	if !missing.Allowed("/anything") {
+		t.Error("expected a missing robots.txt to allow everything")
+	}
+}
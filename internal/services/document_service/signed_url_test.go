@@ -0,0 +1,61 @@
+package document_service
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignedFileURL(t *testing.T) {
+	original := signingKey
+	signingKey = func() []byte {
+		return []byte("test-key")
+	}
+	defer func() {
+		signingKey = original
+	}()
+
+	now := time.Unix(1700000000, 0)
+	link, expiresTS := SignedFileURL("doc-1", now)
+	if !strings.HasPrefix(link, "/document/doc-1/file?") || expiresTS != now.Add(SIGNED_URL_TTL).Unix() {
+		t.Fatalf("unexpected link %s expiring %d", link, expiresTS)
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires := parsed.Query().Get("expires")
+	signature := parsed.Query().Get("signature")
+
+	if err := VerifyFileSignature("doc-1", expires, signature, now.Add(time.Minute)); err != nil {
+		t.Errorf("expected a valid link, got %v", err)
+	}
+	if VerifyFileSignature("doc-2", expires, signature, now) == nil {
+		t.Error("expected the link to be bound to its document")
+	}
+	if VerifyFileSignature("doc-1", expires, signature, now.Add(SIGNED_URL_TTL+time.Second)) == nil {
+		t.Error("expected an expired link to fail")
+	}
+	if VerifyFileSignature("doc-1", "9999999999", signature, now) == nil {
+		t.Error("expected a changed expiry to fail")
+	}
+}
+
+func TestServeInline(t *testing.T) {
+	cases := map[string]bool{
+		"application/pdf":           true,
+		"text/plain; charset=utf-8": true,
+		"image/png":                 true,
+		"text/html":                 false,
+		"image/svg+xml":             false,
+		"application/xhtml+xml":     false,
+		"":                          false,
+	}
+	for contentType, expected := range cases {
+		if ServeInline(contentType) != expected {
+			t.Errorf("%q: expected %v", contentType, expected)
+		}
+	}
+}
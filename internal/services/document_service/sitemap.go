@@ -0,0 +1,321 @@
+package document_service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	CRAWL_DEFAULT_MAX_PAGES = 100
+	CRAWL_MAX_PAGES         = 1000
+	CRAWL_DEFAULT_DELAY     = time.Second
+	CRAWL_MIN_DELAY         = 250 * time.Millisecond
+	// CRAWL_MAX_DELAY caps the robots.txt Crawl-delay so one site cannot hold a worker all day
+	CRAWL_MAX_DELAY = 30 * time.Second
+	// CRAWL_MAX_SITEMAPS bounds how many sitemap files an index may expand into
+	CRAWL_MAX_SITEMAPS = 50
+)
+
+// sitemapXML reads both urlset and sitemapindex documents
+type sitemapXML struct {
+	URLs []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// ParseSitemap returns the page URLs of a urlset and the sitemap URLs of a sitemap index, gzipped files are
+// decompressed
+func ParseSitemap(body []byte) ([]string, []string, error) {
+	if len(body) > 2 && body[0] == 0x1f && body[1] == 0x8b {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		body, err = io.ReadAll(io.LimitReader(reader, WEBPAGE_MAX_BYTES*10))
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+	}
+
+	parsed := &sitemapXML{}
+	err := xml.Unmarshal(body, parsed)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "not a sitemap")
+	}
+
+	pages := []string{}
+	for _, entry := range parsed.URLs {
+		if loc := strings.TrimSpace(entry.Loc); loc != "" {
+			pages = append(pages, loc)
+		}
+	}
+	sitemaps := []string{}
+	for _, entry := range parsed.Sitemaps {
+		if loc := strings.TrimSpace(entry.Loc); loc != "" {
+			sitemaps = append(sitemaps, loc)
+		}
+	}
+	return pages, sitemaps, nil
+}
+
+// fetchFunc GETs a URL returning the body, the final URL after redirects and the content type
+type fetchFunc func(ctx context.Context, rawURL string, accept string) ([]byte, string, string, error)
+
+// Crawler discovers pages through sitemaps and fetches them, honoring robots.txt and waiting between
+// requests to the same host
+type Crawler struct {
+	Include  []*regexp.Regexp
+	Exclude  []*regexp.Regexp
+	MaxPages int
+	Delay    time.Duration
+
+	fetch       fetchFunc
+	now         func() time.Time
+	sleep       func(ctx context.Context, duration time.Duration) error
+	robots      map[string]*Robots
+	lastRequest map[string]time.Time
+}
+
+// NewCrawler builds a crawler, patterns match URL paths with * matching anything
+func NewCrawler(include []string, exclude []string, maxPages int, delay time.Duration) *Crawler {
+	if maxPages <= 0 {
+		maxPages = CRAWL_DEFAULT_MAX_PAGES
+	}
+	if maxPages > CRAWL_MAX_PAGES {
+		maxPages = CRAWL_MAX_PAGES
+	}
+	if delay <= 0 {
+		delay = CRAWL_DEFAULT_DELAY
+	}
+	if delay < CRAWL_MIN_DELAY {
+		delay = CRAWL_MIN_DELAY
+	}
+	if delay > CRAWL_MAX_DELAY {
+		delay = CRAWL_MAX_DELAY
+	}
+
+	return &Crawler{
+		Include:     compilePatterns(include),
+		Exclude:     compilePatterns(exclude),
+		MaxPages:    maxPages,
+		Delay:       delay,
+		fetch:       fetch,
+		now:         time.Now,
+		sleep:       sleepContext,
+		robots:      map[string]*Robots{},
+		lastRequest: map[string]time.Time{},
+	}
+}
+
+// CrawlResult is what discovery found
+type CrawlResult struct {
+	Pages   []string
+	Skipped int
+}
+
+// Discover reads the sitemaps for a start URL and returns the pages to capture. The start URL is used as the
+// sitemap when it looks like one, otherwise the site's robots.txt Sitemap lines or /sitemap.xml are read.
+// Only pages on the start URL's host that pass the patterns and robots.txt are returned, up to MaxPages.
+func (this *Crawler) Discover(ctx context.Context, startURL string) (*CrawlResult, error) {
+	start, err := url.Parse(strings.TrimSpace(startURL))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	robots, err := this.getRobots(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := []string{start.String()}
+	if !isSitemapURL(start) {
+		queue = robots.Sitemaps
+		if len(queue) == 0 {
+			queue = []string{start.Scheme + "://" + start.Host + "/sitemap.xml"}
+		}
+	}
+
+	result := &CrawlResult{Pages: []string{}}
+	seenPages := map[string]bool{}
+	seenSitemaps := map[string]bool{}
+	read := 0
+
+	for len(queue) > 0 && len(result.Pages) < this.MaxPages {
+		sitemapURL := queue[0]
+		queue = queue[1:]
+		if seenSitemaps[sitemapURL] {
+			continue
+		}
+		seenSitemaps[sitemapURL] = true
+
+		read++
+		if read > CRAWL_MAX_SITEMAPS {
+			break
+		}
+
+		body, err := this.politeFetch(ctx, sitemapURL, "application/xml, text/xml")
+		if err != nil {
+			// The sitemap named by the caller must be readable, ones listed in an index may be stale
+			if read == 1 {
+				return nil, err
+			}
+			continue
+		}
+
+		pages, sitemaps, err := ParseSitemap(body)
+		if err != nil {
+			if read == 1 {
+				return nil, err
+			}
+			continue
+		}
+		queue = append(queue, sitemaps...)
+
+		for _, page := range pages {
+			if len(result.Pages) >= this.MaxPages {
+				break
+			}
+			pageURL, err := url.Parse(page)
+			if err != nil || !strings.EqualFold(pageURL.Host, start.Host) || seenPages[pageURL.String()] {
+				continue
+			}
+			seenPages[pageURL.String()] = true
+
+			if !this.Matches(pageURL.Path) || !robots.Allowed(pageURL.RequestURI()) {
+				result.Skipped++
+				continue
+			}
+			result.Pages = append(result.Pages, pageURL.String())
+		}
+	}
+
+	return result, nil
+}
+
+// FetchPage downloads a discovered page and extracts its readable content
+func (this *Crawler) FetchPage(ctx context.Context, pageURL string) (*Webpage, error) {
+	err := this.wait(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body, finalURL, contentType, err := this.fetch(ctx, pageURL, "text/html, text/plain;q=0.9")
+	if err != nil {
+		return nil, err
+	}
+	return readWebpage(body, finalURL, contentType)
+}
+
+// Matches reports whether a URL path passes the include and exclude patterns
+func (this *Crawler) Matches(path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	for _, pattern := range this.Exclude {
+		if pattern.MatchString(path) {
+			return false
+		}
+	}
+	if len(this.Include) == 0 {
+		return true
+	}
+	for _, pattern := range this.Include {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// getRobots loads and caches the robots.txt of a host, a missing file allows everything
+func (this *Crawler) getRobots(ctx context.Context, site *url.URL) (*Robots, error) {
+	if robots, ok := this.robots[site.Host]; ok {
+		return robots, nil
+	}
+
+	robots := &Robots{}
+	body, err := this.politeFetch(ctx, site.Scheme+"://"+site.Host+"/robots.txt", "text/plain")
+	if err == nil {
+		robots = ParseRobots(string(body))
+	}
+	if ctx.Err() != nil {
+		return nil, errors.WithStack(ctx.Err())
+	}
+
+	if robots.CrawlDelay > this.Delay {
+		this.Delay = min(robots.CrawlDelay, CRAWL_MAX_DELAY)
+	}
+	this.robots[site.Host] = robots
+	return robots, nil
+}
+
+func (this *Crawler) politeFetch(ctx context.Context, rawURL string, accept string) ([]byte, error) {
+	err := this.wait(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	body, _, _, err := this.fetch(ctx, rawURL, accept)
+	return body, err
+}
+
+// wait sleeps until Delay has passed since the last request to the URL's host
+func (this *Crawler) wait(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	host := strings.ToLower(parsed.Host)
+	if last, ok := this.lastRequest[host]; ok {
+		remaining := this.Delay - this.now().Sub(last)
+		if remaining > 0 {
+			err = this.sleep(ctx, remaining)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	this.lastRequest[host] = this.now()
+	return nil
+}
+
+func isSitemapURL(parsed *url.URL) bool {
+	path := strings.ToLower(parsed.Path)
+	return strings.HasSuffix(path, ".xml") || strings.HasSuffix(path, ".xml.gz") || strings.Contains(path, "sitemap")
+}
+
+func compilePatterns(patterns []string) []*regexp.Regexp {
+	compiled := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+		compiled = append(compiled, regexp.MustCompile(expression))
+	}
+	return compiled
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	}
+}
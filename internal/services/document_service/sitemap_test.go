@@ -0,0 +1,162 @@
+package document_service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseSitemap(t *testing.T) {
+	pages, sitemaps, err := ParseSitemap([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<url><loc> https://example.com/ </loc></url>
+<url><loc>https://example.com/pricing</loc><lastmod>2024-01-01</lastmod></url>
+</urlset>`))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(pages) != 2 || pages[0] != "https://example.com/" || pages[1] != "https://example.com/pricing" {
+		t.Errorf("unexpected pages %v", pages)
+	}
+	if len(sitemaps) != 0 {
+		t.Errorf("unexpected sitemaps %v", sitemaps)
+	}
+}
+
+func TestParseSitemap_IndexGzipped(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	_, _ = writer.Write([]byte(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<sitemap><loc>https://example.com/sitemap-blog.xml</loc></sitemap>
+</sitemapindex>`))
+	_ = writer.Close()
+
+	pages, sitemaps, err := ParseSitemap(buffer.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(pages) != 0 || len(sitemaps) != 1 || sitemaps[0] != "https://example.com/sitemap-blog.xml" {
+		t.Errorf("unexpected pages %v sitemaps %v", pages, sitemaps)
+	}
+}
+
+// fakeCrawler serves fixed responses and records requests and waits instead of making them
+func fakeCrawler(
+	responses map[string]string,
+	include []string,
+	exclude []string,
+	maxPages int,
+) (*Crawler, *[]string, *[]time.Duration) {
+	requests := &[]string{}
+	waits := &[]time.Duration{}
+	now := time.Unix(0, 0)
+
+	crawler := NewCrawler(include, exclude, maxPages, time.Second)
+	crawler.fetch = func(_ context.Context, rawURL string, _ string) ([]byte, string, string, error) {
+		*requests = append(*requests, rawURL)
+		body, ok := responses[rawURL]
+		if !ok {
+			return nil, "", "", errors.New("status 404")
+		}
+		return []byte(body), rawURL, "text/html", nil
+	}
+	crawler.now = func() time.Time {
+		return now
+	}
+	crawler.sleep = func(_ context.Context, duration time.Duration) error {
+		*waits = append(*waits, duration)
+		now = now.Add(duration)
+		return nil
+	}
+	return crawler, requests, waits
+}
+
+func TestCrawlerDiscover(t *testing.T) {
+	crawler, requests, waits := fakeCrawler(map[string]string{
+		"https://example.com/robots.txt": "User-agent: *\nDisallow: /admin\nCrawl-delay: 3\n" +
+			"Sitemap: https://example.com/sitemap_index.xml\n",
+		"https://example.com/sitemap_index.xml": `<sitemapindex>
+<sitemap><loc>https://example.com/sitemap-docs.xml</loc></sitemap>
+<sitemap><loc>https://example.com/sitemap-missing.xml</loc></sitemap>
+</sitemapindex>`,
+		"https://example.com/sitemap-docs.xml": `<urlset>
+<url><loc>https://example.com/docs/intro</loc></url>
+<url><loc>https://example.com/docs/intro</loc></url>
+<url><loc>https://example.com/docs/setup</loc></url>
+<url><loc>https://example.com/admin/users</loc></url>
+<url><loc>https://example.com/blog/news</loc></url>
+<url><loc>https://other.com/docs/intro</loc></url>
+<url><loc>https://example.com/docs/draft-1</loc></url>
+</urlset>`,
+	}, []string{"/docs/*"}, []string{"/docs/draft-*"}, 10)
+
+	result, err := crawler.Discover(context.Background(), "https://example.com/")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if len(result.Pages) != 2 || result.Pages[0] != "https://example.com/docs/intro" ||
+		result.Pages[1] != "https://example.com/docs/setup" {
+		t.Errorf("unexpected pages %v", result.Pages)
+	}
+	// admin by robots.txt, blog by include, draft by exclude
+	if result.Skipped != 3 {
+		t.Errorf("expected 3 skipped, got %d", result.Skipped)
+	}
+	if len(*requests) != 4 {
+		t.Errorf("unexpected requests %v", *requests)
+	}
+	if crawler.Delay != 3*time.Second {
+		t.Errorf("expected the robots.txt crawl delay, got %s", crawler.Delay)
+	}
+	for _, wait := range *waits {
+		if wait != 3*time.Second {
+			t.Errorf("unexpected wait %s", wait)
+		}
+	}
+}
+
+func TestCrawlerDiscover_MaxPages(t *testing.T) {
+	crawler, _, _ := fakeCrawler(map[string]string{
+		"https://example.com/sitemap.xml": `<urlset>
+<url><loc>https://example.com/a</loc></url>
+<url><loc>https://example.com/b</loc></url>
+<url><loc>https://example.com/c</loc></url>
+</urlset>`,
+	}, nil, nil, 2)
+
+	result, err := crawler.Discover(context.Background(), "https://example.com/sitemap.xml")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(result.Pages) != 2 {
+		t.Errorf("expected 2 pages, got %v", result.Pages)
+	}
+}
+
+func TestCrawlerDiscover_MissingSitemap(t *testing.T) {
+	crawler, _, _ := fakeCrawler(map[string]string{}, nil, nil, 10)
+
+	_, err := crawler.Discover(context.Background(), "https://example.com/")
+	if err == nil {
+		t.Error("expected an error when the site has no sitemap")
+	}
+}
+
+func TestNewCrawler_Delay(t *testing.T) {
+	cases := map[time.Duration]time.Duration{
+		0:                CRAWL_DEFAULT_DELAY,
+		time.Millisecond: CRAWL_MIN_DELAY,
+		time.Hour:        CRAWL_MAX_DELAY,
+	}
+	for delay, expected := range cases {
+		crawler := NewCrawler(nil, nil, 10, delay)
+		if crawler.Delay != expected {
+			t.Errorf("delay %v: expected %v, got %v", delay, expected, crawler.Delay)
+		}
+	}
+}
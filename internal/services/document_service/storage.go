@@ -0,0 +1,94 @@
+package document_service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+// STORAGE_TIMEOUT bounds a single artifact write
+const STORAGE_TIMEOUT = 60 * time.Second
+
+var storageClient = &http.Client{Timeout: STORAGE_TIMEOUT}
+
+// ErrStorageNotConfigured is returned when there is no S3 client to reach the documents bucket with
+var ErrStorageNotConfigured = errors.New("document storage is not configured")
+
+// UploadURL returns a presigned URL the client PUTs a raw file to
+func UploadURL(key string, contentType string) (string, error) {
+	return environment.GetS3().GetPreSignedPutURL(document.Bucket(), key, contentType)
+}
+
+// download reads an object from the documents bucket
+func download(ctx context.Context, key string) ([]byte, error) {
+	return environment.GetS3().DownloadToBuffer(ctx, document.Bucket(), key)
+}
+
+// objectSize returns the size of an object in the documents bucket without downloading it
+func objectSize(ctx context.Context, key string) (int64, error) {
+	client := environment.GetS3Client()
+	if client == nil {
+		return 0, ErrStorageNotConfigured
+	}
+
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(document.Bucket()),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "head of %s", key)
+	}
+	return aws.ToInt64(head.ContentLength), nil
+}
+
+// upload writes an object to the documents bucket through a presigned PUT
+func upload(ctx context.Context, key string, contentType string, body []byte) error {
+	url, err := UploadURL(key, contentType)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = int64(len(body))
+
+	resp, err := storageClient.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("upload of %s returned %d", key, resp.StatusCode)
+	}
+	return nil
+}
+
+// remove deletes an object from the documents bucket, deleting a missing object is not an error
+func remove(ctx context.Context, key string) error {
+	client := environment.GetS3Client()
+	if client == nil {
+		return ErrStorageNotConfigured
+	}
+
+	_, err := client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(document.Bucket()),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "delete of %s", key)
+	}
+	return nil
+}
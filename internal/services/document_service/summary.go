@@ -0,0 +1,66 @@
+package document_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
+)
+
+// SUMMARY_INPUT_LIMIT is how much of a page is sent to the model, in bytes
+const SUMMARY_INPUT_LIMIT = 24000
+
+// StructuredResponder produces schema constrained JSON, satisfied by *openai.Service
+type StructuredResponder interface {
+	CreateStructuredResponse(ctx context.Context, structuredRequest *openai.StructuredRequest) ([]byte, error)
+}
+
+// Summary is the model's digest of a captured page
+type Summary struct {
+	Summary  string   `json:"summary"`
+	KeyFacts []string `json:"key_facts"`
+}
+
+const summaryInstructions = `You summarize web pages for a business knowledge base.
+Write a summary of at most three short paragraphs describing what the page is about.
+List the key facts a support or sales agent would need: names, prices, dates, limits, contact details and policies.
+Only use information on the page. Each key fact is one self contained sentence.`
+
+var summarySchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"summary": map[string]any{"type": "string"},
+		"key_facts": map[string]any{
+			"type":  "array",
+			"items": map[string]any{"type": "string"},
+		},
+	},
+	"required":             []string{"summary", "key_facts"},
+	"additionalProperties": false,
+}
+
+// Summarize asks the model for a summary and key facts of a page
+func Summarize(ctx context.Context, responder StructuredResponder, title string, text string) (*Summary, error) {
+	if len(text) > SUMMARY_INPUT_LIMIT {
+		text = text[:runeBoundary(text, SUMMARY_INPUT_LIMIT)]
+	}
+
+	raw, err := responder.CreateStructuredResponse(ctx, &openai.StructuredRequest{
+		Instructions: summaryInstructions,
+		Input:        fmt.Sprintf("Title: %s\n\n%s", title, text),
+		SchemaName:   "page_summary",
+		Schema:       summarySchema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{}
+	err = json.Unmarshal(raw, summary)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse page summary")
+	}
+	return summary, nil
+}
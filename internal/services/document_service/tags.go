@@ -0,0 +1,82 @@
+package document_service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/object_tag"
+	"github.com/griffnb/techboss-ai-go/internal/models/tag"
+	"github.com/pkg/errors"
+)
+
+// MAX_TAGS keeps a document's labels to something a person can scan
+const MAX_TAGS = 20
+
+// ErrTooManyTags is returned when a document is given more than MAX_TAGS tags
+var ErrTooManyTags = errors.New("too many tags")
+
+// SetTags replaces a document's tags with the named ones, creating tags that do not exist yet. Names are trimmed
+// and matched case insensitively.
+func SetTags(ctx context.Context, documentObj *document.Document, names []string, savingUser coremodel.Model) error {
+	names = normalizeTagNames(names)
+	if len(names) > MAX_TAGS {
+		return ErrTooManyTags
+	}
+
+	tagIDs := []types.UUID{}
+	for _, name := range names {
+		tagObj, err := tag.GetOrCreateByNameAndType(ctx, name, constants.TAG_TYPE_DOCUMENT, savingUser)
+		if err != nil {
+			return err
+		}
+		tagIDs = append(tagIDs, tagObj.ID())
+	}
+
+	existing, err := object_tag.GetByObjectAndType(ctx, documentObj.URN.Get(), constants.TAG_TYPE_DOCUMENT)
+	if err != nil {
+		return err
+	}
+
+	kept := []types.UUID{}
+	for _, objectTagObj := range existing {
+		if slices.Contains(tagIDs, objectTagObj.TagID.Get()) {
+			kept = append(kept, objectTagObj.TagID.Get())
+			continue
+		}
+		err = objectTagObj.Delete()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tagID := range tagIDs {
+		if slices.Contains(kept, tagID) {
+			continue
+		}
+		_, err = object_tag.AddTag(documentObj.URN.Get(), tagID, savingUser)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeTagNames trims names and drops blanks and case insensitive duplicates, keeping the first spelling
+func normalizeTagNames(names []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
@@ -0,0 +1,14 @@
+package document_service
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeTagNames(t *testing.T) {
+	got := normalizeTagNames([]string{" Policy ", "", "HR", "policy", "  ", "Onboarding"})
+	want := []string{"Policy", "HR", "Onboarding"}
+	if !slices.Equal(got, want) {
+		t.Errorf("normalizeTagNames() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package document_service
+
+import (
+	"context"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/services/vector_store_service"
+	"github.com/pkg/errors"
+)
+
+// SyncVectorStore brings a document's file in its organization's provider vector store up to date with its
+// processed text, or removes it when the document no longer belongs there. Nothing is done when no provider is
+// configured, and a file the provider could not ingest is recorded on the document rather than retried.
+func SyncVectorStore(ctx context.Context, documentID types.UUID) error {
+	documentObj, err := document.Get(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(documentObj) {
+		return nil
+	}
+
+	if !vector_store_service.Syncable(documentObj) {
+		err = vector_store_service.RemoveDocument(ctx, documentObj)
+	} else {
+		var artifact *Artifact
+		artifact, err = ReadArtifact(ctx, documentObj)
+		if err != nil {
+			return err
+		}
+		err = vector_store_service.SyncDocument(ctx, documentObj, artifact.Text)
+	}
+
+	switch {
+	case errors.Is(err, vector_store_service.ErrNotConfigured):
+		return nil
+	case errors.Is(err, vector_store_service.ErrIngestionFailed):
+		log.ErrorContext(err, ctx)
+		return nil
+	}
+	return err
+}
+
+// removeFromVectorStore deletes a document's provider file, a failure is left for reconciliation to clean up
+func removeFromVectorStore(ctx context.Context, documentObj *document.Document) {
+	err := vector_store_service.RemoveDocument(ctx, documentObj)
+	if err != nil && !errors.Is(err, vector_store_service.ErrNotConfigured) {
+		log.ErrorContext(err, ctx)
+	}
+}
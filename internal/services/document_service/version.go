@@ -0,0 +1,201 @@
+package document_service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/pkg/errors"
+)
+
+// ErrNotVersionable is returned when a new version is uploaded for something other than the current version of
+// an uploaded file
+var ErrNotVersionable = errors.New("only the current version of an uploaded file can be replaced")
+
+// CreateVersion saves a pending document as the next version of previousObj and returns the presigned URL to PUT
+// its file to. The previous version stays searchable until the new one is indexed.
+func CreateVersion(
+	ctx context.Context,
+	previousObj *document.Document,
+	documentObj *document.Document,
+	savingUser coremodel.Model,
+) (string, error) {
+	if !previousObj.IsCurrent() || tools.Empty(previousObj.RawS3URL.Get()) ||
+		previousObj.DocumentType.Get() == document.DOCUMENT_TYPE_WEBPAGE_SUMMARY ||
+		previousObj.DocumentType.Get() == document.DOCUMENT_TYPE_SITEMAP {
+		return "", ErrNotVersionable
+	}
+
+	// numbered after every upload so far, earlier uploads may still be processing or have failed
+	versions, err := document.FindVersions(ctx, previousObj.VersionRootID())
+	if err != nil {
+		return "", err
+	}
+	version := previousObj.Version.Get()
+	for _, versionObj := range versions {
+		version = max(version, versionObj.Version.Get())
+	}
+
+	documentObj.RootID.Set(previousObj.VersionRootID())
+	documentObj.Version.Set(version + 1)
+	documentObj.OrganizationID.Set(previousObj.OrganizationID.Get())
+	documentObj.Sharing.Set(previousObj.Sharing.Get())
+	documentObj.DocumentGroupID.Set(previousObj.DocumentGroupID.Get())
+	if documentObj.Name.Get() == "" {
+		documentObj.Name.Set(previousObj.Name.Get())
+	}
+	return CreateUpload(documentObj, savingUser)
+}
+
+// hashContent is the SHA-256 of a document's content, hex encoded
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// reuseIdentical skips extraction and embedding when an identical document was already processed, copying its
+// artifact and embedded chunks. It reports false when there is nothing to reuse and the document should be
+// processed normally.
+func reuseIdentical(ctx context.Context, documentObj *document.Document) (bool, error) {
+	sourceObj, err := document.FindIdentical(ctx, documentObj)
+	if err != nil {
+		return false, err
+	}
+	if tools.Empty(sourceObj) {
+		return false, nil
+	}
+
+	artifact, err := ReadArtifact(ctx, sourceObj)
+	if err != nil {
+		// the source can still be processed from scratch
+		log.ErrorContext(err, ctx)
+		return false, nil
+	}
+	artifact.DocumentID = documentObj.ID()
+
+	err = copyChunks(ctx, sourceObj, documentObj)
+	if err != nil {
+		return false, err
+	}
+
+	err = WriteArtifact(ctx, documentObj, artifact)
+	if err != nil {
+		return false, err
+	}
+
+	documentObj.DuplicateOfID.Set(sourceObj.ID())
+	return true, advance(documentObj, document.STATUS_PROCESSED, nil)
+}
+
+// copyChunks gives a document its own copy of another document's embedded chunks, so it stays searchable however
+// the two are grouped, shared or deleted later
+func copyChunks(ctx context.Context, sourceObj *document.Document, documentObj *document.Document) error {
+	chunks, err := document_chunk.FindByDocument(ctx, sourceObj.ID())
+	if err != nil {
+		return err
+	}
+
+	err = document_chunk.DeleteByDocument(ctx, documentObj.ID())
+	if err != nil {
+		return err
+	}
+
+	for _, sourceChunkObj := range chunks {
+		chunkObj := document_chunk.New()
+		chunkObj.OrganizationID.Set(documentObj.OrganizationID.Get())
+		chunkObj.DocumentID.Set(documentObj.ID())
+		chunkObj.DocumentGroupID.Set(documentObj.DocumentGroupID.Get())
+		chunkObj.ChunkIndex.Set(sourceChunkObj.ChunkIndex.Get())
+		chunkObj.Content.Set(sourceChunkObj.Content.Get())
+		chunkObj.StartOffset.Set(sourceChunkObj.StartOffset.Get())
+		chunkObj.EndOffset.Set(sourceChunkObj.EndOffset.Get())
+		chunkObj.Page.Set(sourceChunkObj.Page.Get())
+		chunkObj.Heading.Set(sourceChunkObj.Heading.Get())
+		chunkObj.EmbeddingModel.Set(sourceChunkObj.EmbeddingModel.Get())
+		chunkObj.Embedding.Set(sourceChunkObj.Embedding.Get())
+		err = chunkObj.Save(nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retireOlderVersions takes the versions a newly indexed document replaces out of retrieval and the provider
+// vector store. Their files and artifacts are kept so they can still be downloaded.
+func retireOlderVersions(ctx context.Context, documentObj *document.Document) error {
+	if tools.Empty(documentObj.RootID.Get()) {
+		return nil
+	}
+
+	versions, err := document.FindVersions(ctx, documentObj.RootID.Get())
+	if err != nil {
+		return err
+	}
+
+	for _, versionObj := range versions {
+		if versionObj.ID() == documentObj.ID() || !versionObj.IsCurrent() ||
+			versionObj.Version.Get() > documentObj.Version.Get() {
+			continue
+		}
+
+		versionObj.SupersededByID.Set(documentObj.ID())
+		err = versionObj.Save(nil)
+		if err != nil {
+			return err
+		}
+		err = document_chunk.DeleteByDocument(ctx, versionObj.ID())
+		if err != nil {
+			return err
+		}
+		removeFromVectorStore(ctx, versionObj)
+	}
+	return nil
+}
+
+// restorePreviousVersion makes the version a deleted document replaced current again and queues it to be indexed
+func restorePreviousVersion(ctx context.Context, documentObj *document.Document, savingUser coremodel.Model) error {
+	if !documentObj.IsCurrent() {
+		return nil
+	}
+
+	versions, err := document.FindVersions(ctx, documentObj.VersionRootID())
+	if err != nil {
+		return err
+	}
+
+	// newest first, so the first one this document superseded is the one it replaced
+	for _, versionObj := range versions {
+		if versionObj.SupersededByID.Get() != documentObj.ID() {
+			continue
+		}
+
+		versionObj.SupersededByID.Set("")
+		if versionObj.Status.Get() != document.STATUS_INDEXED {
+			return versionObj.Save(savingUser)
+		}
+		return advance(versionObj, document.STATUS_PROCESSED, savingUser)
+	}
+	return nil
+}
+
+// chunksCurrent reports whether a document's stored chunks already embed the artifact with the given model, as
+// they do after they were copied from an identical document
+func chunksCurrent(chunks []*document_chunk.DocumentChunk, artifact *Artifact, embeddingModel string) bool {
+	if len(chunks) == 0 || len(chunks) != len(artifact.Chunks) {
+		return false
+	}
+	for i, chunkObj := range chunks {
+		chunk := artifact.Chunks[i]
+		if chunkObj.EmbeddingModel.Get() != embeddingModel || chunkObj.Content.Get() != chunk.Text ||
+			chunkObj.Page.Get() != int64(chunk.Page) || chunkObj.Heading.Get() != chunk.Heading {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package document_service
+
+import (
+	"testing"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+)
+
+func TestHashContent(t *testing.T) {
+	got := hashContent([]byte("abc"))
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got != want {
+		t.Errorf("hashContent() = %s, want %s", got, want)
+	}
+}
+
+func TestChunksCurrent(t *testing.T) {
+	artifact := &Artifact{Chunks: []*Chunk{{Index: 0, Text: "first"}, {Index: 1, Text: "second"}}}
+
+	newChunk := func(text string, model string) *document_chunk.DocumentChunk {
+		chunkObj := document_chunk.New()
+		chunkObj.Content.Set(text)
+		chunkObj.EmbeddingModel.Set(model)
+		return chunkObj
+	}
+
+	tests := []struct {
+		name   string
+		chunks []*document_chunk.DocumentChunk
+		want   bool
+	}{
+		{"no chunks", nil, false},
+		{"matching", []*document_chunk.DocumentChunk{newChunk("first", "m"), newChunk("second", "m")}, true},
+		{"other model", []*document_chunk.DocumentChunk{newChunk("first", "m"), newChunk("second", "old")}, false},
+		{"other text", []*document_chunk.DocumentChunk{newChunk("first", "m"), newChunk("changed", "m")}, false},
+		{"missing chunk", []*document_chunk.DocumentChunk{newChunk("first", "m")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chunksCurrent(tt.chunks, artifact, "m"); got != tt.want {
+				t.Errorf("chunksCurrent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
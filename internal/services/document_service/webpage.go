@@ -0,0 +1,174 @@
+package document_service
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// WEBPAGE_FETCH_TIMEOUT bounds a whole page fetch, redirects included
+	WEBPAGE_FETCH_TIMEOUT = 20 * time.Second
+	// WEBPAGE_MAX_BYTES is the largest page body that is read
+	WEBPAGE_MAX_BYTES = 5 << 20
+	// WEBPAGE_MAX_REDIRECTS stops redirect loops
+	WEBPAGE_MAX_REDIRECTS = 5
+	WEBPAGE_USER_AGENT    = "TechBossBot/1.0 (+https://techboss.ai/bot)"
+)
+
+var (
+	// ErrPageTooLarge is returned when a page body is over WEBPAGE_MAX_BYTES
+	ErrPageTooLarge = errors.New("page is too large")
+
+	htmlTitlePattern       = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlCommentPattern     = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlBoilerplatePattern = regexp.MustCompile(`(?is)<(nav|header|footer|aside|form|svg|iframe|button|select)\b.*?</(nav|header|footer|aside|form|svg|iframe|button|select)>`)
+	htmlArticlePattern     = regexp.MustCompile(`(?is)<(article|main)\b[^>]*>(.*?)</(article|main)>`)
+	htmlBodyPattern        = regexp.MustCompile(`(?is)<body\b[^>]*>(.*)</body>`)
+)
+
+// Webpage is the readable content of a fetched page
+type Webpage struct {
+	URL   string
+	Title string
+	Text  string
+}
+
+var webpageClient = &http.Client{
+	Timeout: WEBPAGE_FETCH_TIMEOUT,
+	Transport: &http.Transport{
+		DialContext:           (&net.Dialer{Timeout: 10 * time.Second, Control: common.PublicAddressOnly}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: WEBPAGE_FETCH_TIMEOUT,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= WEBPAGE_MAX_REDIRECTS {
+			return errors.Errorf("stopped after %d redirects", WEBPAGE_MAX_REDIRECTS)
+		}
+		return ValidateWebpageURL(req.URL.String())
+	},
+}
+
+// ValidateWebpageURL checks a URL can be captured
+func ValidateWebpageURL(rawURL string) error {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return errors.Errorf("%s is not an http url", rawURL)
+	}
+	return nil
+}
+
+// FetchWebpage downloads a page and extracts its readable content
+func FetchWebpage(ctx context.Context, rawURL string) (*Webpage, error) {
+	body, finalURL, contentType, err := fetch(ctx, rawURL, "text/html, text/plain;q=0.9")
+	if err != nil {
+		return nil, err
+	}
+	return readWebpage(body, finalURL, contentType)
+}
+
+// readWebpage extracts the readable content of a fetched page
+func readWebpage(body []byte, finalURL string, contentType string) (*Webpage, error) {
+	mediaType := NormalizeContentType(contentType)
+	if mediaType == "" {
+		mediaType = "text/html"
+	}
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" && mediaType != "text/plain" {
+		return nil, errors.Wrapf(ErrUnsupportedContentType, "%s", mediaType)
+	}
+	if !utf8.Valid(body) {
+		body = []byte(strings.ToValidUTF8(string(body), ""))
+	}
+
+	page := &Webpage{URL: finalURL}
+	if mediaType == "text/plain" {
+		page.Text = strings.TrimSpace(string(body))
+	} else {
+		page.Title, page.Text = ReadableText(string(body))
+	}
+
+	if page.Text == "" {
+		return nil, ErrNoText
+	}
+	return page, nil
+}
+
+// fetch GETs a URL, reading at most WEBPAGE_MAX_BYTES of the body
+func fetch(ctx context.Context, rawURL string, accept string) ([]byte, string, string, error) {
+	err := ValidateWebpageURL(rawURL)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSpace(rawURL), nil)
+	if err != nil {
+		return nil, "", "", errors.WithStack(err)
+	}
+	req.Header.Set("User-Agent", WEBPAGE_USER_AGENT)
+	req.Header.Set("Accept", accept)
+
+	resp, err := webpageClient.Do(req)
+	if err != nil {
+		return nil, "", "", errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", "", errors.Errorf("%s returned %d", rawURL, resp.StatusCode)
+	}
+	if resp.ContentLength > WEBPAGE_MAX_BYTES {
+		return nil, "", "", ErrPageTooLarge
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, WEBPAGE_MAX_BYTES+1))
+	if err != nil {
+		return nil, "", "", errors.WithStack(err)
+	}
+	if len(body) > WEBPAGE_MAX_BYTES {
+		return nil, "", "", ErrPageTooLarge
+	}
+
+	return body, resp.Request.URL.String(), resp.Header.Get("Content-Type"), nil
+}
+
+// ReadableText returns the title and main content of an HTML page. Scripts, navigation, headers, footers
+// and forms are dropped, and the article or main element is preferred over the whole body when there is one.
+func ReadableText(page string) (string, string) {
+	title := ""
+	if match := htmlTitlePattern.FindStringSubmatch(page); match != nil {
+		title = strings.TrimSpace(HTMLToText(match[1]))
+	}
+
+	page = htmlCommentPattern.ReplaceAllString(page, "")
+	page = htmlHiddenPattern.ReplaceAllString(page, "")
+	page = htmlBoilerplatePattern.ReplaceAllString(page, "")
+
+	content := ""
+	for _, match := range htmlArticlePattern.FindAllStringSubmatch(page, -1) {
+		if len(match[2]) > len(content) {
+			content = match[2]
+		}
+	}
+	if strings.TrimSpace(HTMLToText(content)) == "" {
+		content = page
+		if match := htmlBodyPattern.FindStringSubmatch(page); match != nil {
+			content = match[1]
+		}
+	}
+
+	return title, HTMLToText(content)
+}
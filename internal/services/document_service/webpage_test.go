@@ -0,0 +1,64 @@
+package document_service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadableText(t *testing.T) {
+	page := `<!DOCTYPE html><html><head><title>Pricing &ndash; Acme</title><script>track()</script></head>
+<body>
+<header><a href="/">Acme</a></header>
+<nav><ul><li>Home</li><li>Pricing</li></ul></nav>
+<main>
+<h1>Pricing</h1>
+<!-- hidden note -->
+<p>The starter plan is $10 a month.</p>
+<form><input name="email"><button>Subscribe</button></form>
+</main>
+<footer>Copyright Acme</footer>
+</body></html>`
+
+	title, text := ReadableText(page)
+	if title != "Pricing – Acme" {
+		t.Errorf("unexpected title %q", title)
+	}
+	if text != "Pricing\n\nThe starter plan is $10 a month." {
+		t.Errorf("unexpected text %q", text)
+	}
+}
+
+func TestReadableText_NoMain(t *testing.T) {
+	_, text := ReadableText(`<html><body><nav>Menu</nav><div>Only content</div><style>.a{}</style></body></html>`)
+	if text != "Only content" {
+		t.Errorf("unexpected text %q", text)
+	}
+}
+
+func TestValidateWebpageURL(t *testing.T) {
+	for _, valid := range []string{"https://example.com", "http://example.com/page?a=1"} {
+		if err := ValidateWebpageURL(valid); err != nil {
+			t.Errorf("%s: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"ftp://example.com", "file:///etc/passwd", "example.com", "https://"} {
+		if err := ValidateWebpageURL(invalid); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
+
+func TestFetchWebpage_RefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<p>internal</p>"))
+	}))
+	defer server.Close()
+
+	_, err := FetchWebpage(context.Background(), server.URL)
+	if err == nil || !strings.Contains(err.Error(), "not public") {
+		t.Errorf("expected loopback to be refused, got %v", err)
+	}
+}
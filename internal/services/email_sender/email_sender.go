@@ -28,6 +28,11 @@ func GetEmailTemplate(filename string) (string, error) {
 type MessageID string
 
 func Send(ctx context.Context, messageID MessageID, to []string, subject, message string) error {
+	return SendWithReplyTo(ctx, messageID, REPLY_TO, to, subject, message)
+}
+
+// SendWithReplyTo sends like Send but directs replies to another address, such as an agent's inbox
+func SendWithReplyTo(ctx context.Context, messageID MessageID, replyTo string, to []string, subject, message string) error {
 	// Email isnt Setup
 	if tools.Empty(environment.GetConfig().Email) {
 		if environment.IsUnitTest() || environment.IsLocalDev() {
@@ -41,7 +46,7 @@ func Send(ctx context.Context, messageID MessageID, to []string, subject, messag
 	req := email.EmailRequest{
 		To:        to,
 		From:      from,
-		ReplyTo:   REPLY_TO,
+		ReplyTo:   replyTo,
 		Subject:   subject,
 		Body:      message,
 		MessageID: string(messageID),
@@ -0,0 +1,75 @@
+package email_sender
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RETENTION_SUMMARY_MAX_DOCUMENTS is how many document names are listed per policy, the rest are counted
+const RETENTION_SUMMARY_MAX_DOCUMENTS = 25
+
+type RetentionSummaryEmailTemplate struct {
+	Logo             string
+	OrganizationName string
+	Total            int
+	Policies         []*RetentionSummaryPolicy
+}
+
+type RetentionSummaryPolicy struct {
+	Name      string
+	Action    string
+	Days      int64
+	Count     int
+	Documents []string
+	More      int
+}
+
+// AddDocument lists a purged document under the policy, past RETENTION_SUMMARY_MAX_DOCUMENTS it is only counted
+func (this *RetentionSummaryPolicy) AddDocument(name string) {
+	this.Count++
+	if len(this.Documents) >= RETENTION_SUMMARY_MAX_DOCUMENTS {
+		this.More++
+		return
+	}
+	this.Documents = append(this.Documents, name)
+}
+
+func BuildRetentionSummaryEmail(data *RetentionSummaryEmailTemplate) (string, error) {
+	data.Logo = "https://app.atlas.net/img/logo.png"
+
+	emailTemplate, err := GetEmailTemplate("RetentionSummary.html")
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("emailTemplate").Parse(emailTemplate)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	var bufBody strings.Builder
+	err = tmpl.Execute(&bufBody, data)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return bufBody.String(), nil
+}
+
+// SendRetentionSummaryEmail tells an organization's owners what its retention policies purged
+func SendRetentionSummaryEmail(ctx context.Context, to []string, data *RetentionSummaryEmailTemplate) error {
+	if len(to) == 0 {
+		return nil
+	}
+
+	message, err := BuildRetentionSummaryEmail(data)
+	if err != nil {
+		return err
+	}
+	subject := fmt.Sprintf("%d documents were removed by your retention policies", data.Total)
+
+	return Send(ctx, "retention_summary", to, subject, message)
+}
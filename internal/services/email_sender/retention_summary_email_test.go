@@ -0,0 +1,31 @@
+package email_sender_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/griffnb/techboss-ai-go/internal/services/email_sender"
+)
+
+func TestBuildRetentionSummaryEmail(t *testing.T) {
+	policy := &email_sender.RetentionSummaryPolicy{Name: "Contracts", Action: "Delete documents", Days: 90}
+	for i := 0; i < email_sender.RETENTION_SUMMARY_MAX_DOCUMENTS+2; i++ {
+		policy.AddDocument("Signed contract.pdf")
+	}
+
+	template, err := email_sender.BuildRetentionSummaryEmail(&email_sender.RetentionSummaryEmailTemplate{
+		OrganizationName: "Acme",
+		Total:            policy.Count,
+		Policies:         []*email_sender.RetentionSummaryPolicy{policy},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(template, "{{") || strings.Contains(template, "}}") {
+		t.Fatal("Template not parsed properly")
+	}
+	if !strings.Contains(template, "Contracts") || !strings.Contains(template, "and 2 more") {
+		t.Fatal("Template is missing the policy summary")
+	}
+}
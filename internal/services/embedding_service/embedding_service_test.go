@@ -0,0 +1,99 @@
+package embedding_service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+)
+
+func TestFakeProvider_Deterministic(t *testing.T) {
+	provider := NewFakeProvider(64)
+
+	first, err := provider.Embed(context.Background(), []string{"Refund policy for annual plans"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewFakeProvider(64).Embed(context.Background(), []string{"refund policy, for annual plans!"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first[0]) != 64 {
+		t.Fatalf("expected 64 dimensions, got %d", len(first[0]))
+	}
+	if score := Cosine(first[0], second[0]); score < 0.9999 {
+		t.Errorf("expected the same words to embed the same, got %f", score)
+	}
+}
+
+func TestFakeProvider_Similarity(t *testing.T) {
+	vectors, err := NewFakeProvider(256).Embed(context.Background(), []string{
+		"how do refunds work",
+		"refunds are issued within 14 days of a request",
+		"our office dog is named biscuit",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	related := Cosine(vectors[0], vectors[1])
+	unrelated := Cosine(vectors[0], vectors[2])
+	if related <= unrelated {
+		t.Errorf("expected shared words to score higher, related %f unrelated %f", related, unrelated)
+	}
+}
+
+func TestCosine(t *testing.T) {
+	if score := Cosine([]float32{1, 0}, []float32{0, 1}); score != 0 {
+		t.Errorf("expected orthogonal vectors to score 0, got %f", score)
+	}
+	if score := Cosine([]float32{1, 1}, []float32{2, 2}); score < 0.9999 {
+		t.Errorf("expected parallel vectors to score 1, got %f", score)
+	}
+	if score := Cosine([]float32{1}, []float32{1, 2}); score != 0 {
+		t.Errorf("expected mismatched sizes to score 0, got %f", score)
+	}
+}
+
+type countingProvider struct {
+	FakeProvider
+	calls int
+}
+
+func (this *countingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	this.calls++
+	return this.FakeProvider.Embed(ctx, texts)
+}
+
+func TestEmbedAll_Batches(t *testing.T) {
+	provider := &countingProvider{FakeProvider: FakeProvider{Dimensions: 8}}
+	texts := make([]string, MAX_BATCH*2+1)
+	for i := range texts {
+		texts[i] = "text"
+	}
+
+	vectors, err := EmbedAll(context.Background(), provider, texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != len(texts) {
+		t.Errorf("expected %d vectors, got %d", len(texts), len(vectors))
+	}
+	if provider.calls != 3 {
+		t.Errorf("expected 3 batches, got %d", provider.calls)
+	}
+}
+
+func TestOpenAIProvider_ModelIsIndexed(t *testing.T) {
+	provider := &OpenAIProvider{model: openai.DefaultEmbeddingModel, dimensions: OPENAI_DIMENSIONS}
+
+	dimensions, ok := document_chunk.INDEXED_DIMENSIONS[provider.Model()]
+	if !ok {
+		t.Fatalf("model %s has no chunk embedding index", provider.Model())
+	}
+	if dimensions != OPENAI_DIMENSIONS {
+		t.Fatalf("index dimensions %d, want %d", dimensions, OPENAI_DIMENSIONS)
+	}
+}
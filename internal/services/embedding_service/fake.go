@@ -0,0 +1,45 @@
+package embedding_service
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// FakeProvider embeds without a network call by hashing each word into a bucket, so the same text always gets
+// the same vector and texts sharing words score as similar. Meant for tests and local development.
+type FakeProvider struct {
+	Dimensions int
+}
+
+// NewFakeProvider builds a fake provider of the given size
+func NewFakeProvider(dimensions int) *FakeProvider {
+	return &FakeProvider{Dimensions: dimensions}
+}
+
+func (this *FakeProvider) Model() string {
+	return fmt.Sprintf("fake/%d", this.Dimensions)
+}
+
+func (this *FakeProvider) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = this.embed(text)
+	}
+	return vectors, nil
+}
+
+func (this *FakeProvider) embed(text string) []float32 {
+	vector := make([]float32, this.Dimensions)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(word))
+		vector[hash.Sum32()%uint32(this.Dimensions)]++
+	}
+	return Normalize(vector)
+}
@@ -0,0 +1,43 @@
+package embedding_service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+)
+
+// OPENAI_DIMENSIONS shortens OpenAI vectors, small enough to index and store cheaply
+const OPENAI_DIMENSIONS = 1536
+
+// OpenAIProvider embeds with the OpenAI embeddings API
+type OpenAIProvider struct {
+	service    *openai.Service
+	model      string
+	dimensions int
+}
+
+// NewOpenAIProviderFromEnv builds a provider from the configured OpenAI key
+func NewOpenAIProviderFromEnv() (*OpenAIProvider, error) {
+	service, err := openai.NewServiceFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &OpenAIProvider{
+		service:    service,
+		model:      openai.DefaultEmbeddingModel,
+		dimensions: OPENAI_DIMENSIONS,
+	}, nil
+}
+
+func (this *OpenAIProvider) Model() string {
+	return fmt.Sprintf("openai/%s/%d", this.model, this.dimensions)
+}
+
+func (this *OpenAIProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return this.service.CreateEmbeddings(ctx, &openai.EmbeddingRequest{
+		Model:      this.model,
+		Input:      texts,
+		Dimensions: this.dimensions,
+	})
+}
@@ -0,0 +1,63 @@
+package embedding_service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// MAX_BATCH is how many texts are sent to a provider in one call
+const MAX_BATCH = 100
+
+// Provider turns text into vectors. Vectors from different models are not comparable, so Model names the model
+// and its size and is stored next to every vector.
+type Provider interface {
+	Model() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+var (
+	providerLock sync.RWMutex
+	provider     Provider
+)
+
+// SetProvider replaces the provider used for all embeddings, e.g. with a FakeProvider in tests
+func SetProvider(newProvider Provider) {
+	providerLock.Lock()
+	defer providerLock.Unlock()
+	provider = newProvider
+}
+
+// GetProvider returns the provider set with SetProvider, defaulting to OpenAI
+func GetProvider() (Provider, error) {
+	providerLock.RLock()
+	current := provider
+	providerLock.RUnlock()
+	if current != nil {
+		return current, nil
+	}
+
+	openAIProvider, err := NewOpenAIProviderFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return openAIProvider, nil
+}
+
+// EmbedAll embeds any number of texts in batches of MAX_BATCH, returning one vector per text in order
+func EmbedAll(ctx context.Context, embedder Provider, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += MAX_BATCH {
+		end := min(start+MAX_BATCH, len(texts))
+		batch, err := embedder.Embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) != end-start {
+			return nil, errors.Errorf("%s returned %d vectors for %d texts", embedder.Model(), len(batch), end-start)
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
@@ -0,0 +1,36 @@
+package embedding_service
+
+import "math"
+
+// Normalize scales a vector to unit length in place, a zero vector is returned unchanged
+func Normalize(vector []float32) []float32 {
+	var sum float64
+	for _, value := range vector {
+		sum += float64(value) * float64(value)
+	}
+	if sum == 0 {
+		return vector
+	}
+	length := math.Sqrt(sum)
+	for i, value := range vector {
+		vector[i] = float32(float64(value) / length)
+	}
+	return vector
+}
+
+// Cosine returns the cosine similarity of two vectors, 0 when their sizes differ or either is zero
+func Cosine(a []float32, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,76 @@
+package eval_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_run"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+	"github.com/griffnb/techboss-ai-go/internal/services/agent_service"
+)
+
+// CheckAssertion evaluates a single assertion against an agent reply
+func CheckAssertion(ctx context.Context, provider Provider, assertion *eval_suite.Assertion, output string) *eval_run.AssertionResult {
+	result := &eval_run.AssertionResult{Type: string(assertion.Type)}
+
+	switch assertion.Type {
+	case eval_suite.ASSERTION_CONTAINS:
+		result.Passed = strings.Contains(strings.ToLower(output), strings.ToLower(assertion.Value))
+		if !result.Passed {
+			result.Detail = fmt.Sprintf("missing %q", assertion.Value)
+		}
+
+	case eval_suite.ASSERTION_REGEX:
+		pattern, err := regexp.Compile(assertion.Value)
+		if err != nil {
+			result.Detail = err.Error()
+			return result
+		}
+		result.Passed = pattern.MatchString(output)
+		if !result.Passed {
+			result.Detail = fmt.Sprintf("no match for %s", assertion.Value)
+		}
+
+	case eval_suite.ASSERTION_JSON_SCHEMA:
+		var value any
+		err := json.Unmarshal([]byte(extractJSON(output)), &value)
+		if err == nil {
+			err = agent_service.ValidateSchema(assertion.Schema, value)
+		}
+		result.Passed = err == nil
+		if err != nil {
+			result.Detail = err.Error()
+		}
+
+	case eval_suite.ASSERTION_LLM_JUDGE:
+		judgement, err := provider.Judge(ctx, assertion.Rubric, output)
+		if err != nil {
+			result.Detail = err.Error()
+			return result
+		}
+		result.Score = judgement.Score
+		result.Passed = judgement.Score >= assertion.MinScore
+		result.Detail = judgement.Reason
+
+	default:
+		result.Detail = fmt.Sprintf("unknown assertion type %q", assertion.Type)
+	}
+
+	return result
+}
+
+// extractJSON strips a markdown code fence when the model wrapped its JSON in one
+func extractJSON(output string) string {
+	trimmed := strings.TrimSpace(output)
+	if !strings.HasPrefix(trimmed, "```") {
+		return trimmed
+	}
+
+	trimmed = strings.TrimPrefix(trimmed, "```json")
+	trimmed = strings.TrimPrefix(trimmed, "```")
+	trimmed = strings.TrimSuffix(trimmed, "```")
+	return strings.TrimSpace(trimmed)
+}
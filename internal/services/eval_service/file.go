@@ -0,0 +1,43 @@
+package eval_service
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+	"github.com/pkg/errors"
+)
+
+// SuiteFile is a suite definition kept on disk so it can run offline in CI against the stub provider
+type SuiteFile struct {
+	Name          string                 `json:"name"`
+	AgentSettings *agent.Settings        `json:"agent_settings"`
+	Cases         []*eval_suite.EvalCase `json:"cases"`
+}
+
+// LoadSuiteFile reads and validates a suite definition from disk
+func LoadSuiteFile(path string) (*SuiteFile, error) {
+	// nolint:gosec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	suiteFile := &SuiteFile{}
+	err = json.Unmarshal(data, suiteFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse suite file %s", path)
+	}
+
+	if suiteFile.AgentSettings == nil {
+		suiteFile.AgentSettings = &agent.Settings{}
+	}
+
+	err = eval_suite.ValidateCases(suiteFile.Cases)
+	if err != nil {
+		return nil, err
+	}
+
+	return suiteFile, nil
+}
@@ -0,0 +1,37 @@
+package eval_service
+
+import (
+	"strings"
+)
+
+// modelPricing is USD per million input and output tokens
+var modelPricing = map[string][2]float64{
+	"gpt-4.1":      {2.00, 8.00},
+	"gpt-4.1-mini": {0.40, 1.60},
+	"gpt-4.1-nano": {0.10, 0.40},
+	"gpt-4o":       {2.50, 10.00},
+	"gpt-4o-mini":  {0.15, 0.60},
+	"gpt-5":        {1.25, 10.00},
+	"gpt-5-mini":   {0.25, 2.00},
+	"gpt-5-nano":   {0.05, 0.40},
+}
+
+// EstimateCost returns the USD cost of a completion, matching dated model snapshots to their base model.
+// Unknown models cost zero.
+func EstimateCost(model string, inputTokens int, outputTokens int) float64 {
+	prices, ok := modelPricing[model]
+	if !ok {
+		best := ""
+		for name := range modelPricing {
+			if strings.HasPrefix(model, name+"-") && len(name) > len(best) {
+				best = name
+			}
+		}
+		if best == "" {
+			return 0
+		}
+		prices = modelPricing[best]
+	}
+
+	return (float64(inputTokens)*prices[0] + float64(outputTokens)*prices[1]) / 1_000_000
+}
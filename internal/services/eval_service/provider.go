@@ -0,0 +1,102 @@
+package eval_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
+)
+
+// Provider generates agent replies and grades them for an eval run
+type Provider interface {
+	Complete(ctx context.Context, completionRequest *CompletionRequest) (*Completion, error)
+	Judge(ctx context.Context, rubric string, output string) (*Judgement, error)
+}
+
+type CompletionRequest struct {
+	CaseName     string
+	Model        string
+	Instructions string
+	Messages     []*eval_suite.EvalMessage
+}
+
+type Completion struct {
+	Model        string
+	Text         string
+	InputTokens  int
+	OutputTokens int
+}
+
+// Judgement is an LLM judge score between 0 and 1
+type Judgement struct {
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+var judgeSchema = map[string]any{
+	"type":                 "object",
+	"additionalProperties": false,
+	"required":             []any{"score", "reason"},
+	"properties": map[string]any{
+		"score":  map[string]any{"type": "number"},
+		"reason": map[string]any{"type": "string"},
+	},
+}
+
+const judgeInstructions = "You grade AI assistant replies against a rubric. " +
+	"Return a score between 0 and 1 where 1 fully satisfies the rubric, and a one sentence reason."
+
+// OpenAIProvider runs evals against the OpenAI responses API
+type OpenAIProvider struct {
+	service *openai.Service
+}
+
+func NewOpenAIProvider(service *openai.Service) *OpenAIProvider {
+	return &OpenAIProvider{service: service}
+}
+
+func (this *OpenAIProvider) Complete(ctx context.Context, completionRequest *CompletionRequest) (*Completion, error) {
+	messages := []*openai.InputMessage{}
+	for _, message := range completionRequest.Messages {
+		messages = append(messages, &openai.InputMessage{Role: message.Role, Content: message.Content})
+	}
+
+	result, err := this.service.CreateResponse(ctx, &openai.ResponseRequest{
+		Model:        completionRequest.Model,
+		Instructions: completionRequest.Instructions,
+		Messages:     messages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Completion{
+		Model:        result.Model,
+		Text:         result.Text,
+		InputTokens:  result.InputTokens,
+		OutputTokens: result.OutputTokens,
+	}, nil
+}
+
+func (this *OpenAIProvider) Judge(ctx context.Context, rubric string, output string) (*Judgement, error) {
+	raw, err := this.service.CreateStructuredResponse(ctx, &openai.StructuredRequest{
+		Instructions: judgeInstructions,
+		Input:        fmt.Sprintf("Rubric:\n%s\n\nReply:\n%s", rubric, output),
+		SchemaName:   "judgement",
+		Schema:       judgeSchema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	judgement := &Judgement{}
+	err = json.Unmarshal(raw, judgement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse judgement")
+	}
+
+	return judgement, nil
+}
@@ -0,0 +1,215 @@
+package eval_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/griffnb/core/lib/model/coremodel"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_run"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// NewProvider returns the provider registered under name
+func NewProvider(name string, cases []*eval_suite.EvalCase) (Provider, error) {
+	switch name {
+	case eval_run.PROVIDER_STUB:
+		return NewStubProvider(cases), nil
+	case eval_run.PROVIDER_OPENAI, "":
+		service, err := openai.NewServiceFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		return NewOpenAIProvider(service), nil
+	}
+
+	return nil, errors.Errorf("unknown eval provider %s", name)
+}
+
+// RunCases runs every case against the provider without persisting anything
+func RunCases(ctx context.Context, provider Provider, settings *agent.Settings, cases []*eval_suite.EvalCase) []*eval_run.CaseResult {
+	results := []*eval_run.CaseResult{}
+	for _, evalCase := range cases {
+		results = append(results, runCase(ctx, provider, settings, evalCase))
+	}
+	return results
+}
+
+func runCase(ctx context.Context, provider Provider, settings *agent.Settings, evalCase *eval_suite.EvalCase) *eval_run.CaseResult {
+	result := &eval_run.CaseResult{
+		CaseName:   evalCase.Name,
+		Assertions: []*eval_run.AssertionResult{},
+	}
+
+	start := time.Now()
+	completion, err := provider.Complete(ctx, &CompletionRequest{
+		CaseName:     evalCase.Name,
+		Model:        settings.Model,
+		Instructions: settings.Instructions,
+		Messages:     evalCase.Messages,
+	})
+	result.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Output = completion.Text
+	result.InputTokens = completion.InputTokens
+	result.OutputTokens = completion.OutputTokens
+	result.Cost = EstimateCost(completion.Model, completion.InputTokens, completion.OutputTokens)
+
+	result.Passed = true
+	for _, assertion := range evalCase.Assertions {
+		assertionResult := CheckAssertion(ctx, provider, assertion, completion.Text)
+		result.Assertions = append(result.Assertions, assertionResult)
+		if !assertionResult.Passed {
+			result.Passed = false
+		}
+	}
+
+	return result
+}
+
+// CreateRun stores a pending run for a suite, snapshotting the agent settings being tested.
+// settingsOverride lets a candidate version be evaluated before it is saved on the agent.
+func CreateRun(
+	ctx context.Context,
+	suiteObj *eval_suite.EvalSuite,
+	agentObj *agent.Agent,
+	providerName string,
+	label string,
+	settingsOverride *agent.Settings,
+	savingUser coremodel.Model,
+) (*eval_run.EvalRun, error) {
+	settings := settingsOverride
+	if settings == nil {
+		var err error
+		settings, err = agentObj.Settings.Get()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if settings == nil {
+		settings = &agent.Settings{}
+	}
+
+	if providerName == "" {
+		providerName = eval_run.PROVIDER_OPENAI
+	}
+
+	runObj := eval_run.New()
+	runObj.SuiteID.Set(suiteObj.ID())
+	runObj.AgentID.Set(agentObj.ID())
+	runObj.Provider.Set(providerName)
+	runObj.Label.Set(label)
+	runObj.AgentSettings.Set(settings)
+	runObj.Status.Set(eval_run.STATUS_PENDING)
+
+	err := runObj.Save(savingUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return runObj, nil
+}
+
+// ExecuteRun runs a pending run and stores the per case results
+func ExecuteRun(ctx context.Context, runID types.UUID) (*eval_run.EvalRun, error) {
+	runObj, err := eval_run.Get(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if tools.Empty(runObj) {
+		return nil, errors.Errorf("eval run not found %s", runID)
+	}
+	if runObj.Status.Get() != eval_run.STATUS_PENDING {
+		return runObj, nil
+	}
+
+	runObj.Status.Set(eval_run.STATUS_RUNNING)
+	err = runObj.Save(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = executeRun(ctx, runObj)
+	if err != nil {
+		runObj.Status.Set(eval_run.STATUS_FAILED)
+		runObj.Error.Set(err.Error())
+	}
+
+	saveErr := runObj.Save(nil)
+	if saveErr != nil {
+		return nil, saveErr
+	}
+
+	return runObj, err
+}
+
+func executeRun(ctx context.Context, runObj *eval_run.EvalRun) error {
+	suiteObj, err := eval_suite.Get(ctx, runObj.SuiteID.Get())
+	if err != nil {
+		return err
+	}
+	if tools.Empty(suiteObj) {
+		return errors.Errorf("eval suite not found %s", runObj.SuiteID.Get())
+	}
+
+	cases, err := suiteObj.Cases.Get()
+	if err != nil {
+		return err
+	}
+
+	settings, err := runObj.AgentSettings.Get()
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		settings = &agent.Settings{}
+	}
+
+	provider, err := NewProvider(runObj.Provider.Get(), cases)
+	if err != nil {
+		return err
+	}
+
+	results := RunCases(ctx, provider, settings, cases)
+	summary := Summarize(results)
+
+	runObj.Results.Set(results)
+	runObj.PassedCount.Set(summary.Passed)
+	runObj.FailedCount.Set(summary.Failed)
+	runObj.TotalLatencyMS.Set(int(summary.LatencyMS))
+	runObj.TotalCost.Set(decimal.NewFromFloat(summary.Cost))
+	runObj.Status.Set(eval_run.STATUS_COMPLETED)
+
+	return nil
+}
+
+// RunSummary totals a set of case results
+type RunSummary struct {
+	Passed    int
+	Failed    int
+	LatencyMS int64
+	Cost      float64
+}
+
+func Summarize(results []*eval_run.CaseResult) *RunSummary {
+	summary := &RunSummary{}
+	for _, result := range results {
+		if result.Passed {
+			summary.Passed++
+		} else {
+			summary.Failed++
+		}
+		summary.LatencyMS += result.LatencyMS
+		summary.Cost += result.Cost
+	}
+	return summary
+}
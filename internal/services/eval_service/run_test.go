@@ -0,0 +1,70 @@
+package eval_service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+)
+
+func TestExampleSuiteWithStub(t *testing.T) {
+	suiteFile, err := LoadSuiteFile("testdata/example_suite.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := RunCases(context.Background(), NewStubProvider(suiteFile.Cases), suiteFile.AgentSettings, suiteFile.Cases)
+	for _, result := range results {
+		if !result.Passed {
+			t.Errorf("case %q failed: %+v", result.CaseName, result.Assertions)
+		}
+	}
+
+	summary := Summarize(results)
+	if summary.Passed != len(suiteFile.Cases) || summary.Failed != 0 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if summary.Cost != 0 {
+		t.Errorf("expected stub runs to be free, got %f", summary.Cost)
+	}
+}
+
+func TestRunCasesFailures(t *testing.T) {
+	cases := []*eval_suite.EvalCase{
+		{
+			Name:     "echo",
+			Messages: []*eval_suite.EvalMessage{{Role: "user", Content: "what is the refund policy"}},
+			Assertions: []*eval_suite.Assertion{
+				{Type: eval_suite.ASSERTION_CONTAINS, Value: "30 days"},
+			},
+		},
+		{
+			Name:         "judge",
+			Messages:     []*eval_suite.EvalMessage{{Role: "user", Content: "hi"}},
+			Assertions:   []*eval_suite.Assertion{{Type: eval_suite.ASSERTION_LLM_JUDGE, Rubric: "polite", MinScore: 0.8}},
+			StubResponse: "hello",
+		},
+	}
+
+	provider := NewStubProvider(cases)
+	provider.JudgeScore = 0.5
+
+	results := RunCases(context.Background(), provider, &agent.Settings{}, cases)
+
+	if results[0].Passed || results[0].Output != "what is the refund policy" {
+		t.Errorf("expected echoed reply to fail contains assertion, got %+v", results[0])
+	}
+	if results[1].Passed || results[1].Assertions[0].Score != 0.5 {
+		t.Errorf("expected judge score below minimum to fail, got %+v", results[1].Assertions[0])
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	if cost := EstimateCost("gpt-4.1-mini-2025-04-14", 1_000_000, 1_000_000); cost != 2.0 {
+		t.Errorf("expected dated snapshot to use base pricing, got %f", cost)
+	}
+	if cost := EstimateCost(STUB_MODEL, 100, 100); cost != 0 {
+		t.Errorf("expected unknown model to be free, got %f", cost)
+	}
+}
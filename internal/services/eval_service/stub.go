@@ -0,0 +1,65 @@
+package eval_service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+)
+
+// STUB_MODEL is reported as the model for stub completions, it has no cost
+const STUB_MODEL = "stub"
+
+// StubProvider is a deterministic offline provider for CI.
+// Each case replies with its stub_response, or echoes the last user message when none is set,
+// and every judgement returns JudgeScore.
+type StubProvider struct {
+	Responses  map[string]string
+	JudgeScore float64
+}
+
+// NewStubProvider builds a stub from the stub responses recorded on the suite's cases
+func NewStubProvider(cases []*eval_suite.EvalCase) *StubProvider {
+	responses := map[string]string{}
+	for _, evalCase := range cases {
+		if evalCase.StubResponse != "" {
+			responses[evalCase.Name] = evalCase.StubResponse
+		}
+	}
+
+	return &StubProvider{
+		Responses:  responses,
+		JudgeScore: 1,
+	}
+}
+
+func (this *StubProvider) Complete(_ context.Context, completionRequest *CompletionRequest) (*Completion, error) {
+	text, ok := this.Responses[completionRequest.CaseName]
+	if !ok {
+		for i := len(completionRequest.Messages) - 1; i >= 0; i-- {
+			if completionRequest.Messages[i].Role == "user" {
+				text = completionRequest.Messages[i].Content
+				break
+			}
+		}
+	}
+
+	return &Completion{
+		Model:        STUB_MODEL,
+		Text:         text,
+		InputTokens:  countWords(completionRequest.Instructions, completionRequest.Messages),
+		OutputTokens: len(strings.Fields(text)),
+	}, nil
+}
+
+func (this *StubProvider) Judge(_ context.Context, _ string, _ string) (*Judgement, error) {
+	return &Judgement{Score: this.JudgeScore, Reason: "stub judgement"}, nil
+}
+
+func countWords(instructions string, messages []*eval_suite.EvalMessage) int {
+	count := len(strings.Fields(instructions))
+	for _, message := range messages {
+		count += len(strings.Fields(message.Content))
+	}
+	return count
+}
@@ -0,0 +1,256 @@
+package mailbox_service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MAX_BODY_PARTS and MAX_BODY_DEPTH stop runaway nesting in hostile multipart mail
+	MAX_BODY_PARTS = 50
+	MAX_BODY_DEPTH = 5
+)
+
+var (
+	htmlTagPattern    = regexp.MustCompile(`(?s)<[^>]*>`)
+	htmlBreakPattern  = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>`)
+	replyHeaderLine   = regexp.MustCompile(`^On .+ wrote:$`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// InboundEmail is the part of a received message the inbox needs to route and answer it
+type InboundEmail struct {
+	MessageID     string
+	InReplyTo     string
+	References    []string
+	From          string
+	ReplyTo       string
+	To            []string
+	Subject       string
+	Text          string
+	AutoSubmitted bool
+}
+
+// ParseMIME reads a raw RFC 5322 message. Text is the plain body with quoted history removed,
+// falling back to the HTML body with the markup stripped.
+func ParseMIME(raw []byte) (*InboundEmail, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	decoder := &mime.WordDecoder{}
+	subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	email := &InboundEmail{
+		MessageID:     trimMessageID(msg.Header.Get("Message-ID")),
+		InReplyTo:     trimMessageID(msg.Header.Get("In-Reply-To")),
+		References:    splitMessageIDs(msg.Header.Get("References")),
+		Subject:       strings.TrimSpace(subject),
+		AutoSubmitted: isAutoSubmitted(msg.Header),
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) == 0 {
+		return nil, errors.Errorf("message has no valid From address")
+	}
+	email.From = strings.ToLower(from[0].Address)
+
+	replyTo, err := msg.Header.AddressList("Reply-To")
+	if err == nil && len(replyTo) > 0 {
+		email.ReplyTo = strings.ToLower(replyTo[0].Address)
+	}
+
+	for _, header := range []string{"To", "Cc"} {
+		addresses, err := msg.Header.AddressList(header)
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			email.To = append(email.To, strings.ToLower(address.Address))
+		}
+	}
+
+	body := &bodyText{}
+	err = body.read(partHeader(msg.Header), msg.Body, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	text := body.plain
+	if strings.TrimSpace(text) == "" {
+		text = htmlToText(body.html)
+	}
+	email.Text = StripQuotedReply(text)
+
+	return email, nil
+}
+
+// ReplyAddress is where an answer to the message should go
+func (this *InboundEmail) ReplyAddress() string {
+	if this.ReplyTo != "" {
+		return this.ReplyTo
+	}
+	return this.From
+}
+
+// ThreadMessageIDs returns the ids of earlier messages in the thread, closest first
+func (this *InboundEmail) ThreadMessageIDs() []string {
+	ids := []string{}
+	seen := map[string]bool{}
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	add(this.InReplyTo)
+	for i := len(this.References) - 1; i >= 0; i-- {
+		add(this.References[i])
+	}
+	return ids
+}
+
+// StripQuotedReply drops the quoted history mail clients append below a reply
+func StripQuotedReply(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	kept := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if replyHeaderLine.MatchString(trimmed) || strings.HasPrefix(trimmed, "-----Original Message-----") {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(strings.Join(kept, "\n"), "\n\n"))
+}
+
+// bodyText collects the first plain and HTML parts of a message
+type bodyText struct {
+	plain string
+	html  string
+	parts int
+}
+
+// partHeader reads both message and multipart part headers
+type partHeader map[string][]string
+
+func (this partHeader) Get(key string) string {
+	return mail.Header(this).Get(key)
+}
+
+func (this *bodyText) read(header partHeader, body io.Reader, depth int) error {
+	this.parts++
+	if this.parts > MAX_BODY_PARTS || depth > MAX_BODY_DEPTH {
+		return nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			err = this.read(partHeader(part.Header), part, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	disposition, _, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	if disposition == "attachment" {
+		return nil
+	}
+
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return nil
+	}
+	if (mediaType == "text/plain" && this.plain != "") || (mediaType == "text/html" && this.html != "") {
+		return nil
+	}
+
+	content, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if mediaType == "text/plain" {
+		this.plain = string(content)
+	} else {
+		this.html = string(content)
+	}
+	return nil
+}
+
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	}
+	return body
+}
+
+func htmlToText(body string) string {
+	body = htmlBreakPattern.ReplaceAllString(body, "\n")
+	body = htmlTagPattern.ReplaceAllString(body, "")
+	return html.UnescapeString(body)
+}
+
+func isAutoSubmitted(header mail.Header) bool {
+	autoSubmitted := strings.ToLower(strings.TrimSpace(header.Get("Auto-Submitted")))
+	if autoSubmitted != "" && autoSubmitted != "no" {
+		return true
+	}
+
+	switch strings.ToLower(strings.TrimSpace(header.Get("Precedence"))) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	return false
+}
+
+func trimMessageID(id string) string {
+	return strings.Trim(strings.TrimSpace(id), "<>")
+}
+
+func splitMessageIDs(header string) []string {
+	ids := []string{}
+	for _, field := range strings.Fields(header) {
+		id := trimMessageID(field)
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
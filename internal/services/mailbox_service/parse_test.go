@@ -0,0 +1,95 @@
+package mailbox_service
+
+import (
+	"strings"
+	"testing"
+)
+
+const multipartMail = "From: \"Jane Doe\" <Jane@Example.com>\r\n" +
+	"To: support@acme.test\r\n" +
+	"Cc: other@acme.test\r\n" +
+	"Subject: =?UTF-8?Q?Order_=C3=A9tat?=\r\n" +
+	"Message-ID: <reply-2@example.com>\r\n" +
+	"In-Reply-To: <reply-1@example.com>\r\n" +
+	"References: <root@example.com> <reply-1@example.com>\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"b1\"\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Where is my order?\r\n" +
+	"\r\n" +
+	"On Mon, Jan 5, 2026 at 10:00 AM Support <support@acme.test> wrote:\r\n" +
+	"> Thanks for reaching out\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>Where is my order?</p>\r\n" +
+	"--b1--\r\n"
+
+func TestParseMIME(t *testing.T) {
+	email, err := ParseMIME([]byte(multipartMail))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if email.From != "jane@example.com" {
+		t.Errorf("Expected lowercased from, got %s", email.From)
+	}
+	if email.Subject != "Order état" {
+		t.Errorf("Expected decoded subject, got %s", email.Subject)
+	}
+	if email.MessageID != "reply-2@example.com" || email.InReplyTo != "reply-1@example.com" {
+		t.Errorf("Expected message ids without brackets, got %s %s", email.MessageID, email.InReplyTo)
+	}
+	if strings.Join(email.To, ",") != "support@acme.test,other@acme.test" {
+		t.Errorf("Expected To and Cc recipients, got %v", email.To)
+	}
+	if email.Text != "Where is my order?" {
+		t.Errorf("Expected quoted history stripped, got %q", email.Text)
+	}
+	if strings.Join(email.ThreadMessageIDs(), ",") != "reply-1@example.com,root@example.com" {
+		t.Errorf("Expected closest thread ids first, got %v", email.ThreadMessageIDs())
+	}
+}
+
+func TestParseMIMEHTMLFallback(t *testing.T) {
+	raw := "From: a@example.com\r\n" +
+		"Reply-To: b@example.com\r\n" +
+		"Auto-Submitted: auto-replied\r\n" +
+		"Content-Type: text/html\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"PHA+SGVsbG8gJmFtcDsgd2VsY29tZTwvcD48cD5CeWU8L3A+\r\n"
+
+	email, err := ParseMIME([]byte(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if email.Text != "Hello & welcome\nBye" {
+		t.Errorf("Expected text from html, got %q", email.Text)
+	}
+	if email.ReplyAddress() != "b@example.com" {
+		t.Errorf("Expected reply-to address, got %s", email.ReplyAddress())
+	}
+	if !email.AutoSubmitted {
+		t.Errorf("Expected auto submitted")
+	}
+}
+
+func TestParseMIMERequiresFrom(t *testing.T) {
+	_, err := ParseMIME([]byte("Subject: hi\r\n\r\nbody"))
+	if err == nil {
+		t.Errorf("Expected error for missing From")
+	}
+}
+
+func TestStripQuotedReply(t *testing.T) {
+	text := "Thanks!\n\n\n\nSee you\n-----Original Message-----\nFrom: x"
+	if StripQuotedReply(text) != "Thanks!\n\nSee you" {
+		t.Errorf("Got %q", StripQuotedReply(text))
+	}
+}
@@ -0,0 +1,191 @@
+package mailbox_service
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_inbox"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_message"
+	"github.com/griffnb/techboss-ai-go/internal/services/agent_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
+)
+
+// VerifyKey checks the shared key inbound mail posts are made with
+func VerifyKey(key string) bool {
+	config := environment.GetConfig().InboundEmail
+	if tools.Empty(config) || config.WebhookKey == "" || key == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(key), []byte(config.WebhookKey)) == 1
+}
+
+// Receive delivers a message to the inbox of every recipient that has one. Recipients default to the
+// message's To and Cc addresses when the provider does not pass the envelope.
+func Receive(ctx context.Context, responder agent_service.Responder, email *InboundEmail, recipients []string) error {
+	if len(recipients) == 0 {
+		recipients = email.To
+	}
+
+	seen := map[string]bool{}
+	for _, recipient := range recipients {
+		address := email_inbox.NormalizeAddress(recipient)
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+
+		inboxObj, err := email_inbox.GetByAddress(ctx, address)
+		if err != nil {
+			return err
+		}
+		if tools.Empty(inboxObj) || !inboxObj.IsActive() {
+			continue
+		}
+
+		err = receiveForInbox(ctx, responder, inboxObj, email)
+		if err != nil {
+			log.ErrorContext(err, ctx)
+		}
+	}
+
+	return nil
+}
+
+// receiveForInbox threads the message into its conversation, answers it and sends or holds the answer
+func receiveForInbox(
+	ctx context.Context,
+	responder agent_service.Responder,
+	inboxObj *email_inbox.EmailInboxJoined,
+	email *InboundEmail,
+) error {
+	// Mail from the inbox itself or from autoresponders would loop
+	if email.AutoSubmitted || email.From == inboxObj.Address.Get() || strings.TrimSpace(email.Text) == "" {
+		return nil
+	}
+
+	if email.MessageID != "" {
+		existing, err := email_message.GetInbound(ctx, inboxObj.ID(), email.MessageID)
+		if err != nil {
+			return err
+		}
+		if !tools.Empty(existing) {
+			return nil
+		}
+	}
+
+	agentObj, err := agent.Get(ctx, inboxObj.AgentID.Get())
+	if err != nil {
+		return err
+	}
+	if tools.Empty(agentObj) || !agentObj.CanBeUsedBy(inboxObj.OrganizationID.Get(), int64(inboxObj.BillingPlanLevel.Get())) {
+		return nil
+	}
+
+	conversationObj, err := getOrStartConversation(ctx, inboxObj, agentObj, email)
+	if err != nil {
+		return err
+	}
+
+	inbound := email_message.New()
+	inbound.OrganizationID.Set(inboxObj.OrganizationID.Get())
+	inbound.InboxID.Set(inboxObj.ID())
+	inbound.ConversationID.Set(conversationObj.ID())
+	inbound.Direction.Set(email_message.DIRECTION_INBOUND)
+	inbound.MessageID.Set(email.MessageID)
+	inbound.InReplyTo.Set(email.InReplyTo)
+	inbound.FromAddress.Set(email.ReplyAddress())
+	inbound.ToAddress.Set(inboxObj.Address.Get())
+	inbound.Subject.Set(email.Subject)
+	inbound.Body.Set(email.Text)
+	inbound.Status.Set(email_message.STATUS_RECEIVED)
+	err = inbound.Save(nil)
+	if err != nil {
+		return err
+	}
+
+	reply, err := agent_service.Reply(ctx, responder, agentObj, conversationObj, email.Text)
+	if err != nil {
+		return err
+	}
+
+	outbound := email_message.New()
+	outbound.OrganizationID.Set(inboxObj.OrganizationID.Get())
+	outbound.InboxID.Set(inboxObj.ID())
+	outbound.ConversationID.Set(conversationObj.ID())
+	outbound.Direction.Set(email_message.DIRECTION_OUTBOUND)
+	outbound.InReplyTo.Set(email.MessageID)
+	outbound.FromAddress.Set(inboxObj.Address.Get())
+	outbound.ToAddress.Set(email.ReplyAddress())
+	outbound.Subject.Set(ReplySubject(email.Subject, conversationObj.ExternalID.Get()))
+	outbound.Body.Set(reply.Body)
+	outbound.Status.Set(email_message.STATUS_DRAFT)
+	err = outbound.Save(nil)
+	if err != nil {
+		return err
+	}
+
+	if inboxObj.NeedsApproval() {
+		return nil
+	}
+
+	return Send(ctx, &inboxObj.EmailInbox, outbound, "")
+}
+
+// getOrStartConversation finds the conversation the message answers, first by the Message-IDs it references
+// and then by the reference in its subject, starting a new one when neither matches
+func getOrStartConversation(
+	ctx context.Context,
+	inboxObj *email_inbox.EmailInboxJoined,
+	agentObj *agent.Agent,
+	email *InboundEmail,
+) (*conversation.Conversation, error) {
+	var conversationObj *conversation.Conversation
+
+	previous, err := email_message.FindFirstByMessageIDs(ctx, inboxObj.ID(), email.ThreadMessageIDs())
+	if err != nil {
+		return nil, err
+	}
+	if !tools.Empty(previous) {
+		conversationObj, err = conversation.Get(ctx, previous.ConversationID.Get())
+		if err != nil {
+			return nil, err
+		}
+	} else if threadRef := ParseThreadRef(email.Subject); threadRef != "" {
+		conversationObj, err = conversation.GetBySourceExternalID(ctx, conversation.SOURCE_EMAIL, threadRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !tools.Empty(conversationObj) &&
+		conversationObj.AgentID.Get() == agentObj.ID() &&
+		conversationObj.OrganizationID.Get() == inboxObj.OrganizationID.Get() {
+		return conversationObj, nil
+	}
+
+	threadRef, err := newThreadRef()
+	if err != nil {
+		return nil, err
+	}
+
+	conversationObj = conversation.New()
+	conversationObj.OrganizationID.Set(inboxObj.OrganizationID.Get())
+	conversationObj.AgentID.Set(agentObj.ID())
+	conversationObj.Source.Set(conversation.SOURCE_EMAIL)
+	conversationObj.ExternalID.Set(threadRef)
+	err = conversationObj.Save(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook_service.PublishAndLog(ctx, conversationObj.OrganizationID.Get(), webhook_service.EVENT_CONVERSATION_STARTED,
+		webhook_service.NewConversationData(conversationObj))
+
+	return conversationObj, nil
+}
@@ -0,0 +1,61 @@
+package mailbox_service
+
+import (
+	"context"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_inbox"
+	"github.com/griffnb/techboss-ai-go/internal/models/email_message"
+	"github.com/griffnb/techboss-ai-go/internal/services/email_sender"
+	"github.com/pkg/errors"
+)
+
+// REPLY_MESSAGE_ID identifies agent replies to the email provider
+const REPLY_MESSAGE_ID email_sender.MessageID = "agent_reply"
+
+// ErrNotSendable is returned when a message is not an unsent agent reply
+var ErrNotSendable = errors.New("email message cannot be sent")
+
+// Send delivers an agent reply from its inbox and records the outcome on the message. approverID is
+// set when a person approved a held draft.
+func Send(ctx context.Context, inboxObj *email_inbox.EmailInbox, emailMessageObj *email_message.EmailMessage, approverID types.UUID) error {
+	if !emailMessageObj.CanSend() {
+		return ErrNotSendable
+	}
+	if !tools.Empty(approverID) {
+		emailMessageObj.ApprovedByID.Set(approverID)
+	}
+
+	err := email_sender.SendWithReplyTo(
+		ctx,
+		REPLY_MESSAGE_ID,
+		inboxObj.Address.Get(),
+		[]string{emailMessageObj.ToAddress.Get()},
+		emailMessageObj.Subject.Get(),
+		TextToHTML(emailMessageObj.Body.Get()),
+	)
+	if err != nil {
+		emailMessageObj.Status.Set(email_message.STATUS_FAILED)
+		emailMessageObj.Error.Set(err.Error())
+		saveErr := emailMessageObj.Save(nil)
+		if saveErr != nil {
+			log.ErrorContext(saveErr, ctx)
+		}
+		return err
+	}
+
+	emailMessageObj.Status.Set(email_message.STATUS_SENT)
+	emailMessageObj.SentAtTS.Set(time.Now().Unix())
+	emailMessageObj.Error.Set("")
+	return emailMessageObj.Save(nil)
+}
+
+// TextToHTML escapes a plain text reply and keeps its line breaks
+func TextToHTML(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>\n")
+}
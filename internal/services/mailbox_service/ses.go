@@ -0,0 +1,147 @@
+package mailbox_service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/pkg/errors"
+)
+
+const (
+	SNS_TYPE_SUBSCRIPTION_CONFIRMATION = "SubscriptionConfirmation"
+	SNS_TYPE_NOTIFICATION              = "Notification"
+
+	SES_ACTION_S3  = "S3"
+	SES_ACTION_SNS = "SNS"
+)
+
+// SNSMessage is the envelope SNS posts to an HTTPS subscription
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	Token            string `json:"Token"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// SESNotification is the SES receipt notification carried in an SNS message
+type SESNotification struct {
+	NotificationType string `json:"notificationType"`
+	Mail             struct {
+		MessageID   string   `json:"messageId"`
+		Destination []string `json:"destination"`
+	} `json:"mail"`
+	Receipt struct {
+		Recipients []string `json:"recipients"`
+		Action     struct {
+			Type       string `json:"type"`
+			BucketName string `json:"bucketName"`
+			ObjectKey  string `json:"objectKey"`
+			Encoding   string `json:"encoding"`
+		} `json:"action"`
+	} `json:"receipt"`
+	Content string `json:"content"`
+}
+
+// ParseSNS reads an SNS envelope and, for notifications, the SES receipt inside it
+func ParseSNS(body []byte) (*SNSMessage, *SESNotification, error) {
+	message := &SNSMessage{}
+	err := json.Unmarshal(body, message)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	if message.Type != SNS_TYPE_NOTIFICATION {
+		return message, nil, nil
+	}
+
+	notification := &SESNotification{}
+	err = json.Unmarshal([]byte(message.Message), notification)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	return message, notification, nil
+}
+
+// ConfirmSubscription visits the SubscribeURL of a confirmation, only AWS hosts are followed
+func ConfirmSubscription(ctx context.Context, message *SNSMessage) error {
+	subscribeURL, err := url.Parse(message.SubscribeURL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if subscribeURL.Scheme != "https" || !strings.HasSuffix(subscribeURL.Hostname(), ".amazonaws.com") {
+		return errors.Errorf("refusing subscribe url %s", message.SubscribeURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, subscribeURL.String(), nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("subscription confirmation returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// StoredKey returns the key of the received message in the inbound bucket, staging it there first when SES
+// delivered it inline
+func (this *SESNotification) StoredKey(ctx context.Context) (string, error) {
+	if this.Receipt.Action.Type == SES_ACTION_S3 {
+		config := environment.GetConfig().InboundEmail
+		if tools.Empty(config) || config.Bucket == "" || this.Receipt.Action.BucketName != config.Bucket {
+			return "", errors.Errorf("unexpected inbound bucket %s", this.Receipt.Action.BucketName)
+		}
+		return this.Receipt.Action.ObjectKey, nil
+	}
+
+	raw, err := this.RawEmail(ctx)
+	if err != nil {
+		return "", err
+	}
+	return StageRaw(ctx, raw)
+}
+
+// RawEmail returns the received MIME message, read from the configured bucket for S3 actions or
+// from the notification itself for SNS actions
+func (this *SESNotification) RawEmail(ctx context.Context) ([]byte, error) {
+	switch this.Receipt.Action.Type {
+	case SES_ACTION_S3:
+		config := environment.GetConfig().InboundEmail
+		if tools.Empty(config) || config.Bucket == "" || this.Receipt.Action.BucketName != config.Bucket {
+			return nil, errors.Errorf("unexpected inbound bucket %s", this.Receipt.Action.BucketName)
+		}
+		return environment.GetS3().DownloadToBuffer(ctx, this.Receipt.Action.BucketName, this.Receipt.Action.ObjectKey)
+	case SES_ACTION_SNS:
+		if strings.EqualFold(this.Receipt.Action.Encoding, "BASE64") {
+			raw, err := base64.StdEncoding.DecodeString(this.Content)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			return raw, nil
+		}
+		return []byte(this.Content), nil
+	}
+
+	return nil, errors.Errorf("unsupported SES action %s", this.Receipt.Action.Type)
+}
@@ -0,0 +1,165 @@
+package mailbox_service
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	// nolint:gosec // SNS signature version 1 is SHA1 with RSA
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SNS_CERT_TIMEOUT bounds the download of a signing certificate
+	SNS_CERT_TIMEOUT = 10 * time.Second
+	// snsCertLimit caps the size of a signing certificate
+	snsCertLimit = 64 << 10
+)
+
+var (
+	// ErrSNSSignature is returned for messages that were not signed by SNS, or not for the configured topic
+	ErrSNSSignature = errors.New("invalid SNS signature")
+
+	snsCertHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+	snsCertClient      = &http.Client{Timeout: SNS_CERT_TIMEOUT}
+	// snsCerts caches signing keys by certificate URL, SNS signs with few certificates and rotates them rarely
+	snsCerts sync.Map
+)
+
+// VerifySNS checks that a message was signed by SNS with the certificate it names, and that it comes from the
+// configured topic when there is one. The certificate is only downloaded from SNS hosts over HTTPS.
+func VerifySNS(ctx context.Context, message *SNSMessage) error {
+	config := environment.GetConfig().InboundEmail
+	if !tools.Empty(config) && config.TopicArn != "" && message.TopicArn != config.TopicArn {
+		return errors.Wrapf(ErrSNSSignature, "unexpected topic %s", message.TopicArn)
+	}
+	return verifySNSSignature(ctx, message)
+}
+
+// verifySNSSignature checks a message's signature against the public key of its signing certificate
+func verifySNSSignature(ctx context.Context, message *SNSMessage) error {
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return errors.Wrap(ErrSNSSignature, "signature is not base64")
+	}
+
+	key, err := snsSigningKey(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	canonical := []byte(message.canonical())
+	switch message.SignatureVersion {
+	case "1":
+		// nolint:gosec
+		digest := sha1.Sum(canonical)
+		err = rsa.VerifyPKCS1v15(key, crypto.SHA1, digest[:], signature)
+	case "2":
+		digest := sha256.Sum256(canonical)
+		err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	default:
+		return errors.Wrapf(ErrSNSSignature, "unsupported signature version %q", message.SignatureVersion)
+	}
+	if err != nil {
+		return errors.Wrap(ErrSNSSignature, err.Error())
+	}
+	return nil
+}
+
+// canonical is the string SNS signs, the message's fields as name and value lines in a fixed order
+func (this *SNSMessage) canonical() string {
+	fields := [][2]string{{"Message", this.Message}, {"MessageId", this.MessageID}}
+	if this.Type == SNS_TYPE_NOTIFICATION {
+		if this.Subject != "" {
+			fields = append(fields, [2]string{"Subject", this.Subject})
+		}
+		fields = append(fields, [2]string{"Timestamp", this.Timestamp})
+	} else {
+		fields = append(fields,
+			[2]string{"SubscribeURL", this.SubscribeURL},
+			[2]string{"Timestamp", this.Timestamp},
+			[2]string{"Token", this.Token},
+		)
+	}
+	fields = append(fields, [2]string{"TopicArn", this.TopicArn}, [2]string{"Type", this.Type})
+
+	out := &strings.Builder{}
+	for _, field := range fields {
+		out.WriteString(field[0])
+		out.WriteByte('\n')
+		out.WriteString(field[1])
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
+
+// snsSigningKey returns the public key of a signing certificate, downloading it the first time it is seen
+func snsSigningKey(ctx context.Context, certURL string) (*rsa.PublicKey, error) {
+	parsed, err := url.Parse(certURL)
+	if err != nil || parsed.Scheme != "https" || !snsCertHostPattern.MatchString(parsed.Hostname()) ||
+		parsed.Port() != "" || !strings.HasSuffix(parsed.Path, ".pem") {
+		return nil, errors.Wrapf(ErrSNSSignature, "refusing signing certificate url %s", certURL)
+	}
+	if key, ok := snsCerts.Load(certURL); ok {
+		return key.(*rsa.PublicKey), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	resp, err := snsCertClient.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("signing certificate %s returned %d", certURL, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, snsCertLimit))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	key, err := parseSigningCert(body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signing certificate %s", certURL)
+	}
+	snsCerts.Store(certURL, key)
+	return key, nil
+}
+
+// parseSigningCert reads the RSA key of a PEM certificate that is currently valid
+func parseSigningCert(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("not a PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, errors.New("certificate is expired or not yet valid")
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("certificate key is not RSA")
+	}
+	return key, nil
+}
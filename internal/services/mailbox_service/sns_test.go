@@ -0,0 +1,135 @@
+package mailbox_service
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const testCertURL = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+
+func signTestMessage(t *testing.T, key *rsa.PrivateKey, message *SNSMessage) {
+	digest := sha256.Sum256([]byte(message.canonical()))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	message.SignatureVersion = "2"
+	message.Signature = base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestVerifySNSSignature(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snsCerts.Store(testCertURL, &key.PublicKey)
+	defer snsCerts.Delete(testCertURL)
+
+	message := &SNSMessage{
+		Type:           SNS_TYPE_NOTIFICATION,
+		MessageID:      "b3c1",
+		TopicArn:       "arn:aws:sns:us-east-1:123456789012:inbound",
+		Message:        `{"notificationType":"Received"}`,
+		Timestamp:      "2026-01-05T10:00:00.000Z",
+		SigningCertURL: testCertURL,
+	}
+	signTestMessage(t, key, message)
+	if err := verifySNSSignature(context.Background(), message); err != nil {
+		t.Fatalf("expected a signed message to verify, got %v", err)
+	}
+
+	tampered := *message
+	tampered.Message = `{"notificationType":"Bounce"}`
+	if err := verifySNSSignature(context.Background(), &tampered); !errors.Is(err, ErrSNSSignature) {
+		t.Errorf("expected a changed message to fail, got %v", err)
+	}
+
+	downgraded := *message
+	downgraded.SignatureVersion = "3"
+	if err := verifySNSSignature(context.Background(), &downgraded); !errors.Is(err, ErrSNSSignature) {
+		t.Errorf("expected an unknown signature version to fail, got %v", err)
+	}
+
+	for _, certURL := range []string{
+		"http://sns.us-east-1.amazonaws.com/cert.pem",
+		"https://sns.us-east-1.amazonaws.com.attacker.test/cert.pem",
+		"https://attacker.test/sns.us-east-1.amazonaws.com/cert.pem",
+		"https://s3.amazonaws.com/bucket/cert.pem",
+	} {
+		foreign := *message
+		foreign.SigningCertURL = certURL
+		if err := verifySNSSignature(context.Background(), &foreign); !errors.Is(err, ErrSNSSignature) {
+			t.Errorf("expected certificate url %s to be refused, got %v", certURL, err)
+		}
+	}
+}
+
+func TestSNSCanonical(t *testing.T) {
+	message := &SNSMessage{
+		Type:         SNS_TYPE_SUBSCRIPTION_CONFIRMATION,
+		MessageID:    "id",
+		TopicArn:     "arn",
+		Message:      "confirm",
+		Timestamp:    "now",
+		Token:        "token",
+		SubscribeURL: "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription",
+	}
+	want := "Message\nconfirm\nMessageId\nid\nSubscribeURL\nhttps://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription\n" +
+		"Timestamp\nnow\nToken\ntoken\nTopicArn\narn\nType\nSubscriptionConfirmation\n"
+	if got := message.canonical(); got != want {
+		t.Errorf("unexpected canonical string %q", got)
+	}
+
+	message = &SNSMessage{Type: SNS_TYPE_NOTIFICATION, MessageID: "id", TopicArn: "arn", Message: "body", Timestamp: "now"}
+	want = "Message\nbody\nMessageId\nid\nTimestamp\nnow\nTopicArn\narn\nType\nNotification\n"
+	if got := message.canonical(); got != want {
+		t.Errorf("unexpected canonical string without a subject %q", got)
+	}
+}
+
+func TestParseSigningCert(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := func(notAfter time.Time) []byte {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	parsed, err := parseSigningCert(certPEM(time.Now().Add(time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equal(&key.PublicKey) {
+		t.Errorf("unexpected key")
+	}
+
+	if _, err := parseSigningCert(certPEM(time.Now().Add(-time.Minute))); err == nil {
+		t.Errorf("expected an expired certificate to fail")
+	}
+	if _, err := parseSigningCert([]byte("not a certificate")); err == nil {
+		t.Errorf("expected a non PEM body to fail")
+	}
+}
@@ -0,0 +1,84 @@
+package mailbox_service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
+)
+
+// Received mail is answered by the task worker. Messages are kept in the inbound bucket, where SES stores them
+// already, and the queued job only carries the key, so a message is never too large to queue.
+
+// STAGED_PREFIX is where posted messages are stored in the inbound bucket
+const STAGED_PREFIX = "inbound/"
+
+// inboundBucket returns the configured inbound bucket
+func inboundBucket() (string, error) {
+	config := environment.GetConfig().InboundEmail
+	if tools.Empty(config) || config.Bucket == "" {
+		return "", errors.New("inbound email bucket is not configured")
+	}
+	return config.Bucket, nil
+}
+
+// StageRaw stores a posted MIME message in the inbound bucket and returns its key
+func StageRaw(ctx context.Context, raw []byte) (string, error) {
+	bucket, err := inboundBucket()
+	if err != nil {
+		return "", err
+	}
+	client := environment.GetS3Client()
+	if client == nil {
+		return "", errors.New("S3 is not configured")
+	}
+
+	buffer := make([]byte, 16)
+	_, err = rand.Read(buffer)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	key := STAGED_PREFIX + time.Now().UTC().Format("2006/01/02/") + hex.EncodeToString(buffer)
+
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(raw),
+		ContentType: aws.String("message/rfc822"),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "staging of %s", key)
+	}
+	return key, nil
+}
+
+// ReceiveStored reads a message from the inbound bucket and delivers it to its recipients' inboxes
+func ReceiveStored(ctx context.Context, key string, recipients []string) error {
+	bucket, err := inboundBucket()
+	if err != nil {
+		return err
+	}
+	raw, err := environment.GetS3().DownloadToBuffer(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	email, err := ParseMIME(raw)
+	if err != nil {
+		return err
+	}
+
+	service, err := openai.NewServiceFromEnv()
+	if err != nil {
+		return err
+	}
+	return Receive(ctx, service, email, recipients)
+}
@@ -0,0 +1,56 @@
+package mailbox_service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+var (
+	threadRefPattern     = regexp.MustCompile(`\[ref:([0-9a-f]{12})\]`)
+	replySubjectPrefixes = []string{"re:", "aw:", "sv:"}
+)
+
+// newThreadRef returns the reference stored as the conversation's external id and carried in reply subjects.
+// Outgoing mail does not carry a Message-ID we control, so the subject reference is what ties a customer's
+// answer to our reply back to the conversation.
+func newThreadRef() (string, error) {
+	buffer := make([]byte, 6)
+	_, err := rand.Read(buffer)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buffer), nil
+}
+
+// ParseThreadRef returns the conversation reference in a subject, empty when there is none
+func ParseThreadRef(subject string) string {
+	match := threadRefPattern.FindStringSubmatch(subject)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// ReplySubject prefixes the subject with Re: once and tags it with the conversation reference
+func ReplySubject(subject string, threadRef string) string {
+	subject = strings.TrimSpace(threadRefPattern.ReplaceAllString(subject, ""))
+	if subject == "" {
+		subject = "Your message"
+	}
+
+	isReply := false
+	lower := strings.ToLower(subject)
+	for _, prefix := range replySubjectPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			isReply = true
+			break
+		}
+	}
+	if !isReply {
+		subject = "Re: " + subject
+	}
+
+	return subject + " [ref:" + threadRef + "]"
+}
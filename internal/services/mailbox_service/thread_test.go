@@ -0,0 +1,38 @@
+package mailbox_service
+
+import (
+	"testing"
+)
+
+func TestReplySubject(t *testing.T) {
+	tests := []struct {
+		subject  string
+		expected string
+	}{
+		{"Order status", "Re: Order status [ref:0123456789ab]"},
+		{"RE: Order status", "RE: Order status [ref:0123456789ab]"},
+		{"Re: Order status [ref:ba9876543210]", "Re: Order status [ref:0123456789ab]"},
+		{"", "Re: Your message [ref:0123456789ab]"},
+	}
+
+	for _, test := range tests {
+		result := ReplySubject(test.subject, "0123456789ab")
+		if result != test.expected {
+			t.Errorf("ReplySubject(%q) = %q, expected %q", test.subject, result, test.expected)
+		}
+	}
+}
+
+func TestParseThreadRef(t *testing.T) {
+	ref, err := newThreadRef()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ParseThreadRef(ReplySubject("Hello", ref)) != ref {
+		t.Errorf("Expected %s to round trip", ref)
+	}
+	if ParseThreadRef("Hello [ref:nothex]") != "" {
+		t.Errorf("Expected no reference")
+	}
+}
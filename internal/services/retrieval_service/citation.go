@@ -0,0 +1,44 @@
+package retrieval_service
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/message"
+)
+
+// CITATION_QUOTE_LENGTH is the most characters of a chunk quoted in a citation
+const CITATION_QUOTE_LENGTH = 280
+
+// Citation is the result as a citation on an assistant message
+func (this *Result) Citation() *message.Citation {
+	citation := &message.Citation{
+		DocumentID:   this.DocumentID,
+		DocumentName: this.DocumentName,
+		ChunkID:      this.ChunkID,
+		Quote:        Quote(this.Content, CITATION_QUOTE_LENGTH),
+	}
+	if this.Location != nil {
+		citation.StartOffset = this.Location.StartOffset
+		citation.EndOffset = this.Location.EndOffset
+	}
+	return citation
+}
+
+// Quote shortens text to at most length characters, cutting at a word boundary and marking the cut with an ellipsis
+func Quote(text string, length int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+
+	cut := length - 1
+	for i := cut; i > length/2; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace) + "…"
+}
@@ -0,0 +1,38 @@
+package retrieval_service
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestQuote(t *testing.T) {
+	if quote := Quote("  Short\n\ntext  ", 50); quote != "Short text" {
+		t.Errorf("expected whitespace to be collapsed, got %q", quote)
+	}
+
+	quote := Quote("The starter plan costs ten dollars a month", 20)
+	if quote != "The starter plan…" {
+		t.Errorf("expected a cut at a word boundary, got %q", quote)
+	}
+
+	long := Quote(strings.Repeat("x", 100), 20)
+	if utf8.RuneCountInString(long) != 20 || !strings.HasSuffix(long, "…") {
+		t.Errorf("expected a hard cut of 20 characters, got %q", long)
+	}
+}
+
+func TestResultCitation(t *testing.T) {
+	result := Fuse(matches("a"), nil)[0]
+	result.Content = "Refunds are issued within 14 days."
+	result.Location.StartOffset = 120
+	result.Location.EndOffset = 154
+
+	citation := result.Citation()
+	if citation.ChunkID != "a" || citation.DocumentID != "doc-a" || citation.DocumentName != "Document a" {
+		t.Errorf("unexpected citation %+v", citation)
+	}
+	if citation.StartOffset != 120 || citation.EndOffset != 154 || citation.Quote != result.Content {
+		t.Errorf("unexpected citation location %+v", citation)
+	}
+}
@@ -0,0 +1,67 @@
+package retrieval_service
+
+import (
+	"sort"
+
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+)
+
+// RRF_K damps the weight of the top ranks in reciprocal rank fusion, 60 is the value from the original paper
+const RRF_K = 60
+
+// Fuse merges a keyword and a vector ranking with reciprocal rank fusion, each chunk scores the sum of
+// 1 / (RRF_K + rank) over the rankings it appears in. The raw scores of the two searches are not comparable,
+// ranks are.
+func Fuse(keywordMatches []*document_chunk.ChunkMatch, vectorMatches []*document_chunk.ChunkMatch) []*Result {
+	byChunk := map[string]*Result{}
+	results := []*Result{}
+
+	add := func(match *document_chunk.ChunkMatch, rank int) *Result {
+		result, ok := byChunk[string(match.ID)]
+		if !ok {
+			result = &Result{
+				ChunkID:      match.ID,
+				DocumentID:   match.DocumentID,
+				DocumentName: match.DocumentName,
+				Content:      match.Content,
+				Location: &Location{
+					ChunkIndex:  match.ChunkIndex,
+					StartOffset: match.StartOffset,
+					EndOffset:   match.EndOffset,
+					Page:        match.Page,
+					Heading:     match.Heading,
+				},
+			}
+			byChunk[string(match.ID)] = result
+			results = append(results, result)
+		}
+		result.Score += 1 / float64(RRF_K+rank)
+		return result
+	}
+
+	for i, match := range keywordMatches {
+		add(match, i+1).KeywordRank = i + 1
+	}
+	for i, match := range vectorMatches {
+		add(match, i+1).VectorRank = i + 1
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return bestRank(results[i]) < bestRank(results[j])
+	})
+	return results
+}
+
+// bestRank breaks score ties in favour of the chunk one search placed higher
+func bestRank(result *Result) int {
+	best := 0
+	for _, rank := range []int{result.KeywordRank, result.VectorRank} {
+		if rank > 0 && (best == 0 || rank < best) {
+			best = rank
+		}
+	}
+	return best
+}
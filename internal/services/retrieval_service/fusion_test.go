@@ -0,0 +1,81 @@
+package retrieval_service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/pkg/errors"
+)
+
+func matches(ids ...string) []*document_chunk.ChunkMatch {
+	list := []*document_chunk.ChunkMatch{}
+	for i, id := range ids {
+		list = append(list, &document_chunk.ChunkMatch{
+			ID:           types.UUID(id),
+			DocumentID:   types.UUID("doc-" + id),
+			DocumentName: "Document " + id,
+			ChunkIndex:   i,
+		})
+	}
+	return list
+}
+
+func TestFuse(t *testing.T) {
+	// b is second in both rankings so beats a and c which each top only one
+	results := Fuse(matches("a", "b", "d"), matches("c", "b"))
+
+	order := []string{}
+	for _, result := range results {
+		order = append(order, string(result.ChunkID))
+	}
+	if len(order) != 4 || order[0] != "b" || order[1] != "a" || order[2] != "c" || order[3] != "d" {
+		t.Fatalf("unexpected order %v", order)
+	}
+
+	b := results[0]
+	if b.KeywordRank != 2 || b.VectorRank != 2 {
+		t.Errorf("unexpected ranks %d %d", b.KeywordRank, b.VectorRank)
+	}
+	if expected := 2.0 / (RRF_K + 2); b.Score != expected {
+		t.Errorf("expected score %f, got %f", expected, b.Score)
+	}
+	if b.DocumentName != "Document b" || b.Location == nil || b.Location.ChunkIndex != 1 {
+		t.Errorf("unexpected result %+v", b)
+	}
+	if results[2].KeywordRank != 0 || results[2].VectorRank != 1 {
+		t.Errorf("expected c to be found by vector only, got %d %d", results[2].KeywordRank, results[2].VectorRank)
+	}
+}
+
+func TestFuse_OneRanking(t *testing.T) {
+	results := Fuse(nil, matches("x", "y"))
+	if len(results) != 2 || results[0].ChunkID != "x" || results[1].ChunkID != "y" {
+		t.Errorf("expected the vector order to be kept, got %v", results)
+	}
+}
+
+func TestRerank(t *testing.T) {
+	defer SetReranker(nil)
+	fused := Fuse(matches("a", "b"), nil)
+
+	SetReranker(func(_ context.Context, query string, results []*Result) ([]*Result, error) {
+		if query != "pricing" {
+			t.Errorf("unexpected query %s", query)
+		}
+		return []*Result{results[1], results[0]}, nil
+	})
+	reranked := rerank(context.Background(), "pricing", fused)
+	if reranked[0].ChunkID != "b" {
+		t.Errorf("expected the re-ranked order, got %s first", reranked[0].ChunkID)
+	}
+
+	SetReranker(func(_ context.Context, _ string, _ []*Result) ([]*Result, error) {
+		return nil, errors.New("reranker down")
+	})
+	kept := rerank(context.Background(), "pricing", fused)
+	if len(kept) != 2 || kept[0].ChunkID != "a" {
+		t.Errorf("expected the fused order when the re-ranker fails, got %v", kept)
+	}
+}
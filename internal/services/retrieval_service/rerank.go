@@ -0,0 +1,45 @@
+package retrieval_service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/pkg/errors"
+)
+
+// Reranker reorders fused results, e.g. with a cross encoder. It may drop results but should not add any.
+type Reranker func(ctx context.Context, query string, results []*Result) ([]*Result, error)
+
+var (
+	rerankerLock sync.RWMutex
+	reranker     Reranker
+)
+
+// SetReranker sets the hook every retrieval is passed through, nil turns re-ranking off
+func SetReranker(newReranker Reranker) {
+	rerankerLock.Lock()
+	defer rerankerLock.Unlock()
+	reranker = newReranker
+}
+
+func getReranker() Reranker {
+	rerankerLock.RLock()
+	defer rerankerLock.RUnlock()
+	return reranker
+}
+
+// rerank applies the re-ranker when one is set, keeping the fused order when it fails
+func rerank(ctx context.Context, query string, results []*Result) []*Result {
+	current := getReranker()
+	if current == nil || len(results) == 0 {
+		return results
+	}
+
+	reranked, err := current(ctx, query, results)
+	if err != nil {
+		log.ErrorContext(errors.Wrap(err, "rerank"), ctx)
+		return results
+	}
+	return reranked
+}
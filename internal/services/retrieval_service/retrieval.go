@@ -0,0 +1,112 @@
+package retrieval_service
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_chunk"
+	"github.com/griffnb/techboss-ai-go/internal/services/document_service"
+	"github.com/pkg/errors"
+)
+
+const (
+	DEFAULT_LIMIT = 8
+	MAX_LIMIT     = 20
+	// CANDIDATES is how many hits each search contributes to the fusion
+	CANDIDATES = 40
+)
+
+// ErrNoQuery is returned when retrieving with an empty query
+var ErrNoQuery = errors.New("query is required")
+
+// Request is a search over an organization's documents
+type Request struct {
+	document_chunk.ChunkScope
+	Query string
+}
+
+// Location is where a chunk sits in its document, offsets are characters into the extracted text. Page and
+// Heading are set for formats that have them.
+type Location struct {
+	ChunkIndex  int    `json:"chunk_index"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	Page        int    `json:"page,omitempty"`
+	Heading     string `json:"heading,omitempty"`
+}
+
+// Result is a chunk found by either search. Ranks start at 1 and are 0 when that search did not find the chunk.
+type Result struct {
+	ChunkID      types.UUID `json:"chunk_id"`
+	DocumentID   types.UUID `json:"document_id"`
+	DocumentName string     `json:"document_name"`
+	Content      string     `json:"content"`
+	Score        float64    `json:"score"`
+	KeywordRank  int        `json:"keyword_rank,omitempty"`
+	VectorRank   int        `json:"vector_rank,omitempty"`
+	Location     *Location  `json:"location"`
+}
+
+// Retrieve runs a full text and a vector search over the organization's chunks and fuses the two rankings,
+// then lets the re-ranker reorder them when one is set. When one search fails the other's results are still
+// returned, so keyword search keeps working while the embedding provider is down.
+func Retrieve(ctx context.Context, request *Request) ([]*Result, error) {
+	if tools.Empty(request.OrganizationID) {
+		return nil, errors.New("organization is required")
+	}
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return nil, ErrNoQuery
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = DEFAULT_LIMIT
+	}
+	limit = min(limit, MAX_LIMIT)
+
+	scope := request.ChunkScope
+	scope.Limit = CANDIDATES
+
+	var keywordMatches, vectorMatches []*document_chunk.ChunkMatch
+	var keywordErr, vectorErr error
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		keywordMatches, keywordErr = document_chunk.FindKeyword(ctx, &document_chunk.KeywordQuery{
+			ChunkScope: scope,
+			Query:      query,
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		vectorMatches, vectorErr = document_service.SearchChunks(ctx, &document_service.ChunkSearch{
+			ChunkScope: scope,
+			Query:      query,
+		})
+	}()
+	wg.Wait()
+
+	if keywordErr != nil && vectorErr != nil {
+		log.ErrorContext(vectorErr, ctx)
+		return nil, keywordErr
+	}
+	if keywordErr != nil {
+		log.ErrorContext(errors.Wrap(keywordErr, "keyword search"), ctx)
+	}
+	if vectorErr != nil {
+		log.ErrorContext(errors.Wrap(vectorErr, "vector search"), ctx)
+	}
+
+	results := Fuse(keywordMatches, vectorMatches)
+	results = rerank(ctx, query, results)
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
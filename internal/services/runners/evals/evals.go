@@ -0,0 +1,134 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_run"
+	"github.com/griffnb/techboss-ai-go/internal/models/eval_suite"
+	"github.com/griffnb/techboss-ai-go/internal/services/eval_service"
+	"github.com/pkg/errors"
+)
+
+// EvalRunner runs an eval suite and fails when any case fails.
+//
+//	runner evals <suite_id|suite.json> [--stub] [--agent=<agent_id>] [--label=<label>]
+//
+// A suite id runs the stored suite and saves the run. A path to a suite file runs without
+// touching the database, which combined with --stub needs no network access for CI.
+type EvalRunner struct{}
+
+func (this *EvalRunner) Run(ctx context.Context, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("usage: evals <suite_id|suite.json> [--stub] [--agent=<agent_id>] [--label=<label>]")
+	}
+
+	target := args[0]
+	providerName := eval_run.PROVIDER_OPENAI
+	agentID := ""
+	label := ""
+	for _, arg := range args[1:] {
+		switch {
+		case arg == "--stub":
+			providerName = eval_run.PROVIDER_STUB
+		case strings.HasPrefix(arg, "--agent="):
+			agentID = strings.TrimPrefix(arg, "--agent=")
+		case strings.HasPrefix(arg, "--label="):
+			label = strings.TrimPrefix(arg, "--label=")
+		default:
+			return errors.Errorf("unknown argument %s", arg)
+		}
+	}
+
+	if strings.HasSuffix(target, ".json") {
+		return this.runFile(ctx, target, providerName)
+	}
+
+	return this.runStored(ctx, types.UUID(target), types.UUID(agentID), providerName, label)
+}
+
+func (this *EvalRunner) runFile(ctx context.Context, path string, providerName string) error {
+	suiteFile, err := eval_service.LoadSuiteFile(path)
+	if err != nil {
+		return err
+	}
+
+	provider, err := eval_service.NewProvider(providerName, suiteFile.Cases)
+	if err != nil {
+		return err
+	}
+
+	results := eval_service.RunCases(ctx, provider, suiteFile.AgentSettings, suiteFile.Cases)
+	return report(suiteFile.Name, results)
+}
+
+func (this *EvalRunner) runStored(ctx context.Context, suiteID types.UUID, agentID types.UUID, providerName string, label string) error {
+	suiteObj, err := eval_suite.Get(ctx, suiteID)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(suiteObj) {
+		return errors.Errorf("eval suite not found %s", suiteID)
+	}
+
+	if tools.Empty(agentID) {
+		agentID = suiteObj.AgentID.Get()
+	}
+
+	agentObj, err := agent.Get(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(agentObj) {
+		return errors.Errorf("agent not found %s", agentID)
+	}
+
+	runObj, err := eval_service.CreateRun(ctx, suiteObj, agentObj, providerName, label, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	runObj, err = eval_service.ExecuteRun(ctx, runObj.ID())
+	if err != nil {
+		return err
+	}
+
+	results, err := runObj.Results.Get()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved eval run %s\n", runObj.ID())
+	return report(suiteObj.Name.Get(), results)
+}
+
+func report(name string, results []*eval_run.CaseResult) error {
+	fmt.Printf("Suite: %s\n", name)
+	for _, result := range results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		fmt.Printf("  [%s] %s (%dms, $%.6f)\n", status, result.CaseName, result.LatencyMS, result.Cost)
+		if result.Error != "" {
+			fmt.Printf("      error: %s\n", result.Error)
+		}
+		for _, assertion := range result.Assertions {
+			if !assertion.Passed {
+				fmt.Printf("      %s: %s\n", assertion.Type, assertion.Detail)
+			}
+		}
+	}
+
+	summary := eval_service.Summarize(results)
+	fmt.Printf("Passed %d, failed %d, latency %dms, cost $%.6f\n", summary.Passed, summary.Failed, summary.LatencyMS, summary.Cost)
+
+	if summary.Failed > 0 {
+		return errors.Errorf("%d eval cases failed", summary.Failed)
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/griffnb/techboss-ai-go/internal/services/runners/evals"
 	"github.com/griffnb/techboss-ai-go/internal/services/runners/importing"
+	"github.com/griffnb/techboss-ai-go/internal/services/runners/vector_stores"
 )
 
 type Runner interface {
@@ -37,4 +39,6 @@ func Get(name string) Runner {
 func init() {
 	Register("categories", &importing.CategoryImportRunner{})
 	Register("tools", &importing.ToolImportRunner{})
+	Register("evals", &evals.EvalRunner{})
+	Register("vector_stores", &vector_stores.ReconcileRunner{})
 }
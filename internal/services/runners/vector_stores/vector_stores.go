@@ -0,0 +1,37 @@
+package vector_stores
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/services/vector_store_service"
+	"github.com/pkg/errors"
+)
+
+// ReconcileRunner brings the provider vector stores in line with the documents.
+//
+//	runner vector_stores [organization_id]
+//
+// Without an organization every organization with documents is reconciled, as the daily job does.
+type ReconcileRunner struct{}
+
+func (this *ReconcileRunner) Run(ctx context.Context, args ...string) error {
+	if len(args) > 1 {
+		return errors.New("usage: vector_stores [organization_id]")
+	}
+
+	if len(args) == 0 {
+		return vector_store_service.ReconcileAll(ctx)
+	}
+
+	result, err := vector_store_service.Reconcile(ctx, types.UUID(args[0]))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Dropped stores %d\n", result.DroppedStores)
+	fmt.Printf("Removed %d, resynced %d, queued %d, refreshed %d, orphans deleted %d\n",
+		result.Removed, result.Resynced, result.Queued, result.Refreshed, result.Orphans)
+	return nil
+}
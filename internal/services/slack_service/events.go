@@ -0,0 +1,119 @@
+package slack_service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/slack_installation"
+	"github.com/griffnb/techboss-ai-go/internal/services/ai_proxies/openai"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// CHANNEL_TYPE_IM is the channel type Slack reports for direct messages with the bot
+const CHANNEL_TYPE_IM = "im"
+
+// VerifyRequest checks the request signature against the app's signing secret
+func VerifyRequest(header http.Header, body []byte) error {
+	return verifyRequest(header, body, environment.GetConfig().SlackApp.SigningSecret)
+}
+
+func verifyRequest(header http.Header, body []byte, signingSecret string) error {
+	verifier, err := slack.NewSecretsVerifier(header, signingSecret)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = verifier.Write(body)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(verifier.Ensure())
+}
+
+// ParseEvent parses a verified Events API body, the signature replaces the deprecated verification token
+func ParseEvent(body []byte) (slackevents.EventsAPIEvent, error) {
+	event, err := slackevents.ParseEvent(json.RawMessage(body), slackevents.OptionNoVerifyToken())
+	if err != nil {
+		return event, errors.WithStack(err)
+	}
+	return event, nil
+}
+
+// HandleCallback answers an event callback for the workspace it came from
+func HandleCallback(ctx context.Context, event slackevents.EventsAPIEvent) error {
+	installation, err := slack_installation.GetByTeamID(ctx, event.TeamID)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(installation) || !installation.IsActive() {
+		return nil
+	}
+
+	switch event.InnerEvent.Data.(type) {
+	case *slackevents.AppUninstalledEvent, *slackevents.TokensRevokedEvent:
+		installation.Status.Set(slack_installation.STATUS_REVOKED)
+		return installation.Save(nil)
+	}
+
+	incoming := toIncomingMessage(event.InnerEvent.Data, installation.BotUserID.Get())
+	if incoming == nil {
+		return nil
+	}
+
+	token, err := installation.BotToken()
+	if err != nil {
+		return err
+	}
+
+	service, err := openai.NewServiceFromEnv()
+	if err != nil {
+		return err
+	}
+
+	return HandleMessage(ctx, slack.New(token), service, installation, incoming)
+}
+
+// toIncomingMessage picks out the messages the bot should answer, nil for everything else
+func toIncomingMessage(data any, botUserID string) *IncomingMessage {
+	switch event := data.(type) {
+	case *slackevents.AppMentionEvent:
+		if event.BotID != "" {
+			return nil
+		}
+		return &IncomingMessage{
+			Channel:            event.Channel,
+			User:               event.User,
+			Text:               event.Text,
+			TimeStamp:          event.TimeStamp,
+			ThreadTimeStamp:    event.ThreadTimeStamp,
+			StartsConversation: true,
+		}
+	case *slackevents.MessageEvent:
+		// Edits, joins and bot posts, including our own replies, carry a subtype or bot id
+		if event.BotID != "" || event.SubType != "" || event.User == "" || event.User == botUserID {
+			return nil
+		}
+		directMessage := event.ChannelType == CHANNEL_TYPE_IM
+		// Channel mentions are also delivered as app_mention, answer those once
+		if !directMessage && mentions(event.Text, botUserID) {
+			return nil
+		}
+		return &IncomingMessage{
+			Channel:         event.Channel,
+			User:            event.User,
+			Text:            event.Text,
+			TimeStamp:       event.TimeStamp,
+			ThreadTimeStamp: event.ThreadTimeStamp,
+			DirectMessage:   directMessage,
+			// Outside of DMs plain messages only continue threads the bot is already in
+			StartsConversation: directMessage,
+		}
+	}
+	return nil
+}
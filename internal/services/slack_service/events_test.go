@@ -0,0 +1,55 @@
+package slack_service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func signedHeader(secret string, timestamp int64, body []byte) http.Header {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + strconv.FormatInt(timestamp, 10) + ":"))
+	mac.Write(body)
+
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(timestamp, 10))
+	header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return header
+}
+
+func TestVerifyRequest(t *testing.T) {
+	body := []byte(`{"type":"event_callback","team_id":"T1"}`)
+	now := time.Now().Unix()
+
+	if err := verifyRequest(signedHeader("signing", now, body), body, "signing"); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+
+	if err := verifyRequest(signedHeader("other", now, body), body, "signing"); err == nil {
+		t.Errorf("Expected wrong secret to fail")
+	}
+
+	if err := verifyRequest(signedHeader("signing", now, body), []byte(`{"type":"event_callback","team_id":"T2"}`), "signing"); err == nil {
+		t.Errorf("Expected tampered body to fail")
+	}
+
+	if err := verifyRequest(signedHeader("signing", now-int64(10*time.Minute/time.Second), body), body, "signing"); err == nil {
+		t.Errorf("Expected stale timestamp to fail")
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"type":"event_callback","team_id":"T1","event":{"type":"app_mention","user":"U1","text":"<@UBOT> hi","ts":"100.1","channel":"C1"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	incoming := toIncomingMessage(event.InnerEvent.Data, "UBOT")
+	if event.TeamID != "T1" || incoming == nil || incoming.Channel != "C1" {
+		t.Errorf("Expected a mention in C1 from T1, got %+v %+v", event, incoming)
+	}
+}
@@ -0,0 +1,46 @@
+package slack_service
+
+import (
+	"strings"
+)
+
+// IncomingMessage is a Slack message addressed to the bot
+type IncomingMessage struct {
+	Channel            string
+	User               string
+	Text               string
+	TimeStamp          string
+	ThreadTimeStamp    string
+	DirectMessage      bool
+	StartsConversation bool // whether the message may start a new conversation or only continue one
+}
+
+// ReplyThread returns the thread the bot answers in. Channel messages are always answered in a thread,
+// top level direct messages are answered in the DM itself.
+func (this *IncomingMessage) ReplyThread() string {
+	if this.ThreadTimeStamp != "" {
+		return this.ThreadTimeStamp
+	}
+	if this.DirectMessage {
+		return ""
+	}
+	return this.TimeStamp
+}
+
+// ConversationKey identifies the conversation the message belongs to, one per thread or per DM outside threads
+func (this *IncomingMessage) ConversationKey(teamID string) string {
+	return strings.Join([]string{teamID, this.Channel, this.ReplyThread()}, ":")
+}
+
+// mentions reports whether the text mentions the bot user
+func mentions(text string, botUserID string) bool {
+	return botUserID != "" && strings.Contains(text, "<@"+botUserID+">")
+}
+
+// stripMention removes the bot mention from the text sent to the agent
+func stripMention(text string, botUserID string) string {
+	if botUserID != "" {
+		text = strings.ReplaceAll(text, "<@"+botUserID+">", "")
+	}
+	return strings.TrimSpace(text)
+}
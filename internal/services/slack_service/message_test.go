@@ -0,0 +1,69 @@
+package slack_service
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack/slackevents"
+)
+
+func TestToIncomingMessage(t *testing.T) {
+	t.Run("mention starts a threaded conversation", func(t *testing.T) {
+		incoming := toIncomingMessage(&slackevents.AppMentionEvent{
+			Channel: "C1", User: "U1", Text: "<@UBOT> hi", TimeStamp: "100.1",
+		}, "UBOT")
+		if incoming == nil || !incoming.StartsConversation {
+			t.Fatalf("Expected mention to start a conversation, got %+v", incoming)
+		}
+		if incoming.ReplyThread() != "100.1" || incoming.ConversationKey("T1") != "T1:C1:100.1" {
+			t.Errorf("Expected reply in a thread on the mention, got %s %s", incoming.ReplyThread(), incoming.ConversationKey("T1"))
+		}
+	})
+
+	t.Run("channel message with mention is left to app_mention", func(t *testing.T) {
+		incoming := toIncomingMessage(&slackevents.MessageEvent{
+			Channel: "C1", ChannelType: "channel", User: "U1", Text: "<@UBOT> hi", TimeStamp: "100.1",
+		}, "UBOT")
+		if incoming != nil {
+			t.Errorf("Expected nil, got %+v", incoming)
+		}
+	})
+
+	t.Run("thread reply only continues", func(t *testing.T) {
+		incoming := toIncomingMessage(&slackevents.MessageEvent{
+			Channel: "C1", ChannelType: "channel", User: "U1", Text: "and then?", TimeStamp: "101.1", ThreadTimeStamp: "100.1",
+		}, "UBOT")
+		if incoming == nil || incoming.StartsConversation || incoming.ConversationKey("T1") != "T1:C1:100.1" {
+			t.Errorf("Expected a continuation of thread 100.1, got %+v", incoming)
+		}
+	})
+
+	t.Run("direct message answers in the DM", func(t *testing.T) {
+		incoming := toIncomingMessage(&slackevents.MessageEvent{
+			Channel: "D1", ChannelType: CHANNEL_TYPE_IM, User: "U1", Text: "hi", TimeStamp: "100.1",
+		}, "UBOT")
+		if incoming == nil || !incoming.DirectMessage || !incoming.StartsConversation {
+			t.Fatalf("Expected a direct message, got %+v", incoming)
+		}
+		if incoming.ReplyThread() != "" || incoming.ConversationKey("T1") != "T1:D1:" {
+			t.Errorf("Expected an unthreaded DM reply, got %q %s", incoming.ReplyThread(), incoming.ConversationKey("T1"))
+		}
+	})
+
+	t.Run("bot and edited messages are ignored", func(t *testing.T) {
+		for _, event := range []*slackevents.MessageEvent{
+			{Channel: "D1", ChannelType: CHANNEL_TYPE_IM, BotID: "B1", Text: "hi"},
+			{Channel: "D1", ChannelType: CHANNEL_TYPE_IM, User: "UBOT", Text: "hi"},
+			{Channel: "D1", ChannelType: CHANNEL_TYPE_IM, User: "U1", SubType: "message_changed"},
+		} {
+			if incoming := toIncomingMessage(event, "UBOT"); incoming != nil {
+				t.Errorf("Expected %+v to be ignored, got %+v", event, incoming)
+			}
+		}
+	})
+}
+
+func TestStripMention(t *testing.T) {
+	if text := stripMention("<@UBOT>  what is our refund policy? ", "UBOT"); text != "what is our refund policy?" {
+		t.Errorf("Unexpected text %q", text)
+	}
+}
@@ -0,0 +1,85 @@
+package slack_service
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/griffnb/techboss-ai-go/internal/models/slack_installation"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+)
+
+// BOT_SCOPES are the bot token scopes requested on install
+const BOT_SCOPES = "app_mentions:read,chat:write,channels:history,groups:history,im:history"
+
+// ErrTeamInstalledElsewhere is returned when the workspace is already connected to another organization
+var ErrTeamInstalledElsewhere = errors.New("slack workspace is connected to another organization")
+
+// Configured reports whether the Slack app credentials are present
+func Configured() bool {
+	config := environment.GetConfig().SlackApp
+	return !tools.Empty(config) && !tools.Empty(config.ClientID) && !tools.Empty(config.SigningSecret)
+}
+
+// InstallURL returns the Slack authorize link for an organization admin to install the app
+func InstallURL(organizationID types.UUID, accountID types.UUID) string {
+	config := environment.GetConfig().SlackApp
+
+	query := url.Values{}
+	query.Set("client_id", config.ClientID)
+	query.Set("scope", BOT_SCOPES)
+	query.Set("redirect_uri", config.RedirectURL)
+	query.Set("state", SignState(config.ClientSecret, organizationID, accountID, time.Now()))
+
+	return "https://slack.com/oauth/v2/authorize?" + query.Encode()
+}
+
+// CompleteInstall exchanges the OAuth code for a bot token and stores the installation for the organization in the state
+func CompleteInstall(ctx context.Context, code string, state string) (*slack_installation.SlackInstallation, error) {
+	config := environment.GetConfig().SlackApp
+
+	organizationID, accountID, err := ParseState(config.ClientSecret, state, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := slack.GetOAuthV2ResponseContext(ctx, http.DefaultClient, config.ClientID, config.ClientSecret, code, config.RedirectURL)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	existing, err := slack_installation.GetByTeamID(ctx, resp.Team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	installation := slack_installation.New()
+	if !tools.Empty(existing) {
+		if existing.OrganizationID.Get() != organizationID {
+			return nil, ErrTeamInstalledElsewhere
+		}
+		installation = &existing.SlackInstallation
+	}
+
+	installation.OrganizationID.Set(organizationID)
+	installation.InstalledByID.Set(accountID)
+	installation.TeamID.Set(resp.Team.ID)
+	installation.TeamName.Set(resp.Team.Name)
+	installation.AppID.Set(resp.AppID)
+	installation.BotUserID.Set(resp.BotUserID)
+	installation.Scope.Set(resp.Scope)
+	installation.SetBotToken(resp.AccessToken)
+	installation.Status.Set(slack_installation.STATUS_ACTIVE)
+
+	err = installation.Save(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return installation, nil
+}
@@ -0,0 +1,174 @@
+package slack_service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/models/agent"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/slack_installation"
+	"github.com/griffnb/techboss-ai-go/internal/services/agent_service"
+	"github.com/griffnb/techboss-ai-go/internal/services/webhook_service"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// UPDATE_INTERVAL throttles edits of the streamed reply, chat.update allows roughly one call per second per channel
+	UPDATE_INTERVAL = time.Second
+	// PLACEHOLDER_TEXT is posted while the agent starts answering
+	PLACEHOLDER_TEXT = "_Thinking…_"
+	// FAILURE_TEXT replaces the reply when the agent could not answer
+	FAILURE_TEXT = "Sorry, I couldn't answer that right now. Please try again."
+)
+
+// Poster posts and edits messages, satisfied by *slack.Client
+type Poster interface {
+	PostMessageContext(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error)
+	UpdateMessageContext(ctx context.Context, channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error)
+}
+
+// HandleMessage answers a message with the agent mapped to its channel, editing the reply in the thread as it streams
+func HandleMessage(
+	ctx context.Context,
+	poster Poster,
+	responder agent_service.StreamingResponder,
+	installation *slack_installation.SlackInstallationJoined,
+	incoming *IncomingMessage,
+) error {
+	agentID, err := installation.AgentForChannel(incoming.Channel, incoming.DirectMessage)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(agentID) {
+		return nil
+	}
+
+	agentObj, err := agent.Get(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(agentObj) || !agentObj.CanBeUsedBy(installation.OrganizationID.Get(), int64(installation.BillingPlanLevel.Get())) {
+		return nil
+	}
+
+	body := stripMention(incoming.Text, installation.BotUserID.Get())
+	if body == "" {
+		return nil
+	}
+
+	conversationObj, err := getOrStartConversation(ctx, installation, agentObj, incoming)
+	if err != nil {
+		return err
+	}
+	if conversationObj == nil {
+		return nil
+	}
+
+	options := []slack.MsgOption{slack.MsgOptionText(PLACEHOLDER_TEXT, false)}
+	if thread := incoming.ReplyThread(); thread != "" {
+		options = append(options, slack.MsgOptionTS(thread))
+	}
+	_, timestamp, err := poster.PostMessageContext(ctx, incoming.Channel, options...)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	updater := newReplyUpdater(poster, incoming.Channel, timestamp)
+	reply, err := agent_service.StreamReply(ctx, responder, agentObj, conversationObj, body, func(delta string) error {
+		return updater.write(ctx, delta)
+	})
+	if err != nil {
+		updateErr := updater.set(ctx, FAILURE_TEXT)
+		if updateErr != nil {
+			log.ErrorContext(updateErr, ctx)
+		}
+		return err
+	}
+
+	return updater.set(ctx, reply.Body)
+}
+
+// getOrStartConversation loads the conversation for the message's thread, starting one when the message may
+func getOrStartConversation(
+	ctx context.Context,
+	installation *slack_installation.SlackInstallationJoined,
+	agentObj *agent.Agent,
+	incoming *IncomingMessage,
+) (*conversation.Conversation, error) {
+	externalID := incoming.ConversationKey(installation.TeamID.Get())
+
+	conversationObj, err := conversation.GetBySourceExternalID(ctx, conversation.SOURCE_SLACK, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if !tools.Empty(conversationObj) {
+		return conversationObj, nil
+	}
+	if !incoming.StartsConversation {
+		return nil, nil
+	}
+
+	conversationObj = conversation.New()
+	conversationObj.OrganizationID.Set(installation.OrganizationID.Get())
+	conversationObj.AgentID.Set(agentObj.ID())
+	conversationObj.Source.Set(conversation.SOURCE_SLACK)
+	conversationObj.ExternalID.Set(externalID)
+	err = conversationObj.Save(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook_service.PublishAndLog(ctx, conversationObj.OrganizationID.Get(), webhook_service.EVENT_CONVERSATION_STARTED,
+		webhook_service.NewConversationData(conversationObj))
+
+	return conversationObj, nil
+}
+
+// replyUpdater edits the placeholder message as text streams in, at most once per UPDATE_INTERVAL
+type replyUpdater struct {
+	poster     Poster
+	channel    string
+	timestamp  string
+	text       strings.Builder
+	lastUpdate time.Time
+	now        func() time.Time
+}
+
+func newReplyUpdater(poster Poster, channel string, timestamp string) *replyUpdater {
+	return &replyUpdater{
+		poster:    poster,
+		channel:   channel,
+		timestamp: timestamp,
+		now:       time.Now,
+	}
+}
+
+// write appends a delta and edits the message if the last edit is old enough
+func (this *replyUpdater) write(ctx context.Context, delta string) error {
+	this.text.WriteString(delta)
+
+	now := this.now()
+	if now.Sub(this.lastUpdate) < UPDATE_INTERVAL {
+		return nil
+	}
+	this.lastUpdate = now
+
+	return this.update(ctx, this.text.String()+" …")
+}
+
+// set replaces the message with its final text
+func (this *replyUpdater) set(ctx context.Context, text string) error {
+	if text == "" {
+		text = FAILURE_TEXT
+	}
+	return this.update(ctx, text)
+}
+
+func (this *replyUpdater) update(ctx context.Context, text string) error {
+	_, _, _, err := this.poster.UpdateMessageContext(ctx, this.channel, this.timestamp, slack.MsgOptionText(text, false))
+	return errors.WithStack(err)
+}
@@ -0,0 +1,61 @@
+package slack_service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+type fakePoster struct {
+	updates []string
+}
+
+func (this *fakePoster) PostMessageContext(_ context.Context, _ string, _ ...slack.MsgOption) (string, string, error) {
+	return "C1", "200.1", nil
+}
+
+func (this *fakePoster) UpdateMessageContext(_ context.Context, _ string, _ string, options ...slack.MsgOption) (string, string, string, error) {
+	_, values, err := slack.UnsafeApplyMsgOptions("", "C1", "", options...)
+	if err != nil {
+		return "", "", "", err
+	}
+	this.updates = append(this.updates, values.Get("text"))
+	return "C1", "200.1", "", nil
+}
+
+func TestReplyUpdater(t *testing.T) {
+	poster := &fakePoster{}
+	clock := time.Unix(1_700_000_000, 0)
+	updater := newReplyUpdater(poster, "C1", "200.1")
+	updater.now = func() time.Time { return clock }
+
+	ctx := context.Background()
+	for _, delta := range []string{"Hel", "lo", " there"} {
+		err := updater.write(ctx, delta)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		clock = clock.Add(400 * time.Millisecond)
+	}
+	clock = clock.Add(UPDATE_INTERVAL)
+	err := updater.write(ctx, "!")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = updater.set(ctx, "Hello there!")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"Hel …", "Hello there! …", "Hello there!"}
+	if len(poster.updates) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, poster.updates)
+	}
+	for i := range expected {
+		if poster.updates[i] != expected[i] {
+			t.Errorf("update %d: expected %q, got %q", i, expected[i], poster.updates[i])
+		}
+	}
+}
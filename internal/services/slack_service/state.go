@@ -0,0 +1,52 @@
+package slack_service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/pkg/errors"
+)
+
+// STATE_TTL is how long an install link stays valid
+const STATE_TTL = 15 * time.Minute
+
+// ErrInvalidState is returned when the OAuth state was not issued by us or has expired
+var ErrInvalidState = errors.New("invalid slack oauth state")
+
+// SignState builds the OAuth state carrying the installing organization and account, signed so the callback can trust it
+func SignState(secret string, organizationID types.UUID, accountID types.UUID, now time.Time) string {
+	payload := fmt.Sprintf("%s.%s.%d", organizationID, accountID, now.Unix())
+	return payload + "." + signState(secret, payload)
+}
+
+// ParseState verifies a state built by SignState and returns the organization and account it carries
+func ParseState(secret string, state string, now time.Time) (types.UUID, types.UUID, error) {
+	parts := strings.Split(state, ".")
+	if len(parts) != 4 {
+		return "", "", ErrInvalidState
+	}
+
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(signState(secret, payload))) {
+		return "", "", ErrInvalidState
+	}
+
+	issuedAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || now.Sub(time.Unix(issuedAt, 0)) > STATE_TTL {
+		return "", "", ErrInvalidState
+	}
+
+	return types.UUID(parts[0]), types.UUID(parts[1]), nil
+}
+
+func signState(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
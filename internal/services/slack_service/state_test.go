@@ -0,0 +1,48 @@
+package slack_service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/griffnb/core/lib/types"
+)
+
+func TestState(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	organizationID := types.UUID("3f1c2a5e-7d4b-4c1e-9a0f-2b6d8e4c1a7f")
+	accountID := types.UUID("9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d")
+
+	state := SignState("secret", organizationID, accountID, now)
+
+	t.Run("round trip", func(t *testing.T) {
+		gotOrganization, gotAccount, err := ParseState("secret", state, now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotOrganization != organizationID || gotAccount != accountID {
+			t.Errorf("Expected %s %s, got %s %s", organizationID, accountID, gotOrganization, gotAccount)
+		}
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, _, err := ParseState("other", state, now)
+		if err != ErrInvalidState {
+			t.Errorf("Expected invalid state, got %v", err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, _, err := ParseState("secret", state, now.Add(STATE_TTL+time.Second))
+		if err != ErrInvalidState {
+			t.Errorf("Expected invalid state, got %v", err)
+		}
+	})
+
+	t.Run("tampered organization", func(t *testing.T) {
+		tampered := "00000000-0000-0000-0000-000000000000" + state[len(organizationID):]
+		_, _, err := ParseState("secret", tampered, now)
+		if err != ErrInvalidState {
+			t.Errorf("Expected invalid state, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package vector_store_service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// File statuses reported while a vector store ingests a file
+const (
+	FILE_STATUS_IN_PROGRESS = "in_progress"
+	FILE_STATUS_COMPLETED   = "completed"
+	FILE_STATUS_CANCELLED   = "cancelled"
+	FILE_STATUS_FAILED      = "failed"
+)
+
+var (
+	// ErrNotConfigured is returned when no provider key is set, syncing is skipped rather than failed
+	ErrNotConfigured = errors.New("vector store provider is not configured")
+	// ErrNotFound is returned by a backend when the store or file no longer exists on the provider
+	ErrNotFound = errors.New("not found on the vector store provider")
+)
+
+// Backend is the provider side of the hosted vector stores. Deleting something already gone is not an error.
+type Backend interface {
+	CreateVectorStore(ctx context.Context, name string, metadata map[string]string) (string, error)
+	VectorStoreExists(ctx context.Context, storeID string) (bool, error)
+	DeleteVectorStore(ctx context.Context, storeID string) error
+	UploadFile(ctx context.Context, name string, data []byte) (string, error)
+	DeleteFile(ctx context.Context, fileID string) error
+	AttachFile(ctx context.Context, storeID string, fileID string, attributes map[string]string) error
+	FileStatus(ctx context.Context, storeID string, fileID string) (*FileStatus, error)
+	DetachFile(ctx context.Context, storeID string, fileID string) error
+	ListFiles(ctx context.Context, storeID string) ([]*StoreFile, error)
+}
+
+// FileStatus is how far a vector store has got ingesting a file, Error is set when it failed
+type FileStatus struct {
+	Status string
+	Error  string
+}
+
+// StoreFile is a file attached to a vector store
+type StoreFile struct {
+	ID          string
+	Status      string
+	CreatedAtTS int64
+}
+
+var (
+	backendLock sync.RWMutex
+	backend     Backend
+)
+
+// SetBackend replaces the backend used for all vector stores, e.g. with a fake in tests
+func SetBackend(newBackend Backend) {
+	backendLock.Lock()
+	defer backendLock.Unlock()
+	backend = newBackend
+}
+
+// GetBackend returns the backend set with SetBackend, defaulting to OpenAI
+func GetBackend() (Backend, error) {
+	backendLock.RLock()
+	current := backend
+	backendLock.RUnlock()
+	if current != nil {
+		return current, nil
+	}
+	return NewOpenAIBackendFromEnv()
+}
@@ -0,0 +1,153 @@
+package vector_store_service
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/griffnb/techboss-ai-go/internal/environment"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/pkg/errors"
+)
+
+// LIST_PAGE_SIZE is how many store files are fetched per page when listing
+const LIST_PAGE_SIZE = 100
+
+// OpenAIBackend keeps files in OpenAI vector stores for the file search tool
+type OpenAIBackend struct {
+	client openai.Client
+}
+
+// NewOpenAIBackendFromEnv builds a backend from the configured OpenAI key
+func NewOpenAIBackendFromEnv() (*OpenAIBackend, error) {
+	apiKey := environment.GetConfig().AIKeys.OpenAI.APIKey
+	if apiKey == "" {
+		return nil, ErrNotConfigured
+	}
+	return &OpenAIBackend{
+		client: openai.NewClient(option.WithAPIKey(apiKey)),
+	}, nil
+}
+
+// CreateVectorStore creates an empty vector store
+func (this *OpenAIBackend) CreateVectorStore(ctx context.Context, name string, metadata map[string]string) (string, error) {
+	store, err := this.client.VectorStores.New(ctx, openai.VectorStoreNewParams{
+		Name:     openai.String(name),
+		Metadata: metadata,
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return store.ID, nil
+}
+
+// VectorStoreExists reports whether the store is still on the provider
+func (this *OpenAIBackend) VectorStoreExists(ctx context.Context, storeID string) (bool, error) {
+	_, err := this.client.VectorStores.Get(ctx, storeID)
+	if err != nil {
+		err = openAIError(err)
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteVectorStore deletes a vector store, the files attached to it are kept
+func (this *OpenAIBackend) DeleteVectorStore(ctx context.Context, storeID string) error {
+	_, err := this.client.VectorStores.Delete(ctx, storeID)
+	return ignoreNotFound(err)
+}
+
+// UploadFile uploads a file for use by assistants
+func (this *OpenAIBackend) UploadFile(ctx context.Context, name string, data []byte) (string, error) {
+	file, err := this.client.Files.New(ctx, openai.FileNewParams{
+		File:    openai.File(bytes.NewReader(data), name, "text/plain"),
+		Purpose: openai.FilePurposeAssistants,
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return file.ID, nil
+}
+
+// DeleteFile deletes an uploaded file, removing it from every store it is attached to
+func (this *OpenAIBackend) DeleteFile(ctx context.Context, fileID string) error {
+	_, err := this.client.Files.Delete(ctx, fileID)
+	return ignoreNotFound(err)
+}
+
+// AttachFile adds an uploaded file to a vector store, ingestion carries on in the background
+func (this *OpenAIBackend) AttachFile(ctx context.Context, storeID string, fileID string, attributes map[string]string) error {
+	params := openai.VectorStoreFileNewParams{FileID: fileID}
+	if len(attributes) > 0 {
+		params.Attributes = map[string]openai.VectorStoreFileNewParamsAttributeUnion{}
+		for key, value := range attributes {
+			params.Attributes[key] = openai.VectorStoreFileNewParamsAttributeUnion{OfString: openai.String(value)}
+		}
+	}
+	_, err := this.client.VectorStores.Files.New(ctx, storeID, params)
+	return errors.WithStack(err)
+}
+
+// FileStatus returns how far the store has got ingesting a file, ErrNotFound when it is not attached
+func (this *OpenAIBackend) FileStatus(ctx context.Context, storeID string, fileID string) (*FileStatus, error) {
+	file, err := this.client.VectorStores.Files.Get(ctx, storeID, fileID)
+	if err != nil {
+		return nil, openAIError(err)
+	}
+	return &FileStatus{
+		Status: string(file.Status),
+		Error:  file.LastError.Message,
+	}, nil
+}
+
+// DetachFile removes a file from a vector store, the uploaded file is kept
+func (this *OpenAIBackend) DetachFile(ctx context.Context, storeID string, fileID string) error {
+	_, err := this.client.VectorStores.Files.Delete(ctx, storeID, fileID)
+	return ignoreNotFound(err)
+}
+
+// ListFiles returns every file attached to a vector store
+func (this *OpenAIBackend) ListFiles(ctx context.Context, storeID string) ([]*StoreFile, error) {
+	pager := this.client.VectorStores.Files.ListAutoPaging(ctx, storeID, openai.VectorStoreFileListParams{
+		Limit: openai.Int(LIST_PAGE_SIZE),
+	})
+
+	files := []*StoreFile{}
+	for pager.Next() {
+		file := pager.Current()
+		files = append(files, &StoreFile{
+			ID:          file.ID,
+			Status:      string(file.Status),
+			CreatedAtTS: file.CreatedAt,
+		})
+	}
+	if err := pager.Err(); err != nil {
+		return nil, openAIError(err)
+	}
+	return files, nil
+}
+
+// openAIError turns a 404 from the API into ErrNotFound
+func openAIError(err error) error {
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return errors.WithStack(err)
+}
+
+// ignoreNotFound drops the error when the thing being deleted is already gone
+func ignoreNotFound(err error) error {
+	if err == nil {
+		return nil
+	}
+	err = openAIError(err)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	return err
+}
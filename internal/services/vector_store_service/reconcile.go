@@ -0,0 +1,263 @@
+package vector_store_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_group"
+	"github.com/pkg/errors"
+)
+
+// ORPHAN_GRACE is how old a store file nothing points at must be before it is deleted, a sync in flight attaches
+// its file before saving it on the document
+const ORPHAN_GRACE = time.Hour
+
+// ReconcileResult counts what reconciling an organization changed
+type ReconcileResult struct {
+	DroppedStores int `json:"dropped_stores"` // deleted with their group, or gone from the provider
+	Removed       int `json:"removed"`        // files of documents that no longer belong in a store
+	Resynced      int `json:"resynced"`       // files missing, failed or in the wrong store, uploaded again
+	Queued        int `json:"queued"`         // indexed documents without a file
+	Refreshed     int `json:"refreshed"`      // files still being ingested, status checked
+	Orphans       int `json:"orphans"`        // store files no document points at
+}
+
+// orphanFile is a store file no document points at
+type orphanFile struct {
+	StoreID string
+	FileID  string
+}
+
+// reconcilePlan is what has to change to bring the provider in line with the documents
+type reconcilePlan struct {
+	dropStores []string
+	remove     []*document.Document
+	resync     []*document.Document
+	queue      []*document.Document
+	refresh    []*document.Document
+	orphans    []*orphanFile
+}
+
+// Reconcile brings an organization's vector stores in line with its documents, fixing what failed syncs, deleted
+// groups or changes made on the provider left behind
+func Reconcile(ctx context.Context, organizationID types.UUID) (*ReconcileResult, error) {
+	backend, err := GetBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	stores, err := Stores(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	liveStores := map[string]bool{}
+	storeFiles := map[string][]*StoreFile{}
+	for key, storeID := range stores {
+		live, err := storeLive(ctx, backend, key, storeID)
+		if err != nil {
+			return nil, err
+		}
+		if !live {
+			continue
+		}
+		liveStores[key] = true
+
+		files, err := backend.ListFiles(ctx, storeID)
+		if err != nil {
+			return nil, err
+		}
+		storeFiles[storeID] = files
+	}
+
+	documents, err := document.FindVectorSynced(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	// documents is a batch, orphans are only judged against every file the organization's documents point at
+	fileIDs, err := document.FindVectorFileIDs(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planReconcile(documents, fileIDs, stores, liveStores, storeFiles, time.Now().Add(-ORPHAN_GRACE).Unix())
+	result := &ReconcileResult{}
+
+	err = DeleteStores(ctx, backend, organizationID, plan.dropStores)
+	if err != nil {
+		return nil, err
+	}
+	result.DroppedStores = len(plan.dropStores)
+
+	for _, documentObj := range plan.remove {
+		err = RemoveDocument(ctx, documentObj)
+		if err != nil {
+			return result, err
+		}
+		result.Removed++
+	}
+
+	for _, documentObj := range plan.resync {
+		// the stale copy is forgotten so the sync uploads and attaches a fresh one
+		err = RemoveDocument(ctx, documentObj)
+		if err != nil {
+			return result, err
+		}
+		err = worker_jobs.QueueDocumentVectorSyncJob(documentObj.ID())
+		if err != nil {
+			return result, err
+		}
+		result.Resynced++
+	}
+
+	for _, documentObj := range plan.queue {
+		err = worker_jobs.QueueDocumentVectorSyncJob(documentObj.ID())
+		if err != nil {
+			return result, err
+		}
+		result.Queued++
+	}
+
+	for _, documentObj := range plan.refresh {
+		_, err = RefreshStatus(ctx, documentObj)
+		if err != nil {
+			return result, err
+		}
+		result.Refreshed++
+	}
+
+	for _, orphan := range plan.orphans {
+		err = backend.DetachFile(ctx, orphan.StoreID, orphan.FileID)
+		if err != nil {
+			return result, err
+		}
+		err = backend.DeleteFile(ctx, orphan.FileID)
+		if err != nil {
+			return result, err
+		}
+		result.Orphans++
+	}
+
+	return result, nil
+}
+
+// ReconcileAll reconciles every organization with documents, one failing does not stop the rest
+func ReconcileAll(ctx context.Context) error {
+	_, err := GetBackend()
+	if errors.Is(err, ErrNotConfigured) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	organizationIDs, err := document.FindOrganizationIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, organizationID := range organizationIDs {
+		result, err := Reconcile(ctx, organizationID)
+		if err != nil {
+			log.ErrorContext(errors.Wrapf(err, "reconciling vector stores of organization %s", organizationID), ctx)
+			continue
+		}
+		log.Debugf("Reconciled vector stores of organization %s %+v", organizationID, result)
+	}
+	return nil
+}
+
+// storeLive reports whether a store should be kept: it is still on the provider and its group was not deleted
+func storeLive(ctx context.Context, backend Backend, key string, storeID string) (bool, error) {
+	if key != ORGANIZATION_STORE_KEY {
+		groupObj, err := document_group.Get(ctx, types.UUID(key))
+		if err != nil {
+			return false, err
+		}
+		if tools.Empty(groupObj) || groupObj.Deleted.Get() == 1 {
+			return false, nil
+		}
+	}
+	return backend.VectorStoreExists(ctx, storeID)
+}
+
+// planReconcile works out what has to change for a batch of documents. fileIDs are all the files the organization's
+// documents point at, liveStores the store keys to keep, storeFiles the files attached to each of them, and store
+// files nothing points at are only orphans once created before orphanBeforeTS.
+func planReconcile(
+	documents []*document.Document,
+	fileIDs []string,
+	stores map[string]string,
+	liveStores map[string]bool,
+	storeFiles map[string][]*StoreFile,
+	orphanBeforeTS int64,
+) *reconcilePlan {
+	plan := &reconcilePlan{}
+	for key := range stores {
+		if !liveStores[key] {
+			plan.dropStores = append(plan.dropStores, key)
+		}
+	}
+
+	attached := map[string]map[string]*StoreFile{}
+	for storeID, files := range storeFiles {
+		attached[storeID] = map[string]*StoreFile{}
+		for _, file := range files {
+			attached[storeID][file.ID] = file
+		}
+	}
+
+	known := map[string]bool{}
+	for _, fileID := range fileIDs {
+		known[fileID] = true
+	}
+	for _, documentObj := range documents {
+		metaData, err := getMetaData(documentObj)
+		if err != nil {
+			continue
+		}
+		if metaData.FileOpenAIID != "" {
+			known[metaData.FileOpenAIID] = true
+		}
+
+		if !Syncable(documentObj) {
+			if metaData.FileOpenAIID != "" || metaData.VectorOpenAIID != "" {
+				plan.remove = append(plan.remove, documentObj)
+			}
+			continue
+		}
+
+		if metaData.FileOpenAIID == "" {
+			plan.queue = append(plan.queue, documentObj)
+			continue
+		}
+
+		key := StoreKey(documentObj.DocumentGroupID.Get())
+		storeID := ""
+		if liveStores[key] {
+			storeID = stores[key]
+		}
+		file := attached[storeID][metaData.FileOpenAIID]
+		switch {
+		case storeID == "" || metaData.VectorOpenAIID != storeID || file == nil:
+			plan.resync = append(plan.resync, documentObj)
+		case file.Status == FILE_STATUS_FAILED || file.Status == FILE_STATUS_CANCELLED:
+			plan.resync = append(plan.resync, documentObj)
+		case file.Status != metaData.VectorOpenAIStatus:
+			plan.refresh = append(plan.refresh, documentObj)
+		}
+	}
+
+	for storeID, files := range storeFiles {
+		for _, file := range files {
+			if !known[file.ID] && file.CreatedAtTS < orphanBeforeTS {
+				plan.orphans = append(plan.orphans, &orphanFile{StoreID: storeID, FileID: file.ID})
+			}
+		}
+	}
+	return plan
+}
@@ -0,0 +1,176 @@
+package vector_store_service
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/constants"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+)
+
+const testGroupID = types.UUID("7c1f2a0e-5d1b-4c2e-9a55-3f0d2b8e6a11")
+
+func newSyncedDocument(name string, groupID types.UUID, metaData *document.MetaData) *document.Document {
+	documentObj := document.New()
+	documentObj.ID_.Set(types.UUID(name))
+	documentObj.Name.Set(name)
+	documentObj.OrganizationID.Set(types.UUID("0b6f9a52-1f0e-4b8a-8d7e-2c4a1e9f3d20"))
+	documentObj.DocumentGroupID.Set(groupID)
+	documentObj.Status.Set(document.STATUS_INDEXED)
+	documentObj.Sharing.Set(document.SHARING_ORGANIZATION)
+	documentObj.DocumentType.Set(document.DOCUMENT_TYPE_FILE)
+	if metaData != nil {
+		documentObj.MetaData.Set(metaData)
+	}
+	return documentObj
+}
+
+func TestSyncable(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(documentObj *document.Document)
+		want   bool
+	}{
+		{"indexed and shared", func(documentObj *document.Document) {}, true},
+		{"private", func(documentObj *document.Document) { documentObj.Sharing.Set(document.SHARING_PRIVATE) }, false},
+		{"deleted", func(documentObj *document.Document) { documentObj.Deleted.Set(1) }, false},
+		{"disabled", func(documentObj *document.Document) { documentObj.Disabled.Set(1) }, false},
+		{"processing", func(documentObj *document.Document) { documentObj.Status.Set(document.STATUS_PROCESSED) }, false},
+		{"superseded", func(documentObj *document.Document) { documentObj.SupersededByID.Set(types.UUID("newer")) }, false},
+		{"sitemap", func(documentObj *document.Document) { documentObj.DocumentType.Set(document.DOCUMENT_TYPE_SITEMAP) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documentObj := newSyncedDocument("doc", "", nil)
+			tt.change(documentObj)
+			if got := Syncable(documentObj); got != tt.want {
+				t.Errorf("Syncable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreKey(t *testing.T) {
+	if got := StoreKey(""); got != ORGANIZATION_STORE_KEY {
+		t.Errorf("StoreKey() = %s, want %s", got, ORGANIZATION_STORE_KEY)
+	}
+	if got := StoreKey(testGroupID); got != string(testGroupID) {
+		t.Errorf("StoreKey() = %s, want %s", got, testGroupID)
+	}
+}
+
+func TestPlanReconcile(t *testing.T) {
+	stores := map[string]string{
+		ORGANIZATION_STORE_KEY: "vs_org",
+		string(testGroupID):    "vs_group",
+	}
+	liveStores := map[string]bool{ORGANIZATION_STORE_KEY: true}
+	storeFiles := map[string][]*StoreFile{
+		"vs_org": {
+			{ID: "file_done", Status: FILE_STATUS_COMPLETED, CreatedAtTS: 10},
+			{ID: "file_ingesting", Status: FILE_STATUS_COMPLETED, CreatedAtTS: 10},
+			{ID: "file_failed", Status: FILE_STATUS_FAILED, CreatedAtTS: 10},
+			{ID: "file_private", Status: FILE_STATUS_COMPLETED, CreatedAtTS: 10},
+			{ID: "file_orphan", Status: FILE_STATUS_COMPLETED, CreatedAtTS: 10},
+			{ID: "file_recent", Status: FILE_STATUS_IN_PROGRESS, CreatedAtTS: 500},
+		},
+	}
+
+	private := newSyncedDocument("private", "", &document.MetaData{FileOpenAIID: "file_private", VectorOpenAIID: "vs_org"})
+	private.Sharing.Set(document.SHARING_PRIVATE)
+	documents := []*document.Document{
+		newSyncedDocument("done", "", &document.MetaData{
+			FileOpenAIID: "file_done", VectorOpenAIID: "vs_org", VectorOpenAIStatus: FILE_STATUS_COMPLETED,
+		}),
+		newSyncedDocument("ingesting", "", &document.MetaData{
+			FileOpenAIID: "file_ingesting", VectorOpenAIID: "vs_org", VectorOpenAIStatus: FILE_STATUS_IN_PROGRESS,
+		}),
+		newSyncedDocument("failed", "", &document.MetaData{
+			FileOpenAIID: "file_failed", VectorOpenAIID: "vs_org", VectorOpenAIStatus: FILE_STATUS_IN_PROGRESS,
+		}),
+		newSyncedDocument("missing", "", &document.MetaData{
+			FileOpenAIID: "file_missing", VectorOpenAIID: "vs_org", VectorOpenAIStatus: FILE_STATUS_COMPLETED,
+		}),
+		newSyncedDocument("moved", "", &document.MetaData{
+			FileOpenAIID: "file_moved", VectorOpenAIID: "vs_group", VectorOpenAIStatus: FILE_STATUS_COMPLETED,
+		}),
+		newSyncedDocument("dropped_group", testGroupID, &document.MetaData{
+			FileOpenAIID: "file_grouped", VectorOpenAIID: "vs_group", VectorOpenAIStatus: FILE_STATUS_COMPLETED,
+		}),
+		newSyncedDocument("unsynced", "", nil),
+		private,
+	}
+
+	plan := planReconcile(documents, []string{"file_done", "file_private"}, stores, liveStores, storeFiles, 100)
+
+	names := func(documents []*document.Document) []string {
+		list := []string{}
+		for _, documentObj := range documents {
+			list = append(list, documentObj.Name.Get())
+		}
+		sort.Strings(list)
+		return list
+	}
+	assertList(t, "dropStores", plan.dropStores, []string{string(testGroupID)})
+	assertList(t, "remove", names(plan.remove), []string{"private"})
+	assertList(t, "resync", names(plan.resync), []string{"dropped_group", "failed", "missing", "moved"})
+	assertList(t, "queue", names(plan.queue), []string{"unsynced"})
+	assertList(t, "refresh", names(plan.refresh), []string{"ingesting"})
+
+	orphans := []string{}
+	for _, orphan := range plan.orphans {
+		orphans = append(orphans, orphan.StoreID+"/"+orphan.FileID)
+	}
+	assertList(t, "orphans", orphans, []string{"vs_org/file_orphan"})
+}
+
+func TestPlanReconcile_MoreDocumentsThanLimit(t *testing.T) {
+	stores := map[string]string{ORGANIZATION_STORE_KEY: "vs_org"}
+	liveStores := map[string]bool{ORGANIZATION_STORE_KEY: true}
+
+	total := constants.SYSTEM_LIMIT + 50
+	fileIDs := []string{}
+	files := []*StoreFile{}
+	documents := []*document.Document{}
+	for i := 0; i < total; i++ {
+		fileID := fmt.Sprintf("file_%d", i)
+		fileIDs = append(fileIDs, fileID)
+		files = append(files, &StoreFile{ID: fileID, Status: FILE_STATUS_COMPLETED, CreatedAtTS: 10})
+		// only a batch of the documents is loaded
+		if i < constants.SYSTEM_LIMIT {
+			documents = append(documents, newSyncedDocument(fmt.Sprintf("doc_%d", i), "", &document.MetaData{
+				FileOpenAIID: fileID, VectorOpenAIID: "vs_org", VectorOpenAIStatus: FILE_STATUS_COMPLETED,
+			}))
+		}
+	}
+	files = append(files, &StoreFile{ID: "file_orphan", Status: FILE_STATUS_COMPLETED, CreatedAtTS: 10})
+
+	plan := planReconcile(documents, fileIDs, stores, liveStores, map[string][]*StoreFile{"vs_org": files}, 100)
+
+	orphans := []string{}
+	for _, orphan := range plan.orphans {
+		orphans = append(orphans, orphan.StoreID+"/"+orphan.FileID)
+	}
+	assertList(t, "orphans", orphans, []string{"vs_org/file_orphan"})
+	if len(plan.resync) != 0 || len(plan.queue) != 0 || len(plan.refresh) != 0 {
+		t.Errorf("unexpected changes resync %d queue %d refresh %d", len(plan.resync), len(plan.queue), len(plan.refresh))
+	}
+}
+
+func assertList(t *testing.T, name string, got []string, want []string) {
+	t.Helper()
+	sort.Strings(got)
+	if len(got) != len(want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+		return
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("%s = %v, want %v", name, got, want)
+			return
+		}
+	}
+}
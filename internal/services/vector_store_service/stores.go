@@ -0,0 +1,139 @@
+package vector_store_service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/document_group"
+	"github.com/griffnb/techboss-ai-go/internal/models/organization"
+	"github.com/pkg/errors"
+)
+
+// ORGANIZATION_STORE_KEY is the key of the store for documents outside any group
+const ORGANIZATION_STORE_KEY = "organization"
+
+// StoreKey is the key an organization's store is saved under in its meta data, one store per document group
+// and one for the documents outside any group
+func StoreKey(groupID types.UUID) string {
+	if tools.Empty(groupID) {
+		return ORGANIZATION_STORE_KEY
+	}
+	return string(groupID)
+}
+
+// StoreFor returns the vector store for an organization's group, creating it on first use. Workers may create one
+// at the same time, only the first saved is kept and the others are deleted again.
+func StoreFor(ctx context.Context, backend Backend, organizationID types.UUID, groupID types.UUID) (string, error) {
+	organizationObj, err := organization.Get(ctx, organizationID)
+	if err != nil {
+		return "", err
+	}
+	if tools.Empty(organizationObj) {
+		return "", errors.Errorf("organization %s not found", organizationID)
+	}
+
+	metaData, err := organizationObj.MetaData.Get()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if metaData == nil {
+		metaData = &organization.MetaData{}
+	}
+
+	key := StoreKey(groupID)
+	if storeID := metaData.VectorStoreIDs[key]; storeID != "" {
+		return storeID, nil
+	}
+
+	name := organizationObj.Name.Get()
+	if !tools.Empty(groupID) {
+		groupObj, err := document_group.Get(ctx, groupID)
+		if err != nil {
+			return "", err
+		}
+		if tools.Empty(groupObj) {
+			return "", errors.Errorf("document group %s not found", groupID)
+		}
+		name = fmt.Sprintf("%s - %s", name, groupObj.Name.Get())
+	}
+
+	storeID, err := backend.CreateVectorStore(ctx, name, map[string]string{
+		"organization_id": string(organizationID),
+		"store_key":       key,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claimedID, err := organization.ClaimVectorStore(ctx, organizationID, key, storeID)
+	if err != nil {
+		return "", err
+	}
+	if claimedID != storeID {
+		err = backend.DeleteVectorStore(ctx, storeID)
+		if err != nil {
+			log.ErrorContext(errors.Wrapf(err, "deleting duplicate vector store %s", storeID), ctx)
+		}
+	}
+	return claimedID, nil
+}
+
+// Stores returns an organization's vector stores by key
+func Stores(ctx context.Context, organizationID types.UUID) (map[string]string, error) {
+	organizationObj, err := organization.Get(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if tools.Empty(organizationObj) {
+		return map[string]string{}, nil
+	}
+
+	metaData, err := organizationObj.MetaData.Get()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if metaData == nil || metaData.VectorStoreIDs == nil {
+		return map[string]string{}, nil
+	}
+	return metaData.VectorStoreIDs, nil
+}
+
+// DeleteStores deletes an organization's stores by key, from the provider and from its meta data
+func DeleteStores(ctx context.Context, backend Backend, organizationID types.UUID, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	stores, err := Stores(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		storeID := stores[key]
+		if storeID == "" {
+			continue
+		}
+		err = backend.DeleteVectorStore(ctx, storeID)
+		if err != nil {
+			return err
+		}
+		err = organization.RemoveVectorStore(ctx, organizationID, key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteGroupStore deletes the store of a deleted document group
+func DeleteGroupStore(ctx context.Context, organizationID types.UUID, groupID types.UUID) error {
+	backend, err := GetBackend()
+	if err != nil {
+		return err
+	}
+	return DeleteStores(ctx, backend, organizationID, []string{StoreKey(groupID)})
+}
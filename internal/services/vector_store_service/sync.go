@@ -0,0 +1,242 @@
+package vector_store_service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/techboss-ai-go/internal/models/document"
+	"github.com/pkg/errors"
+)
+
+const (
+	// INGESTION_TIMEOUT is how long a sync waits for the store to finish a file, reconciliation picks up the rest
+	INGESTION_TIMEOUT = 60 * time.Second
+	// INGESTION_POLL_INTERVAL is how often the file's status is checked while waiting
+	INGESTION_POLL_INTERVAL = 2 * time.Second
+)
+
+// ErrIngestionFailed is returned when the store could not ingest a document's file
+var ErrIngestionFailed = errors.New("vector store could not ingest the file")
+
+// Syncable reports whether a document belongs in its organization's vector store: the current, enabled version of
+// an indexed document shared with the organization. The store is searched on behalf of the whole organization, so
+// private documents are kept out of it. Sitemaps have no text of their own, their pages are synced instead.
+func Syncable(documentObj *document.Document) bool {
+	return documentObj.Deleted.Get() == 0 &&
+		documentObj.Disabled.Get() == 0 &&
+		documentObj.Status.Get() == document.STATUS_INDEXED &&
+		documentObj.Sharing.Get() == document.SHARING_ORGANIZATION &&
+		documentObj.DocumentType.Get() != document.DOCUMENT_TYPE_SITEMAP &&
+		documentObj.IsCurrent() &&
+		!tools.Empty(documentObj.OrganizationID.Get())
+}
+
+// SyncDocument puts a document's extracted text in the vector store of its group, or removes its file when it no
+// longer belongs in one. The text is only uploaded again when it changed and the file only re-attached when the
+// document moved store, the copy it replaces is removed once the new one is in place. It then waits up to
+// INGESTION_TIMEOUT for the store to ingest the file.
+func SyncDocument(ctx context.Context, documentObj *document.Document, text string) error {
+	if !Syncable(documentObj) {
+		return RemoveDocument(ctx, documentObj)
+	}
+
+	backend, err := GetBackend()
+	if err != nil {
+		return err
+	}
+	metaData, err := getMetaData(documentObj)
+	if err != nil {
+		return err
+	}
+
+	storeID, err := StoreFor(ctx, backend, documentObj.OrganizationID.Get(), documentObj.DocumentGroupID.Get())
+	if err != nil {
+		return err
+	}
+
+	oldFileID := metaData.FileOpenAIID
+	oldStoreID := metaData.VectorOpenAIID
+	hash := textHash(text)
+
+	fileID := oldFileID
+	if fileID == "" || metaData.FileOpenAIHash != hash {
+		fileID, err = backend.UploadFile(ctx, fmt.Sprintf("%s.txt", documentObj.ID()), []byte(text))
+		if err != nil {
+			return err
+		}
+	}
+
+	if fileID != oldFileID || storeID != oldStoreID {
+		err = backend.AttachFile(ctx, storeID, fileID, fileAttributes(documentObj))
+		if err != nil {
+			if fileID != oldFileID {
+				logError(ctx, backend.DeleteFile(ctx, fileID))
+			}
+			return err
+		}
+
+		metaData.FileOpenAIID = fileID
+		metaData.FileOpenAIHash = hash
+		metaData.VectorOpenAIID = storeID
+		metaData.VectorOpenAIStatus = FILE_STATUS_IN_PROGRESS
+		metaData.VectorOpenAIError = ""
+		metaData.VectorSyncedAtTS = time.Now().Unix()
+		documentObj.MetaData.Set(metaData)
+		err = documentObj.Save(nil)
+		if err != nil {
+			return err
+		}
+
+		// a copy left behind is found as an orphan by reconciliation
+		if oldFileID != "" && oldFileID != fileID {
+			logError(ctx, backend.DetachFile(ctx, oldStoreID, oldFileID))
+			logError(ctx, backend.DeleteFile(ctx, oldFileID))
+		} else if oldStoreID != "" && oldStoreID != storeID {
+			logError(ctx, backend.DetachFile(ctx, oldStoreID, fileID))
+		}
+	}
+
+	return WaitForIngestion(ctx, documentObj)
+}
+
+// WaitForIngestion polls the store until it has ingested the document's file, giving up quietly after
+// INGESTION_TIMEOUT and failing with ErrIngestionFailed when the store could not ingest it
+func WaitForIngestion(ctx context.Context, documentObj *document.Document) error {
+	deadline := time.Now().Add(INGESTION_TIMEOUT)
+	for {
+		status, err := RefreshStatus(ctx, documentObj)
+		if err != nil {
+			return err
+		}
+		switch status {
+		case FILE_STATUS_COMPLETED, "":
+			return nil
+		case FILE_STATUS_FAILED, FILE_STATUS_CANCELLED:
+			metaData, err := getMetaData(documentObj)
+			if err != nil {
+				return err
+			}
+			return errors.Wrapf(ErrIngestionFailed, "document %s: %s %s", documentObj.ID(), status, metaData.VectorOpenAIError)
+		}
+
+		if time.Now().After(deadline) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(INGESTION_POLL_INTERVAL):
+		}
+	}
+}
+
+// RefreshStatus checks how far the store has got with the document's file and saves it on the document, returning
+// the status, "" when the document has no file. A file missing from its store is reported as failed.
+func RefreshStatus(ctx context.Context, documentObj *document.Document) (string, error) {
+	metaData, err := getMetaData(documentObj)
+	if err != nil {
+		return "", err
+	}
+	if metaData.FileOpenAIID == "" || metaData.VectorOpenAIID == "" {
+		return "", nil
+	}
+
+	backend, err := GetBackend()
+	if err != nil {
+		return "", err
+	}
+
+	fileStatus, err := backend.FileStatus(ctx, metaData.VectorOpenAIID, metaData.FileOpenAIID)
+	if errors.Is(err, ErrNotFound) {
+		fileStatus = &FileStatus{Status: FILE_STATUS_FAILED, Error: "file is missing from the vector store"}
+	} else if err != nil {
+		return "", err
+	}
+
+	if fileStatus.Status == metaData.VectorOpenAIStatus && fileStatus.Error == metaData.VectorOpenAIError {
+		return fileStatus.Status, nil
+	}
+	metaData.VectorOpenAIStatus = fileStatus.Status
+	metaData.VectorOpenAIError = fileStatus.Error
+	documentObj.MetaData.Set(metaData)
+	return fileStatus.Status, documentObj.Save(nil)
+}
+
+// RemoveDocument deletes a document's file from the provider and forgets it, safe to call when it has none
+func RemoveDocument(ctx context.Context, documentObj *document.Document) error {
+	metaData, err := getMetaData(documentObj)
+	if err != nil {
+		return err
+	}
+	if metaData.FileOpenAIID == "" && metaData.VectorOpenAIID == "" {
+		return nil
+	}
+
+	backend, err := GetBackend()
+	if err != nil {
+		return err
+	}
+
+	if metaData.FileOpenAIID != "" {
+		if metaData.VectorOpenAIID != "" {
+			err = backend.DetachFile(ctx, metaData.VectorOpenAIID, metaData.FileOpenAIID)
+			if err != nil {
+				return err
+			}
+		}
+		err = backend.DeleteFile(ctx, metaData.FileOpenAIID)
+		if err != nil {
+			return err
+		}
+	}
+
+	metaData.FileOpenAIID = ""
+	metaData.FileOpenAIHash = ""
+	metaData.VectorOpenAIID = ""
+	metaData.VectorOpenAIStatus = ""
+	metaData.VectorOpenAIError = ""
+	metaData.VectorSyncedAtTS = time.Now().Unix()
+	documentObj.MetaData.Set(metaData)
+	return documentObj.Save(nil)
+}
+
+// fileAttributes are stored with the file in the store so search results can be traced back to the document
+func fileAttributes(documentObj *document.Document) map[string]string {
+	attributes := map[string]string{
+		"document_id":     string(documentObj.ID()),
+		"organization_id": string(documentObj.OrganizationID.Get()),
+		"name":            documentObj.Name.Get(),
+	}
+	if !tools.Empty(documentObj.DocumentGroupID.Get()) {
+		attributes["document_group_id"] = string(documentObj.DocumentGroupID.Get())
+	}
+	return attributes
+}
+
+func getMetaData(documentObj *document.Document) (*document.MetaData, error) {
+	metaData, err := documentObj.MetaData.Get()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if metaData == nil {
+		metaData = &document.MetaData{}
+	}
+	return metaData, nil
+}
+
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// logError logs cleanup failures that should not fail the sync
+func logError(ctx context.Context, err error) {
+	if err != nil {
+		log.ErrorContext(err, ctx)
+	}
+}
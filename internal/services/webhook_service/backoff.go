@@ -0,0 +1,29 @@
+package webhook_service
+
+import "time"
+
+const (
+	// MAX_ATTEMPTS is how many times a delivery is sent before it is marked failed
+	MAX_ATTEMPTS = 8
+	// RETRY_BASE_DELAY is the wait before the first retry, doubled for each later attempt. Retries go through the delay
+	// queue, which is drained every minute, so shorter waits would not be honoured.
+	RETRY_BASE_DELAY = time.Minute
+	// RETRY_MAX_DELAY caps the wait between attempts
+	RETRY_MAX_DELAY = 6 * time.Hour
+)
+
+// RetryDelay returns how long to wait before retrying after the given number of attempts
+func RetryDelay(attempts int64) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	delay := RETRY_BASE_DELAY
+	for i := int64(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= RETRY_MAX_DELAY {
+			return RETRY_MAX_DELAY
+		}
+	}
+	return delay
+}
@@ -0,0 +1,23 @@
+package webhook_service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	cases := map[int64]time.Duration{
+		0: RETRY_BASE_DELAY,
+		1: RETRY_BASE_DELAY,
+		2: 2 * RETRY_BASE_DELAY,
+		4: 8 * RETRY_BASE_DELAY,
+		// a minute doubled past six hours is capped
+		20: RETRY_MAX_DELAY,
+	}
+
+	for attempts, expected := range cases {
+		if delay := RetryDelay(attempts); delay != expected {
+			t.Errorf("attempts %d: expected %s, got %s", attempts, expected, delay)
+		}
+	}
+}
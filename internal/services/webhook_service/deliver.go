@@ -0,0 +1,214 @@
+package webhook_service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/common"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/delay_queue"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/webhook_delivery"
+	"github.com/griffnb/techboss-ai-go/internal/models/webhook_endpoint"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DELIVERY_TIMEOUT bounds how long an endpoint has to answer
+	DELIVERY_TIMEOUT = 10 * time.Second
+	// RESPONSE_BODY_LIMIT is how much of the endpoint's response is kept in the delivery log
+	RESPONSE_BODY_LIMIT = 2048
+)
+
+// ErrEndpointInactive is returned when replaying to a disabled endpoint
+var ErrEndpointInactive = errors.New("webhook endpoint is not active")
+
+// deliveryClient only connects to public addresses, endpoint URLs are chosen by organizations. Redirects are not
+// followed, the redirect itself is the endpoint's answer.
+var deliveryClient = &http.Client{
+	Timeout: DELIVERY_TIMEOUT,
+	Transport: &http.Transport{
+		DialContext:           (&net.Dialer{Timeout: DELIVERY_TIMEOUT, Control: common.PublicAddressOnly}).DialContext,
+		TLSHandshakeTimeout:   DELIVERY_TIMEOUT,
+		ResponseHeaderTimeout: DELIVERY_TIMEOUT,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// ValidateEndpointURL checks an endpoint URL can receive deliveries, only https URLs are accepted
+func ValidateEndpointURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if parsed.Scheme != "https" || parsed.Hostname() == "" {
+		return errors.Errorf("%s is not an https url", rawURL)
+	}
+	return nil
+}
+
+// attemptResult is the outcome of posting a delivery once
+type attemptResult struct {
+	Status int
+	Body   string
+	Err    error
+}
+
+func (this *attemptResult) succeeded() bool {
+	return this.Err == nil && this.Status >= 200 && this.Status < 300
+}
+
+func (this *attemptResult) reason() string {
+	if this.Err != nil {
+		return this.Err.Error()
+	}
+	return fmt.Sprintf("endpoint responded %d", this.Status)
+}
+
+// Deliver posts a queued delivery to its endpoint and records the attempt. Failures are retried with exponential
+// backoff through the delay queue until MAX_ATTEMPTS, and count towards auto disabling the endpoint.
+func Deliver(ctx context.Context, deliveryID types.UUID) error {
+	delivery, err := webhook_delivery.Get(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+	if tools.Empty(delivery) {
+		return nil
+	}
+	// Already settled, the job was queued twice
+	if delivery.Status.Get() == webhook_delivery.STATUS_DELIVERED || delivery.Status.Get() == webhook_delivery.STATUS_FAILED {
+		return nil
+	}
+
+	endpoint, err := webhook_endpoint.Get(ctx, delivery.EndpointID.Get())
+	if err != nil {
+		return err
+	}
+	if tools.Empty(endpoint) || !endpoint.IsActive() {
+		delivery.Status.Set(webhook_delivery.STATUS_FAILED)
+		delivery.Error.Set(ErrEndpointInactive.Error())
+		return delivery.Save(nil)
+	}
+
+	now := time.Now()
+	secrets := []string{}
+	signingSecrets, err := endpoint.Secrets.Get()
+	if err != nil {
+		return err
+	}
+	if signingSecrets != nil {
+		secrets, err = signingSecrets.Active(now)
+		if err != nil {
+			return err
+		}
+	}
+
+	payload, err := delivery.Payload.Get()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	result := &attemptResult{Err: ValidateEndpointURL(endpoint.URL.Get())}
+	if result.Err == nil {
+		result = send(ctx, deliveryClient, endpoint.URL.Get(), secrets, delivery, body, now)
+	}
+
+	delivery.Attempts.Set(delivery.Attempts.Get() + 1)
+	delivery.ResponseStatus.Set(int64(result.Status))
+	delivery.ResponseBody.Set(result.Body)
+
+	if result.succeeded() {
+		delivery.Status.Set(webhook_delivery.STATUS_DELIVERED)
+		delivery.DeliveredAt.Set(now.Unix())
+		delivery.Error.Set("")
+		if endpoint.FailureCount.Get() > 0 {
+			endpoint.RecordSuccess()
+			err = endpoint.Save(nil)
+			if err != nil {
+				return err
+			}
+		}
+		return delivery.Save(nil)
+	}
+
+	delivery.Error.Set(result.reason())
+	endpoint.RecordFailure(result.reason())
+	err = endpoint.Save(nil)
+	if err != nil {
+		return err
+	}
+
+	if delivery.Attempts.Get() >= MAX_ATTEMPTS || !endpoint.IsActive() {
+		delivery.Status.Set(webhook_delivery.STATUS_FAILED)
+		return delivery.Save(nil)
+	}
+
+	nextAttemptAt := now.Add(RetryDelay(delivery.Attempts.Get())).Unix()
+	delivery.Status.Set(webhook_delivery.STATUS_RETRYING)
+	delivery.NextAttemptAt.Set(nextAttemptAt)
+	err = delivery.Save(nil)
+	if err != nil {
+		return err
+	}
+
+	return delay_queue.NewItem(worker_jobs.WEBHOOK_DELIVERY, nextAttemptAt, &worker_jobs.WebhookDeliveryJob{
+		DeliveryID: delivery.ID(),
+	}).Save(ctx)
+}
+
+// send posts the signed body to the endpoint once
+func send(
+	ctx context.Context,
+	client *http.Client,
+	url string,
+	secrets []string,
+	delivery *webhook_delivery.WebhookDelivery,
+	body []byte,
+	now time.Time,
+) *attemptResult {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return &attemptResult{Err: errors.WithStack(err)}
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TechBoss-Webhooks/1.0")
+	req.Header.Set(HEADER_SIGNATURE, SignatureHeader(secrets, now.Unix(), body))
+	req.Header.Set(HEADER_EVENT_TYPE, delivery.EventType.Get())
+	req.Header.Set(HEADER_EVENT_ID, delivery.EventID.Get())
+	req.Header.Set(HEADER_DELIVERY_ID, delivery.ID().String())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &attemptResult{Err: errors.WithStack(err)}
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.ErrorContext(closeErr, ctx)
+		}
+	}()
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, RESPONSE_BODY_LIMIT))
+	if err != nil {
+		return &attemptResult{Status: resp.StatusCode, Err: errors.WithStack(err)}
+	}
+
+	return &attemptResult{Status: resp.StatusCode, Body: string(responseBody)}
+}
@@ -0,0 +1,27 @@
+package webhook_service
+
+import "slices"
+
+// Event types organizations can subscribe their endpoints to
+const (
+	EVENT_CONVERSATION_STARTED = "conversation.started"
+	EVENT_LEAD_CAPTURED        = "lead.captured"
+	EVENT_SUBSCRIPTION_UPDATED = "subscription.updated"
+)
+
+// EventTypes lists every event type that can be delivered
+var EventTypes = []string{
+	EVENT_CONVERSATION_STARTED,
+	EVENT_LEAD_CAPTURED,
+	EVENT_SUBSCRIPTION_UPDATED,
+}
+
+// ValidEventTypes reports whether every type in the filter is a known event type
+func ValidEventTypes(eventTypes []string) bool {
+	for _, eventType := range eventTypes {
+		if !slices.Contains(EventTypes, eventType) {
+			return false
+		}
+	}
+	return true
+}
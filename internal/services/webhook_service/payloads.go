@@ -0,0 +1,68 @@
+package webhook_service
+
+import (
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/models/conversation"
+	"github.com/griffnb/techboss-ai-go/internal/models/subscription"
+	"github.com/griffnb/techboss-ai-go/internal/models/widget_lead"
+)
+
+// ConversationData is the data of conversation events
+type ConversationData struct {
+	ID        types.UUID `json:"id"`
+	AgentID   types.UUID `json:"agent_id"`
+	AccountID types.UUID `json:"account_id,omitempty"`
+	Source    int64      `json:"source"`
+}
+
+// NewConversationData builds the event data for a conversation
+func NewConversationData(conversationObj *conversation.Conversation) *ConversationData {
+	return &ConversationData{
+		ID:        conversationObj.ID(),
+		AgentID:   conversationObj.AgentID.Get(),
+		AccountID: conversationObj.AccountID.Get(),
+		Source:    int64(conversationObj.Source.Get()),
+	}
+}
+
+// LeadData is the data of lead events
+type LeadData struct {
+	ID             types.UUID `json:"id"`
+	Email          string     `json:"email"`
+	Name           string     `json:"name,omitempty"`
+	Phone          string     `json:"phone,omitempty"`
+	ConversationID types.UUID `json:"conversation_id,omitempty"`
+	AgentID        types.UUID `json:"agent_id,omitempty"`
+}
+
+// NewLeadData builds the event data for a lead captured in a conversation
+func NewLeadData(leadObj *widget_lead.WidgetLead, conversationObj *conversation.Conversation) *LeadData {
+	return &LeadData{
+		ID:             leadObj.ID(),
+		Email:          leadObj.Email.Get(),
+		Name:           leadObj.Name.Get(),
+		Phone:          leadObj.Phone.Get(),
+		ConversationID: conversationObj.ID(),
+		AgentID:        conversationObj.AgentID.Get(),
+	}
+}
+
+// SubscriptionData is the data of subscription events
+type SubscriptionData struct {
+	ID                 types.UUID `json:"id"`
+	Status             int64      `json:"status"`
+	BillingPlanPriceID types.UUID `json:"billing_plan_price_id"`
+	InTrial            bool       `json:"in_trial"`
+	EndTS              int64      `json:"end_ts,omitempty"`
+}
+
+// NewSubscriptionData builds the event data for a subscription
+func NewSubscriptionData(subObj *subscription.Subscription) *SubscriptionData {
+	return &SubscriptionData{
+		ID:                 subObj.ID(),
+		Status:             int64(subObj.Status.Get()),
+		BillingPlanPriceID: subObj.BillingPlanPriceID.Get(),
+		InTrial:            subObj.InTrial.Get() == 1,
+		EndTS:              subObj.EndTS.Get(),
+	}
+}
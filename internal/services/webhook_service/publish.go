@@ -0,0 +1,132 @@
+package webhook_service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/griffnb/core/lib/log"
+	"github.com/griffnb/core/lib/tools"
+	"github.com/griffnb/core/lib/types"
+	"github.com/griffnb/techboss-ai-go/internal/cron/taskworker/worker_jobs"
+	"github.com/griffnb/techboss-ai-go/internal/models/webhook_delivery"
+	"github.com/griffnb/techboss-ai-go/internal/models/webhook_endpoint"
+	"github.com/pkg/errors"
+)
+
+// Event is the envelope posted to endpoints
+type Event struct {
+	ID             string     `json:"id"`
+	Type           string     `json:"type"`
+	OrganizationID types.UUID `json:"organization_id"`
+	CreatedAt      int64      `json:"created_at"`
+	Data           any        `json:"data"`
+}
+
+// Publish records a delivery for every active endpoint of the organization subscribed to the event and queues them
+func Publish(ctx context.Context, organizationID types.UUID, eventType string, data any) error {
+	if tools.Empty(organizationID) {
+		return nil
+	}
+
+	endpoints, err := webhook_endpoint.FindActiveByOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	event := &Event{
+		ID:             "evt_" + tools.SessionKey(),
+		Type:           eventType,
+		OrganizationID: organizationID,
+		CreatedAt:      time.Now().Unix(),
+		Data:           data,
+	}
+	payload, err := toPayload(event)
+	if err != nil {
+		return err
+	}
+
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribes(eventType) {
+			continue
+		}
+
+		delivery := webhook_delivery.New()
+		delivery.EndpointID.Set(endpoint.ID())
+		delivery.OrganizationID.Set(organizationID)
+		delivery.EventID.Set(event.ID)
+		delivery.EventType.Set(eventType)
+		delivery.Payload.Set(payload)
+		delivery.Status.Set(webhook_delivery.STATUS_PENDING)
+		err = delivery.Save(nil)
+		if err != nil {
+			return err
+		}
+
+		err = worker_jobs.QueueWebhookDeliveryJob(delivery.ID())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PublishAndLog publishes an event on behalf of a caller that should not fail when webhooks do
+func PublishAndLog(ctx context.Context, organizationID types.UUID, eventType string, data any) {
+	err := Publish(ctx, organizationID, eventType, data)
+	if err != nil {
+		log.ErrorContext(errors.Wrapf(err, "publish %s", eventType), ctx)
+	}
+}
+
+// Replay queues a new delivery of a previously sent event to the same endpoint
+func Replay(ctx context.Context, original *webhook_delivery.WebhookDelivery) (*webhook_delivery.WebhookDelivery, error) {
+	endpoint, err := webhook_endpoint.Get(ctx, original.EndpointID.Get())
+	if err != nil {
+		return nil, err
+	}
+	if tools.Empty(endpoint) || !endpoint.IsActive() {
+		return nil, ErrEndpointInactive
+	}
+
+	payload, err := original.Payload.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := webhook_delivery.New()
+	delivery.EndpointID.Set(original.EndpointID.Get())
+	delivery.OrganizationID.Set(original.OrganizationID.Get())
+	delivery.EventID.Set(original.EventID.Get())
+	delivery.EventType.Set(original.EventType.Get())
+	delivery.Payload.Set(payload)
+	delivery.ReplayOfID.Set(original.ID())
+	delivery.Status.Set(webhook_delivery.STATUS_PENDING)
+	err = delivery.Save(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = worker_jobs.QueueWebhookDeliveryJob(delivery.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// toPayload converts the event to the map stored on the delivery, so replays post the same body
+func toPayload(event *Event) (map[string]any, error) {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	payload := map[string]any{}
+	err = json.Unmarshal(bytes, &payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,67 @@
+package webhook_service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Headers sent with every delivery
+const (
+	HEADER_SIGNATURE   = "X-TechBoss-Signature"
+	HEADER_EVENT_TYPE  = "X-TechBoss-Event"
+	HEADER_EVENT_ID    = "X-TechBoss-Event-ID"
+	HEADER_DELIVERY_ID = "X-TechBoss-Delivery"
+)
+
+// Sign computes the hex HMAC-SHA256 of "<timestamp>.<body>" with the secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeader builds the signature header value, one v1 entry per active secret so receivers
+// keep verifying while a rotated secret is still in its grace period
+func SignatureHeader(secrets []string, timestamp int64, body []byte) string {
+	parts := []string{fmt.Sprintf("t=%d", timestamp)}
+	for _, secret := range secrets {
+		parts = append(parts, "v1="+Sign(secret, timestamp, body))
+	}
+	return strings.Join(parts, ",")
+}
+
+// VerifySignature checks a signature header against a secret, the way a receiver would
+func VerifySignature(header string, secret string, body []byte) bool {
+	var timestamp int64
+	signatures := []string{}
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return false
+			}
+			timestamp = parsed
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	expected := Sign(secret, timestamp, body)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package webhook_service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSignatureHeader(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+
+	t.Run("single secret verifies", func(t *testing.T) {
+		header := SignatureHeader([]string{"whsec_current"}, 1700000000, body)
+		if !strings.HasPrefix(header, "t=1700000000,v1=") {
+			t.Errorf("Expected timestamp and signature, got %s", header)
+		}
+		if !VerifySignature(header, "whsec_current", body) {
+			t.Errorf("Expected signature to verify")
+		}
+	})
+
+	t.Run("rotation signs with both secrets", func(t *testing.T) {
+		header := SignatureHeader([]string{"whsec_new", "whsec_old"}, 1700000000, body)
+		if strings.Count(header, "v1=") != 2 {
+			t.Errorf("Expected two signatures, got %s", header)
+		}
+		if !VerifySignature(header, "whsec_new", body) || !VerifySignature(header, "whsec_old", body) {
+			t.Errorf("Expected both secrets to verify")
+		}
+	})
+
+	t.Run("wrong secret or body fails", func(t *testing.T) {
+		header := SignatureHeader([]string{"whsec_current"}, 1700000000, body)
+		if VerifySignature(header, "whsec_other", body) {
+			t.Errorf("Expected wrong secret to fail")
+		}
+		if VerifySignature(header, "whsec_current", []byte(`{"id":"evt_2"}`)) {
+			t.Errorf("Expected tampered body to fail")
+		}
+	})
+
+	t.Run("timestamp is part of the signature", func(t *testing.T) {
+		header := SignatureHeader([]string{"whsec_current"}, 1700000000, body)
+		tampered := strings.Replace(header, "t=1700000000", "t=1700000001", 1)
+		if VerifySignature(tampered, "whsec_current", body) {
+			t.Errorf("Expected changed timestamp to fail")
+		}
+	})
+}